@@ -7,6 +7,7 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/awesome-gocui/gocui"
@@ -24,35 +25,142 @@ var (
 )
 
 type cliOptions struct {
+	command      string
 	doctor       bool
 	doctorStrict bool
 	doctorJSON   bool
 	projectDir   string
+	executor     string
 	showVersion  bool
+	erd          erdOptions
+}
+
+type erdOptions struct {
+	format      string
+	apps        []string
+	focus       string
+	depth       int
+	depthSet    bool
+	hideContrib bool
+	output      string
 }
 
 func usage() string {
 	return `Usage: lazy-django [options]
+       lazy-django erd [erd options] [--project <dir>]
 
 Options:
   --doctor         Run dependency preflight checks and exit
   --doctor-strict  Exit non-zero if required dependencies are missing (with --doctor)
   --doctor-json    Emit doctor output as JSON (with --doctor)
   --project <dir>  Project directory to inspect (default: current directory)
+  --executor <b>   Run manage.py via python, venv, uv, poetry, docker-exec, docker-run, or ssh
   -v, --version    Show version
   -h, --help       Show help
+
+ERD options:
+  --format <fmt>   mermaid (default), dot, or plantuml
+  --app <label>    Only include models from this app (repeatable)
+  --focus <model>  Center the diagram on app.Model
+  --depth <n>      Relation hops from --focus (default: 1)
+  --hide-contrib   Hide django.contrib models
+  --output <file>  Write the diagram to a file instead of stdout
 `
 }
 
+// optionValue returns the value for a flag given as "--name value" or "--name=value".
+func optionValue(args []string, i *int, name string) (string, bool, error) {
+	arg := args[*i]
+	if arg == name {
+		if *i+1 >= len(args) {
+			return "", true, fmt.Errorf("%s requires a value", name)
+		}
+		*i++
+		return args[*i], true, nil
+	}
+	if strings.HasPrefix(arg, name+"=") {
+		value := strings.TrimPrefix(arg, name+"=")
+		if value == "" {
+			return "", true, fmt.Errorf("%s requires a value", name)
+		}
+		return value, true, nil
+	}
+	return "", false, nil
+}
+
+func parseERDOption(opts *erdOptions, args []string, i *int) (bool, error) {
+	if args[*i] == "--hide-contrib" {
+		opts.hideContrib = true
+		return true, nil
+	}
+	for _, name := range []string{"--format", "--app", "--focus", "--depth", "--output"} {
+		value, ok, err := optionValue(args, i, name)
+		if !ok {
+			continue
+		}
+		if err != nil {
+			return true, err
+		}
+		switch name {
+		case "--format":
+			format, err := django.NormalizeERFormat(value)
+			if err != nil {
+				return true, err
+			}
+			opts.format = format
+		case "--app":
+			opts.apps = append(opts.apps, value)
+		case "--focus":
+			opts.focus = value
+		case "--depth":
+			depth, err := strconv.Atoi(value)
+			if err != nil || depth < 0 {
+				return true, fmt.Errorf("--depth requires a non-negative integer")
+			}
+			opts.depth = depth
+			opts.depthSet = true
+		case "--output":
+			opts.output = value
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func validExecutor(name string) bool {
+	if name == "auto" {
+		return true
+	}
+	for _, backend := range django.ExecutorBackends {
+		if name == backend {
+			return true
+		}
+	}
+	return false
+}
+
 func versionString() string {
 	return fmt.Sprintf("lazy-django %s (commit=%s date=%s builtBy=%s)", version, commit, date, builtBy)
 }
 
 func parseOptions(args []string) (cliOptions, error) {
 	var opts cliOptions
+	if len(args) > 0 && args[0] == "erd" {
+		opts.command = "erd"
+		args = args[1:]
+	}
 
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
+		if opts.command == "erd" {
+			handled, err := parseERDOption(&opts.erd, args, &i)
+			if err != nil {
+				return opts, err
+			}
+			if handled {
+				continue
+			}
+		}
 		switch arg {
 		case "--doctor":
 			opts.doctor = true
@@ -71,6 +179,16 @@ func parseOptions(args []string) (cliOptions, error) {
 		case "-h", "--help":
 			return opts, errShowHelp
 		default:
+			if value, ok, err := optionValue(args, &i, "--executor"); ok {
+				if err != nil {
+					return opts, err
+				}
+				if !validExecutor(value) {
+					return opts, fmt.Errorf("unknown executor %q (expected auto, %s)", value, strings.Join(django.ExecutorBackends, ", "))
+				}
+				opts.executor = value
+				continue
+			}
 			if strings.HasPrefix(arg, "--project=") {
 				opts.projectDir = strings.TrimPrefix(arg, "--project=")
 				if opts.projectDir == "" {
@@ -85,6 +203,12 @@ func parseOptions(args []string) (cliOptions, error) {
 	if (opts.doctorStrict || opts.doctorJSON) && !opts.doctor {
 		return opts, fmt.Errorf("--doctor-strict/--doctor-json require --doctor")
 	}
+	if opts.command == "erd" && opts.doctor {
+		return opts, fmt.Errorf("--doctor cannot be combined with erd")
+	}
+	if opts.erd.focus != "" && !opts.erd.depthSet {
+		opts.erd.depth = 1
+	}
 
 	return opts, nil
 }
@@ -125,6 +249,8 @@ func main() {
 	discoveryOpts := django.DiscoverOptions{
 		// Keep normal GUI startup fast; the GUI hydrates deep metadata asynchronously.
 		DeepScan: opts.doctor,
+		// Discovery applies the override so the first manage.py call already uses it.
+		Executor: opts.executor,
 	}
 	project, err := django.DiscoverProjectWithOptions(startDir, discoveryOpts)
 	if err != nil {
@@ -133,6 +259,11 @@ func main() {
 		os.Exit(1)
 	}
 
+	if opts.executor != "" && project.ExecutorError != "" {
+		fmt.Fprintf(os.Stderr, "Error: executor %s: %s\n", opts.executor, project.ExecutorError)
+		os.Exit(2)
+	}
+
 	if debug {
 		fmt.Fprintf(os.Stderr, "[DEBUG] Project discovered: %s\n", project.RootDir)
 		fmt.Fprintf(os.Stderr, "[DEBUG] Apps: %d, Models: %d\n", len(project.Apps), len(project.Models))
@@ -157,6 +288,14 @@ func main() {
 		return
 	}
 
+	if opts.command == "erd" {
+		if err := runERDExport(project, opts.erd); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Run GUI
 	if err := gui.RunWithVersion(project, version); err != nil {
 		if errors.Is(err, gocui.ErrQuit) {
@@ -166,3 +305,33 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+func runERDExport(project *django.Project, opts erdOptions) error {
+	graph, err := django.NewDataViewer(project).GetERGraph()
+	if err != nil {
+		return err
+	}
+	filtered, err := graph.Filter(django.ERFilter{
+		Apps:        opts.apps,
+		Focus:       opts.focus,
+		Depth:       opts.depth,
+		HideContrib: opts.hideContrib,
+	})
+	if err != nil {
+		return err
+	}
+	diagram, err := django.RenderERDiagram(filtered, opts.format)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "" {
+		fmt.Fprint(os.Stdout, diagram)
+		return nil
+	}
+	if err := os.WriteFile(opts.output, []byte(diagram), 0644); err != nil {
+		return fmt.Errorf("failed to write diagram: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %d models to %s\n", len(filtered.Models), opts.output)
+	return nil
+}
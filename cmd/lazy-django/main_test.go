@@ -52,3 +52,46 @@ func TestVersionString(t *testing.T) {
 		t.Fatalf("expected %q, got %q", want, got)
 	}
 }
+
+func TestParseOptionsERDSubcommand(t *testing.T) {
+	opts, err := parseOptions([]string{"erd", "--format=dot", "--app", "blog", "--app=shop", "--focus", "blog.Post", "--hide-contrib", "--output", "erd.dot", "--project", "/tmp/demo"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if opts.command != "erd" || opts.projectDir != "/tmp/demo" {
+		t.Fatalf("unexpected command options: %+v", opts)
+	}
+	erd := opts.erd
+	if erd.format != "dot" || len(erd.apps) != 2 || erd.focus != "blog.Post" || !erd.hideContrib || erd.output != "erd.dot" {
+		t.Fatalf("unexpected erd options: %+v", erd)
+	}
+	if erd.depth != 1 {
+		t.Fatalf("expected focus to default depth to 1, got %d", erd.depth)
+	}
+}
+
+func TestParseOptionsERDRejectsInvalidValues(t *testing.T) {
+	if _, err := parseOptions([]string{"erd", "--format", "svg"}); err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+	if _, err := parseOptions([]string{"erd", "--depth", "-1"}); err == nil {
+		t.Fatal("expected error for negative depth")
+	}
+	if _, err := parseOptions([]string{"--format", "dot"}); err == nil {
+		t.Fatal("expected erd options to require the erd subcommand")
+	}
+}
+
+func TestParseOptionsExecutor(t *testing.T) {
+	opts, err := parseOptions([]string{"--executor", "uv"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if opts.executor != "uv" {
+		t.Fatalf("expected executor=uv, got %q", opts.executor)
+	}
+
+	if _, err := parseOptions([]string{"--executor=conda"}); err == nil {
+		t.Fatal("expected unknown executor to be rejected")
+	}
+}
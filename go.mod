@@ -2,7 +2,10 @@ module github.com/williamblackie/lazydjango
 
 go 1.21
 
-require github.com/awesome-gocui/gocui v1.1.0
+require (
+	github.com/awesome-gocui/gocui v1.1.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/gdamore/encoding v1.0.0 // indirect
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProjectConfigFileName is the per-project config file under .lazy-django/
+const ProjectConfigFileName = "config.json"
+
+// ProjectConfig contains settings stored with a project
+type ProjectConfig struct {
+	Executor  ExecutorConfig  `json:"executor"`
+	Timeouts  TimeoutConfig   `json:"timeouts"`
+	Server    ServerConfig    `json:"server"`
+	Processes ProcessesConfig `json:"processes"`
+}
+
+// ExecutorConfig selects how manage.py commands are run
+type ExecutorConfig struct {
+	Backend string `json:"backend,omitempty"` // python, venv, uv, poetry, docker-exec, docker-run, ssh; empty auto-detects
+	Python  string `json:"python,omitempty"`  // Interpreter for python/venv/ssh backends
+	Service string `json:"service,omitempty"` // Compose service for docker backends
+	Host    string `json:"host,omitempty"`    // ssh destination, e.g. dev@devbox
+	Dir     string `json:"dir,omitempty"`     // Project directory on the ssh host
+}
+
+// ServerConfig controls where the dev server binds and which server profiles exist
+type ServerConfig struct {
+	Host     string          `json:"host,omitempty"`     // Defaults to 127.0.0.1, or 0.0.0.0 when manage.py runs in a container or over ssh
+	Port     int             `json:"port,omitempty"`     // Defaults to 8000
+	Default  string          `json:"default,omitempty"`  // Profile started by "Start dev server"; defaults to runserver
+	Profiles []ServerProfile `json:"profiles,omitempty"` // Added to, or replacing by name, the built-in profiles
+}
+
+// ServerProfile is one way of serving the project
+type ServerProfile struct {
+	Name   string            `json:"name"`
+	Runner string            `json:"runner"`           // runserver, runserver_plus, gunicorn, uvicorn, daphne
+	App    string            `json:"app,omitempty"`    // WSGI/ASGI application, e.g. mysite.asgi:application; detected when empty
+	Host   string            `json:"host,omitempty"`   // Overrides the server host
+	Port   int               `json:"port,omitempty"`   // Overrides the server port
+	Args   []string          `json:"args,omitempty"`   // Extra arguments after the defaults
+	Env    map[string]string `json:"env,omitempty"`    // Extra environment variables
+	Reload *bool             `json:"reload,omitempty"` // Auto-reload on code changes; defaults to true
+}
+
+// ProcessesConfig lists auxiliary processes (workers, asset watchers) run next to the server
+type ProcessesConfig struct {
+	Procfile string          `json:"procfile,omitempty"` // Procfile to import; defaults to Procfile.dev, then Procfile
+	List     []ProcessConfig `json:"list,omitempty"`     // Added to, or replacing by name, the Procfile entries
+}
+
+// ProcessConfig is one named long-running process
+type ProcessConfig struct {
+	Name    string            `json:"name"`
+	Command string            `json:"command"`       // Shell command, run from the project root
+	Dir     string            `json:"dir,omitempty"` // Working directory relative to the project root
+	Env     map[string]string `json:"env,omitempty"` // Extra environment variables
+}
+
+// Operations with their own timeout
+const (
+	TimeoutDiscovery = "discovery"
+	TimeoutCommand   = "command"
+	TimeoutShell     = "shell"
+	TimeoutSnapshot  = "snapshot"
+)
+
+var defaultTimeouts = map[string]time.Duration{
+	TimeoutDiscovery: 2 * time.Minute,
+	TimeoutCommand:   30 * time.Minute,
+	TimeoutShell:     2 * time.Minute,
+	TimeoutSnapshot:  30 * time.Minute,
+}
+
+// TimeoutConfig limits how long background operations may run.
+// Values are Go durations such as "90s" or "10m"; "0" or "off" disables the limit.
+type TimeoutConfig struct {
+	Discovery string `json:"discovery,omitempty"` // Settings, apps and migrations discovery
+	Command   string `json:"command,omitempty"`   // manage.py commands run from the UI
+	Shell     string `json:"shell,omitempty"`     // Data viewer and schema shell scripts
+	Snapshot  string `json:"snapshot,omitempty"`  // Snapshot create and restore
+}
+
+// Duration returns the timeout for an operation, falling back to the default for empty or invalid values
+func (t TimeoutConfig) Duration(op string) time.Duration {
+	var raw string
+	switch op {
+	case TimeoutDiscovery:
+		raw = t.Discovery
+	case TimeoutCommand:
+		raw = t.Command
+	case TimeoutShell:
+		raw = t.Shell
+	case TimeoutSnapshot:
+		raw = t.Snapshot
+	}
+
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	switch raw {
+	case "":
+		return defaultTimeouts[op]
+	case "0", "off", "none":
+		return 0
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil || d < 0 {
+		return defaultTimeouts[op]
+	}
+	return d
+}
+
+// ProjectConfigPath returns the config file path for a project root
+func ProjectConfigPath(rootDir string) string {
+	return filepath.Join(rootDir, ".lazy-django", ProjectConfigFileName)
+}
+
+// LoadProjectConfig reads the project config, returning an empty config when the file is missing
+func LoadProjectConfig(rootDir string) (*ProjectConfig, error) {
+	cfg := &ProjectConfig{}
+	data, err := os.ReadFile(ProjectConfigPath(rootDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return &ProjectConfig{}, fmt.Errorf("invalid project config: %w", err)
+	}
+	return cfg, nil
+}
+
+// SaveProjectConfig writes the project config
+func SaveProjectConfig(rootDir string, cfg *ProjectConfig) error {
+	path := ProjectConfigPath(rootDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
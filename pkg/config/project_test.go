@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestProjectConfigRoundTrip tests saving and loading the project config
+func TestProjectConfigRoundTrip(t *testing.T) {
+	root := t.TempDir()
+
+	cfg, err := LoadProjectConfig(root)
+	if err != nil {
+		t.Fatalf("expected missing config to load empty, got %v", err)
+	}
+	if cfg.Executor.Backend != "" {
+		t.Fatalf("expected empty backend, got %q", cfg.Executor.Backend)
+	}
+
+	cfg.Executor = ExecutorConfig{Backend: "ssh", Host: "dev@box", Dir: "/srv/app"}
+	cfg.Server = ServerConfig{Host: "0.0.0.0", Port: 8080, Default: "asgi", Profiles: []ServerProfile{
+		{Name: "asgi", Runner: "uvicorn", Args: []string{"--workers", "2"}, Env: map[string]string{"DEBUG": "1"}},
+	}}
+	if err := SaveProjectConfig(root, cfg); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+
+	loaded, err := LoadProjectConfig(root)
+	if err != nil {
+		t.Fatalf("LoadProjectConfig failed: %v", err)
+	}
+	if loaded.Executor != cfg.Executor {
+		t.Errorf("Expected %+v, got %+v", cfg.Executor, loaded.Executor)
+	}
+	if !reflect.DeepEqual(loaded.Server, cfg.Server) {
+		t.Errorf("Expected %+v, got %+v", cfg.Server, loaded.Server)
+	}
+}
+
+// TestProjectConfigInvalidJSON tests that a broken config file reports an error
+func TestProjectConfigInvalidJSON(t *testing.T) {
+	root := t.TempDir()
+	if err := SaveProjectConfig(root, &ProjectConfig{}); err != nil {
+		t.Fatalf("SaveProjectConfig failed: %v", err)
+	}
+	if err := os.WriteFile(ProjectConfigPath(root), []byte("{"), 0644); err != nil {
+		t.Fatalf("failed to corrupt config: %v", err)
+	}
+	if _, err := LoadProjectConfig(root); err == nil {
+		t.Error("Expected invalid JSON to fail")
+	}
+}
+
+// TestTimeoutConfigDuration tests timeout defaults, overrides and disabling
+func TestTimeoutConfigDuration(t *testing.T) {
+	timeouts := TimeoutConfig{Command: "90s", Shell: "off", Snapshot: "soon"}
+
+	cases := map[string]time.Duration{
+		TimeoutDiscovery: 2 * time.Minute,
+		TimeoutCommand:   90 * time.Second,
+		TimeoutShell:     0,
+		TimeoutSnapshot:  30 * time.Minute,
+		"unknown":        0,
+	}
+	for op, want := range cases {
+		if got := timeouts.Duration(op); got != want {
+			t.Errorf("Duration(%q) = %v, want %v", op, got, want)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package django
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ManagementCommand is a manage.py subcommand and the app that provides it
+type ManagementCommand struct {
+	Name      string
+	App       string
+	Help      string
+	Arguments []CommandArgument
+}
+
+// CommandArgument is one argparse argument of a management command
+type CommandArgument struct {
+	Dest     string   `json:"dest"`
+	Flags    []string `json:"flags"`  // Option strings; empty for positional arguments
+	Action   string   `json:"action"` // store, store_true, store_false, store_const, append or count
+	Nargs    string   `json:"nargs"`
+	Default  string   `json:"default"`
+	Choices  []string `json:"choices"`
+	Required bool     `json:"required"`
+	Help     string   `json:"help"`
+	Metavar  string   `json:"metavar"`
+}
+
+// Positional reports whether the argument is given without a flag
+func (a CommandArgument) Positional() bool {
+	return len(a.Flags) == 0
+}
+
+// Flag returns the long option string when there is one
+func (a CommandArgument) Flag() string {
+	for _, flag := range a.Flags {
+		if strings.HasPrefix(flag, "--") {
+			return flag
+		}
+	}
+	if len(a.Flags) > 0 {
+		return a.Flags[0]
+	}
+	return ""
+}
+
+// Boolean reports whether the argument is a switch without a value
+func (a CommandArgument) Boolean() bool {
+	switch a.Action {
+	case "store_true", "store_false", "store_const", "count":
+		return true
+	}
+	return false
+}
+
+// ParseCommandList reads the grouped output of `manage.py help`:
+//
+//	[auth]
+//	    changepassword
+func ParseCommandList(output string) []ManagementCommand {
+	var commands []ManagementCommand
+	app := ""
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case strings.HasPrefix(trimmed, "[") && strings.HasSuffix(trimmed, "]"):
+			app = strings.TrimSuffix(strings.TrimPrefix(trimmed, "["), "]")
+		case app != "" && line != trimmed && !strings.Contains(trimmed, " "):
+			commands = append(commands, ManagementCommand{Name: trimmed, App: app})
+		default:
+			// Usage text or the "only Django core commands are listed" note ends a group.
+			if line == trimmed {
+				app = ""
+			}
+		}
+	}
+	sort.SliceStable(commands, func(i, j int) bool {
+		if commands[i].App != commands[j].App {
+			return commands[i].App < commands[j].App
+		}
+		return commands[i].Name < commands[j].Name
+	})
+	return commands
+}
+
+// ListManagementCommands lists the commands of every installed app, grouped by app
+func (p *Project) ListManagementCommands() ([]ManagementCommand, error) {
+	output, err := p.runDiscoveryCommand("help")
+	if err != nil {
+		if out := strings.TrimSpace(output); out != "" {
+			return nil, fmt.Errorf("%w: %s", err, lastNonEmptyLine(out))
+		}
+		return nil, err
+	}
+	commands := ParseCommandList(output)
+	if len(commands) == 0 {
+		return nil, fmt.Errorf("no management commands found in `manage.py help` output")
+	}
+	return commands, nil
+}
+
+// commandArgumentsScript prints the argparse definition of the command named by _NAME.
+// Options every command inherits from BaseCommand are left out.
+const commandArgumentsScript = `
+import json
+from django.core.management import get_commands, load_command_class
+
+_NAME = %q
+_SKIP = {'help', 'version', 'settings', 'pythonpath', 'traceback', 'no_color', 'force_color', 'skip_checks'}
+_ACTIONS = {
+    '_StoreAction': 'store', '_StoreTrueAction': 'store_true', '_StoreFalseAction': 'store_false',
+    '_StoreConstAction': 'store_const', '_AppendAction': 'append', '_CountAction': 'count',
+}
+
+def _text(value):
+    if value is None or value is False or value == []:
+        return ''
+    if isinstance(value, (list, tuple)):
+        return ' '.join(str(v) for v in value)
+    return str(value)
+
+app = get_commands()[_NAME]
+command = app if not isinstance(app, str) else load_command_class(app, _NAME)
+parser = command.create_parser('manage.py', _NAME)
+arguments = []
+for action in parser._actions:
+    if action.dest in _SKIP:
+        continue
+    kind = _ACTIONS.get(type(action).__name__, 'store')
+    nargs = action.nargs
+    arguments.append({
+        'dest': action.dest,
+        'flags': list(action.option_strings),
+        'action': kind,
+        'nargs': '' if nargs is None else str(nargs),
+        'default': '' if kind != 'store' else _text(action.default),
+        'choices': [str(c) for c in action.choices] if action.choices else [],
+        'required': bool(action.required),
+        'help': (action.help or '').replace('%%(default)s', _text(action.default)),
+        'metavar': _text(action.metavar),
+    })
+print(json.dumps({'help': command.help or '', 'arguments': arguments}))
+`
+
+// DescribeManagementCommand introspects the argparse parser of a command through the Django shell
+func (p *Project) DescribeManagementCommand(command ManagementCommand) (ManagementCommand, error) {
+	output, err := p.runDiscoveryCommand("shell", "-c", fmt.Sprintf(commandArgumentsScript, command.Name))
+	if err != nil {
+		if out := strings.TrimSpace(output); out != "" {
+			return command, fmt.Errorf("%w: %s", err, lastNonEmptyLine(out))
+		}
+		return command, err
+	}
+	payload, err := extractJSONPayload(output)
+	if err != nil {
+		return command, err
+	}
+	var described struct {
+		Help      string            `json:"help"`
+		Arguments []CommandArgument `json:"arguments"`
+	}
+	if err := json.Unmarshal([]byte(payload), &described); err != nil {
+		return command, fmt.Errorf("failed to parse arguments of %s: %w", command.Name, err)
+	}
+	command.Help = strings.TrimSpace(described.Help)
+	command.Arguments = described.Arguments
+	return command, nil
+}
+
+// CommandArgs builds the manage.py arguments for a command from form values keyed by Dest.
+// Boolean switches are on when their value is "true"; values equal to the default are omitted;
+// multi-value arguments are split on whitespace.
+func CommandArgs(command ManagementCommand, values map[string]string) ([]string, error) {
+	args := []string{command.Name}
+	var options []string
+	var missing []string
+	for _, arg := range command.Arguments {
+		value := strings.TrimSpace(values[arg.Dest])
+		if arg.Boolean() {
+			if value == "true" {
+				options = append(options, arg.Flag())
+			}
+			continue
+		}
+		if value == "" {
+			if arg.Required || (arg.Positional() && arg.Nargs != "?" && arg.Nargs != "*") {
+				missing = append(missing, arg.Dest)
+			}
+			continue
+		}
+		if !arg.Positional() && value == arg.Default {
+			continue
+		}
+		words := []string{value}
+		if (arg.Nargs != "" && arg.Nargs != "?") || arg.Action == "append" {
+			words = strings.Fields(value)
+		}
+		if len(arg.Choices) > 0 {
+			for _, word := range words {
+				if !slices.Contains(arg.Choices, word) {
+					return nil, fmt.Errorf("%s must be one of %s", arg.Dest, strings.Join(arg.Choices, ", "))
+				}
+			}
+		}
+		switch {
+		case arg.Positional():
+			args = append(args, words...)
+		case arg.Action == "append":
+			for _, word := range words {
+				options = append(options, arg.Flag(), word)
+			}
+		default:
+			options = append(options, arg.Flag())
+			options = append(options, words...)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%s required", strings.Join(missing, ", "))
+	}
+	return append(args, options...), nil
+}
+
+// ShellCommandLine renders args as one POSIX shell command line
+func ShellCommandLine(args ...string) string {
+	words := make([]string, 0, len(args))
+	for _, arg := range args {
+		words = append(words, shellQuote(arg))
+	}
+	return strings.Join(words, " ")
+}
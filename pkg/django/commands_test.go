@@ -0,0 +1,96 @@
+package django
+
+import (
+	"strings"
+	"testing"
+)
+
+const sampleHelpOutput = `
+Type 'manage.py help <subcommand>' for help on a specific subcommand.
+
+Available subcommands:
+
+[auth]
+    createsuperuser
+    changepassword
+
+[django]
+    check
+    migrate
+
+[blog]
+    publish
+Note that only Django core commands are listed as settings are not properly configured (error: boom).
+`
+
+func TestParseCommandList(t *testing.T) {
+	commands := ParseCommandList(sampleHelpOutput)
+	var got []string
+	for _, command := range commands {
+		got = append(got, command.App+":"+command.Name)
+	}
+	want := "auth:changepassword auth:createsuperuser blog:publish django:check django:migrate"
+	if strings.Join(got, " ") != want {
+		t.Fatalf("unexpected commands: %v", got)
+	}
+}
+
+func TestCommandArgs(t *testing.T) {
+	command := ManagementCommand{
+		Name: "migrate",
+		Arguments: []CommandArgument{
+			{Dest: "app_label", Nargs: "?"},
+			{Dest: "migration_name", Nargs: "?"},
+			{Dest: "verbosity", Flags: []string{"-v", "--verbosity"}, Action: "store", Default: "1", Choices: []string{"0", "1", "2", "3"}},
+			{Dest: "interactive", Flags: []string{"--noinput", "--no-input"}, Action: "store_false"},
+			{Dest: "database", Flags: []string{"--database"}, Action: "store", Default: "default"},
+			{Dest: "plan", Flags: []string{"--plan"}, Action: "store_true"},
+		},
+	}
+	args, err := CommandArgs(command, map[string]string{
+		"app_label":   "blog",
+		"verbosity":   "2",
+		"interactive": "true",
+		"database":    "default",
+		"plan":        "false",
+	})
+	if err != nil {
+		t.Fatalf("CommandArgs returned error: %v", err)
+	}
+	if strings.Join(args, " ") != "migrate blog --verbosity 2 --noinput" {
+		t.Fatalf("unexpected args: %v", args)
+	}
+
+	if _, err := CommandArgs(command, map[string]string{"verbosity": "9"}); err == nil || !strings.Contains(err.Error(), "verbosity must be one of") {
+		t.Fatalf("expected choices error, got %v", err)
+	}
+}
+
+func TestCommandArgsRequiredAndMultiValue(t *testing.T) {
+	command := ManagementCommand{
+		Name: "dumpdata",
+		Arguments: []CommandArgument{
+			{Dest: "app_label", Nargs: "+"},
+			{Dest: "exclude", Flags: []string{"-e", "--exclude"}, Action: "append"},
+			{Dest: "output", Flags: []string{"-o", "--output"}, Action: "store", Required: true},
+		},
+	}
+	if _, err := CommandArgs(command, map[string]string{}); err == nil || err.Error() != "app_label, output required" {
+		t.Fatalf("expected required error, got %v", err)
+	}
+	args, err := CommandArgs(command, map[string]string{"app_label": "blog auth", "exclude": "auth.permission contenttypes", "output": "dump.json"})
+	if err != nil {
+		t.Fatalf("CommandArgs returned error: %v", err)
+	}
+	want := "dumpdata blog auth --exclude auth.permission --exclude contenttypes --output dump.json"
+	if strings.Join(args, " ") != want {
+		t.Fatalf("unexpected args: %v", args)
+	}
+}
+
+func TestShellCommandLine(t *testing.T) {
+	got := ShellCommandLine("loaddata", "my fixture.json", "--database", "default")
+	if got != "loaddata 'my fixture.json' --database default" {
+		t.Fatalf("unexpected command line: %q", got)
+	}
+}
@@ -0,0 +1,797 @@
+package django
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ComposeBuild describes a service build section
+type ComposeBuild struct {
+	Context    string
+	Dockerfile string
+	Target     string
+}
+
+// ComposeHealthcheck describes a service healthcheck
+type ComposeHealthcheck struct {
+	Test     []string
+	Interval string
+	Timeout  string
+	Retries  int
+	Disable  bool
+}
+
+// ComposeMount is a bind mount from the host into a service container
+type ComposeMount struct {
+	Source   string // Host path, absolute once loaded from a compose file
+	Target   string // Path inside the container
+	ReadOnly bool
+}
+
+// ComposeService is a resolved compose service after overrides, profiles, and interpolation
+type ComposeService struct {
+	Name          string
+	Image         string
+	Build         *ComposeBuild
+	Command       string
+	Entrypoint    string
+	ContainerName string
+	Ports         []string
+	DependsOn     []string
+	Healthcheck   *ComposeHealthcheck
+	Environment   map[string]string // env_file values overlaid with environment
+	EnvFiles      []string
+	Profiles      []string
+	Mounts        []ComposeMount // bind mounts; named volumes are skipped
+}
+
+// ComposeProject is the merged service graph for a project's compose files
+type ComposeProject struct {
+	Files    []string // base file first, then overrides in merge order
+	Services []ComposeService
+	Disabled []string // services skipped because none of their profiles are active
+	Profiles []string // active profiles from COMPOSE_PROFILES
+}
+
+// composeOverrideNames are checked next to the base file when COMPOSE_FILE is unset
+var composeOverrideNames = []string{
+	"docker-compose.override.yml",
+	"docker-compose.override.yaml",
+	"compose.override.yaml",
+	"compose.override.yml",
+}
+
+// appendedComposeKeys are sequences compose concatenates rather than replaces when merging files
+var appendedComposeKeys = map[string]bool{
+	"ports":       true,
+	"expose":      true,
+	"volumes":     true,
+	"env_file":    true,
+	"dns":         true,
+	"extra_hosts": true,
+	"cap_add":     true,
+	"cap_drop":    true,
+}
+
+// LoadComposeProject loads the compose files for rootDir, resolving the
+// .env file, COMPOSE_FILE, COMPOSE_PROFILES, and docker-compose.override.yml.
+// It returns nil without error when the project has no compose file.
+func LoadComposeProject(rootDir string) (*ComposeProject, error) {
+	return loadComposeProject(rootDir, os.LookupEnv)
+}
+
+func loadComposeProject(rootDir string, lookupEnv func(string) (string, bool)) (*ComposeProject, error) {
+	dotEnv := map[string]string{}
+	if content, err := os.ReadFile(filepath.Join(rootDir, ".env")); err == nil {
+		dotEnv = parseDotEnv(string(content))
+	}
+	// Shell variables win over the project .env file, as with docker compose.
+	lookup := func(name string) (string, bool) {
+		if value, ok := lookupEnv(name); ok {
+			return value, true
+		}
+		value, ok := dotEnv[name]
+		return value, ok
+	}
+
+	files := composeFilesForProject(rootDir, lookup)
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	var profiles []string
+	if value, ok := lookup("COMPOSE_PROFILES"); ok {
+		for _, profile := range strings.Split(value, ",") {
+			if profile = strings.TrimSpace(profile); profile != "" {
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+
+	var merged map[string]interface{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		services, err := decodeComposeServices(content, filepath.Dir(file), lookup)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if merged == nil {
+			merged = services
+			continue
+		}
+		merged, _ = mergeComposeValue(merged, services, "").(map[string]interface{})
+	}
+
+	project := &ComposeProject{Files: files, Profiles: profiles}
+	for _, name := range sortedComposeKeys(merged) {
+		raw, _ := merged[name].(map[string]interface{})
+		service := buildComposeService(name, raw, lookup)
+		if !composeProfilesActive(service.Profiles, profiles) {
+			project.Disabled = append(project.Disabled, name)
+			continue
+		}
+		project.Services = append(project.Services, service)
+	}
+	return project, nil
+}
+
+// scanComposeServices is the line-based fallback used when the compose YAML does not parse.
+// It only recovers service names and a few scalar keys, which is enough to pick the Django service.
+func scanComposeServices(content string) []ComposeService {
+	inServices := false
+	servicesIndent := -1
+	serviceIndent := -1
+
+	var services []ComposeService
+	for _, raw := range strings.Split(content, "\n") {
+		line := raw
+		if idx := strings.Index(line, "#"); idx >= 0 {
+			line = line[:idx]
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if !inServices {
+			if trimmed == "services:" {
+				inServices = true
+				servicesIndent = indent
+			}
+			continue
+		}
+		if indent <= servicesIndent {
+			break
+		}
+		if serviceIndent < 0 {
+			serviceIndent = indent
+		}
+
+		if indent == serviceIndent {
+			if name := strings.TrimSpace(strings.TrimSuffix(trimmed, ":")); name != "" && strings.HasSuffix(trimmed, ":") {
+				services = append(services, ComposeService{Name: name})
+			}
+			continue
+		}
+		if len(services) == 0 {
+			continue
+		}
+		current := &services[len(services)-1]
+		key, value, _ := strings.Cut(trimmed, ":")
+		value = strings.Trim(strings.TrimSpace(value), "\"'")
+		switch strings.TrimSpace(key) {
+		case "image":
+			current.Image = value
+		case "command":
+			current.Command = value
+		case "entrypoint":
+			current.Entrypoint = value
+		case "container_name":
+			current.ContainerName = value
+		case "build":
+			current.Build = &ComposeBuild{Context: value}
+		}
+	}
+	return services
+}
+
+// ParseComposeServices decodes a single compose document without overrides or .env lookup
+func ParseComposeServices(content []byte) ([]ComposeService, error) {
+	lookup := func(string) (string, bool) { return "", false }
+	raw, err := decodeComposeServices(content, "", lookup)
+	if err != nil {
+		return nil, err
+	}
+	services := make([]ComposeService, 0, len(raw))
+	for _, name := range sortedComposeKeys(raw) {
+		body, _ := raw[name].(map[string]interface{})
+		services = append(services, buildComposeService(name, body, lookup))
+	}
+	return services, nil
+}
+
+func composeFilesForProject(rootDir string, lookup func(string) (string, bool)) []string {
+	if value, ok := lookup("COMPOSE_FILE"); ok && strings.TrimSpace(value) != "" {
+		separator := string(os.PathListSeparator)
+		if custom, ok := lookup("COMPOSE_PATH_SEPARATOR"); ok && custom != "" {
+			separator = custom
+		}
+		var files []string
+		for _, file := range strings.Split(value, separator) {
+			file = strings.TrimSpace(file)
+			if file == "" {
+				continue
+			}
+			if !filepath.IsAbs(file) {
+				file = filepath.Join(rootDir, file)
+			}
+			if fileExists(file) {
+				files = append(files, file)
+			}
+		}
+		if len(files) > 0 {
+			return files
+		}
+	}
+
+	base := findComposeFile(rootDir)
+	if base == "" {
+		return nil
+	}
+	files := []string{base}
+	for _, name := range composeOverrideNames {
+		override := filepath.Join(filepath.Dir(base), name)
+		if fileExists(override) {
+			files = append(files, override)
+			break
+		}
+	}
+	return files
+}
+
+// decodeComposeServices returns the interpolated services mapping from a compose document.
+// Anchors and << merges are resolved by the YAML decoder; list-form environment,
+// labels, and depends_on are normalized to maps so later files merge by key.
+func decodeComposeServices(content []byte, dir string, lookup func(string) (string, bool)) (map[string]interface{}, error) {
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+
+	services := map[string]interface{}{}
+	raw, _ := doc["services"].(map[string]interface{})
+	for name, value := range raw {
+		body, ok := interpolateComposeValue(value, lookup).(map[string]interface{})
+		if !ok {
+			body = map[string]interface{}{}
+		}
+		for _, key := range []string{"environment", "labels"} {
+			if list, ok := body[key].([]interface{}); ok {
+				body[key] = composeListToMap(list)
+			}
+		}
+		if list, ok := body["depends_on"].([]interface{}); ok {
+			deps := map[string]interface{}{}
+			for _, item := range list {
+				deps[composeScalar(item)] = map[string]interface{}{"condition": "service_started"}
+			}
+			body["depends_on"] = deps
+		}
+		if envFiles := composeEnvFiles(body["env_file"]); len(envFiles) > 0 {
+			resolved := make([]interface{}, 0, len(envFiles))
+			for _, file := range envFiles {
+				if dir != "" && !filepath.IsAbs(file) {
+					file = filepath.Join(dir, file)
+				}
+				resolved = append(resolved, file)
+			}
+			body["env_file"] = resolved
+		}
+		if volumes, ok := body["volumes"].([]interface{}); ok && dir != "" {
+			for i, volume := range volumes {
+				volumes[i] = resolveComposeVolume(volume, dir)
+			}
+		}
+		services[name] = body
+	}
+	return services, nil
+}
+
+func mergeComposeValue(base, override interface{}, key string) interface{} {
+	baseMap, baseIsMap := base.(map[string]interface{})
+	overrideMap, overrideIsMap := override.(map[string]interface{})
+	if baseIsMap && overrideIsMap {
+		out := make(map[string]interface{}, len(baseMap)+len(overrideMap))
+		for k, v := range baseMap {
+			out[k] = v
+		}
+		for k, v := range overrideMap {
+			out[k] = mergeComposeValue(baseMap[k], v, k)
+		}
+		return out
+	}
+
+	baseList, baseIsList := base.([]interface{})
+	overrideList, overrideIsList := override.([]interface{})
+	if baseIsList && overrideIsList && appendedComposeKeys[key] {
+		out := append([]interface{}{}, baseList...)
+		seen := make(map[string]bool, len(baseList))
+		for _, item := range baseList {
+			seen[composeScalar(item)] = true
+		}
+		for _, item := range overrideList {
+			if !seen[composeScalar(item)] {
+				out = append(out, item)
+			}
+		}
+		return out
+	}
+
+	if override == nil {
+		return base
+	}
+	return override
+}
+
+func buildComposeService(name string, raw map[string]interface{}, lookup func(string) (string, bool)) ComposeService {
+	service := ComposeService{
+		Name:          name,
+		Image:         composeScalar(raw["image"]),
+		Command:       composeCommand(raw["command"]),
+		Entrypoint:    composeCommand(raw["entrypoint"]),
+		ContainerName: composeScalar(raw["container_name"]),
+		EnvFiles:      composeEnvFiles(raw["env_file"]),
+		Profiles:      composeStrings(raw["profiles"]),
+		Environment:   map[string]string{},
+		Mounts:        composeMounts(raw["volumes"]),
+	}
+
+	switch build := raw["build"].(type) {
+	case string:
+		service.Build = &ComposeBuild{Context: build}
+	case map[string]interface{}:
+		service.Build = &ComposeBuild{
+			Context:    composeScalar(build["context"]),
+			Dockerfile: composeScalar(build["dockerfile"]),
+			Target:     composeScalar(build["target"]),
+		}
+	}
+
+	if ports, ok := raw["ports"].([]interface{}); ok {
+		for _, port := range ports {
+			service.Ports = append(service.Ports, composePort(port))
+		}
+	}
+
+	if deps, ok := raw["depends_on"].(map[string]interface{}); ok {
+		service.DependsOn = sortedComposeKeys(deps)
+	}
+
+	if check, ok := raw["healthcheck"].(map[string]interface{}); ok {
+		healthcheck := &ComposeHealthcheck{
+			Interval: composeScalar(check["interval"]),
+			Timeout:  composeScalar(check["timeout"]),
+			Disable:  composeScalar(check["disable"]) == "true",
+		}
+		switch test := check["test"].(type) {
+		case string:
+			healthcheck.Test = []string{"CMD-SHELL", test}
+		case []interface{}:
+			healthcheck.Test = composeStrings(test)
+		}
+		if retries, ok := check["retries"].(int); ok {
+			healthcheck.Retries = retries
+		}
+		service.Healthcheck = healthcheck
+	}
+
+	for _, file := range service.EnvFiles {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			continue
+		}
+		for key, value := range parseDotEnv(string(content)) {
+			service.Environment[key] = value
+		}
+	}
+	if env, ok := raw["environment"].(map[string]interface{}); ok {
+		for key, value := range env {
+			if value == nil {
+				// A bare key passes the variable through from the shell.
+				if shell, ok := lookup(key); ok {
+					service.Environment[key] = shell
+				}
+				continue
+			}
+			service.Environment[key] = composeScalar(value)
+		}
+	}
+
+	return service
+}
+
+func composeProfilesActive(serviceProfiles, active []string) bool {
+	if len(serviceProfiles) == 0 {
+		return true
+	}
+	for _, profile := range serviceProfiles {
+		for _, enabled := range active {
+			if profile == enabled || enabled == "*" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func composeScalar(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func composeStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		out := make([]string, 0, len(v))
+		for _, item := range v {
+			out = append(out, composeScalar(item))
+		}
+		return out
+	}
+	return nil
+}
+
+func composeCommand(value interface{}) string {
+	return strings.Join(composeStrings(value), " ")
+}
+
+func composeEnvFiles(value interface{}) []string {
+	var files []string
+	items, ok := value.([]interface{})
+	if !ok {
+		items = []interface{}{value}
+	}
+	for _, item := range items {
+		switch v := item.(type) {
+		case string:
+			files = append(files, v)
+		case map[string]interface{}:
+			if path := composeScalar(v["path"]); path != "" {
+				files = append(files, path)
+			}
+		}
+	}
+	return files
+}
+
+func composePort(value interface{}) string {
+	long, ok := value.(map[string]interface{})
+	if !ok {
+		return composeScalar(value)
+	}
+	port := composeScalar(long["target"])
+	if published := composeScalar(long["published"]); published != "" {
+		port = published + ":" + port
+	}
+	if protocol := composeScalar(long["protocol"]); protocol != "" && protocol != "tcp" {
+		port += "/" + protocol
+	}
+	return port
+}
+
+// parseComposeVolume reads the short `source:target[:mode]` or long volume syntax.
+// ok is false for named and anonymous volumes.
+func parseComposeVolume(value interface{}) (ComposeMount, bool) {
+	if long, isMap := value.(map[string]interface{}); isMap {
+		if kind := composeScalar(long["type"]); kind != "" && kind != "bind" {
+			return ComposeMount{}, false
+		}
+		mount := ComposeMount{
+			Source:   composeScalar(long["source"]),
+			Target:   composeScalar(long["target"]),
+			ReadOnly: composeScalar(long["read_only"]) == "true",
+		}
+		return mount, isBindSource(mount.Source) && mount.Target != ""
+	}
+
+	parts := strings.Split(composeScalar(value), ":")
+	if len(parts) < 2 || !isBindSource(parts[0]) {
+		return ComposeMount{}, false
+	}
+	mount := ComposeMount{Source: parts[0], Target: parts[1]}
+	if len(parts) > 2 {
+		for _, option := range strings.Split(parts[2], ",") {
+			if option == "ro" {
+				mount.ReadOnly = true
+			}
+		}
+	}
+	return mount, true
+}
+
+// isBindSource tells host paths apart from named volumes, as compose does
+func isBindSource(source string) bool {
+	return strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~")
+}
+
+// resolveComposeVolume makes a relative bind source absolute against the compose file directory
+// and expands a leading ~ to the home directory
+func resolveComposeVolume(value interface{}, dir string) interface{} {
+	mount, ok := parseComposeVolume(value)
+	if !ok || filepath.IsAbs(mount.Source) {
+		return value
+	}
+	source := filepath.Join(dir, mount.Source)
+	if strings.HasPrefix(mount.Source, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil || (mount.Source != "~" && !strings.HasPrefix(mount.Source, "~/")) {
+			return value
+		}
+		source = filepath.Join(home, mount.Source[1:])
+	}
+	if long, isMap := value.(map[string]interface{}); isMap {
+		long["source"] = source
+		return long
+	}
+	parts := strings.Split(composeScalar(value), ":")
+	parts[0] = source
+	return strings.Join(parts, ":")
+}
+
+func composeMounts(value interface{}) []ComposeMount {
+	volumes, _ := value.([]interface{})
+	var mounts []ComposeMount
+	for _, volume := range volumes {
+		if mount, ok := parseComposeVolume(volume); ok {
+			mounts = append(mounts, mount)
+		}
+	}
+	return mounts
+}
+
+func composeListToMap(list []interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(list))
+	for _, item := range list {
+		entry := composeScalar(item)
+		if idx := strings.Index(entry, "="); idx >= 0 {
+			out[strings.TrimSpace(entry[:idx])] = entry[idx+1:]
+		} else if entry = strings.TrimSpace(entry); entry != "" {
+			out[entry] = nil
+		}
+	}
+	return out
+}
+
+func sortedComposeKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func interpolateComposeValue(value interface{}, lookup func(string) (string, bool)) interface{} {
+	switch v := value.(type) {
+	case string:
+		return interpolateComposeString(v, lookup)
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[key] = interpolateComposeValue(item, lookup)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, item := range v {
+			out[i] = interpolateComposeValue(item, lookup)
+		}
+		return out
+	}
+	return value
+}
+
+// interpolateComposeString expands $VAR, ${VAR}, ${VAR:-default}, ${VAR-default},
+// ${VAR:+alt}, ${VAR+alt}, and ${VAR:?err} the way compose does; $$ is a literal $.
+func interpolateComposeString(text string, lookup func(string) (string, bool)) string {
+	if !strings.Contains(text, "$") {
+		return text
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(text); i++ {
+		if text[i] != '$' || i+1 >= len(text) {
+			b.WriteByte(text[i])
+			continue
+		}
+
+		next := text[i+1]
+		switch {
+		case next == '$':
+			b.WriteByte('$')
+			i++
+		case next == '{':
+			end := matchingBrace(text, i+1)
+			if end < 0 {
+				b.WriteString(text[i:])
+				return b.String()
+			}
+			b.WriteString(expandComposeExpression(text[i+2:end], lookup))
+			i = end
+		case isEnvNameByte(next, true):
+			j := i + 1
+			for j < len(text) && isEnvNameByte(text[j], false) {
+				j++
+			}
+			value, _ := lookup(text[i+1 : j])
+			b.WriteString(value)
+			i = j - 1
+		default:
+			b.WriteByte('$')
+		}
+	}
+	return b.String()
+}
+
+func expandComposeExpression(expr string, lookup func(string) (string, bool)) string {
+	nameEnd := 0
+	for nameEnd < len(expr) && isEnvNameByte(expr[nameEnd], nameEnd == 0) {
+		nameEnd++
+	}
+	name, rest := expr[:nameEnd], expr[nameEnd:]
+	value, set := lookup(name)
+
+	operator := rest
+	argument := ""
+	for _, op := range []string{":-", ":+", ":?", "-", "+", "?"} {
+		if strings.HasPrefix(rest, op) {
+			operator, argument = op, rest[len(op):]
+			break
+		}
+	}
+
+	switch operator {
+	case ":-":
+		if !set || value == "" {
+			return interpolateComposeString(argument, lookup)
+		}
+	case "-":
+		if !set {
+			return interpolateComposeString(argument, lookup)
+		}
+	case ":+":
+		if set && value != "" {
+			return interpolateComposeString(argument, lookup)
+		}
+		return ""
+	case "+":
+		if set {
+			return interpolateComposeString(argument, lookup)
+		}
+		return ""
+	}
+	return value
+}
+
+func matchingBrace(text string, open int) int {
+	depth := 0
+	for i := open; i < len(text); i++ {
+		switch text[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func isEnvNameByte(c byte, first bool) bool {
+	if c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') {
+		return true
+	}
+	return !first && c >= '0' && c <= '9'
+}
+
+// parseDotEnv reads KEY=VALUE lines, ignoring comments and an optional export prefix
+func parseDotEnv(content string) map[string]string {
+	env := make(map[string]string)
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		idx := strings.Index(line, "=")
+		if idx <= 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:idx])
+		value := strings.TrimSpace(line[idx+1:])
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		} else if idx := strings.Index(value, " #"); idx >= 0 {
+			value = strings.TrimSpace(value[:idx])
+		}
+		env[key] = value
+	}
+	return env
+}
+
+// Service returns the named service, if active
+func (c *ComposeProject) Service(name string) (ComposeService, bool) {
+	if c != nil {
+		for _, service := range c.Services {
+			if service.Name == name {
+				return service, true
+			}
+		}
+	}
+	return ComposeService{}, false
+}
+
+// ServiceNames returns the active service names in sorted order
+func (c *ComposeProject) ServiceNames() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Services))
+	for _, service := range c.Services {
+		names = append(names, service.Name)
+	}
+	return names
+}
+
+// Environment merges every service environment, letting the preferred service win on conflicts
+func (c *ComposeProject) Environment(preferred string) map[string]string {
+	env := make(map[string]string)
+	if c == nil {
+		return env
+	}
+	for _, service := range c.Services {
+		if service.Name == preferred {
+			continue
+		}
+		for key, value := range service.Environment {
+			env[key] = value
+		}
+	}
+	if service, ok := c.Service(preferred); ok {
+		for key, value := range service.Environment {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// FileArgs returns the -f flags selecting every compose file, in merge order
+func (c *ComposeProject) FileArgs() []string {
+	if c == nil {
+		return nil
+	}
+	args := make([]string, 0, len(c.Files)*2)
+	for _, file := range c.Files {
+		args = append(args, "-f", file)
+	}
+	return args
+}
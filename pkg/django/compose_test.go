@@ -0,0 +1,193 @@
+package django
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeComposeFixture(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+}
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := env[name]
+		return value, ok
+	}
+}
+
+func TestInterpolateComposeString(t *testing.T) {
+	lookup := lookupFrom(map[string]string{"PORT": "9000", "EMPTY": ""})
+	cases := map[string]string{
+		"${PORT}":                 "9000",
+		"$PORT/tcp":               "9000/tcp",
+		"${MISSING:-8000}":        "8000",
+		"${EMPTY:-fallback}":      "fallback",
+		"${EMPTY-fallback}":       "",
+		"${MISSING-${PORT}}":      "9000",
+		"${PORT:+set}":            "set",
+		"${MISSING:+set}":         "",
+		"$$HOME":                  "$HOME",
+		"postgres://${MISSING}@x": "postgres://@x",
+	}
+	for input, want := range cases {
+		if got := interpolateComposeString(input, lookup); got != want {
+			t.Fatalf("interpolate %q: expected %q, got %q", input, want, got)
+		}
+	}
+}
+
+func TestLoadComposeProjectMergesOverridesAndProfiles(t *testing.T) {
+	dir := t.TempDir()
+	writeComposeFixture(t, dir, ".env", "WEB_PORT=8001\nexport DB_PASSWORD=\"s3cret\"\n")
+	writeComposeFixture(t, dir, "web.env", "SECRET_KEY=from-file\nDEBUG=0\n")
+	writeComposeFixture(t, dir, "docker-compose.yml", `x-env: &common-env
+  DJANGO_SETTINGS_MODULE: config.settings
+
+services:
+  web:
+    build: {context: ., target: dev}
+    command: ["python", "manage.py", "runserver", "0.0.0.0:8000"]
+    ports:
+      - "${WEB_PORT:-8000}:8000"
+    env_file: web.env
+    environment:
+      <<: *common-env
+      DATABASE_PASSWORD: ${DB_PASSWORD}
+    depends_on: [db]
+  db:
+    image: postgres:${PG_VERSION:-16}
+    healthcheck:
+      test: pg_isready -U postgres
+      retries: 5
+  flower:
+    image: mher/flower
+    profiles: [monitoring]
+`)
+	writeComposeFixture(t, dir, "docker-compose.override.yml", `services:
+  web:
+    ports:
+      - target: 5678
+        published: 5678
+    environment:
+      - DEBUG=1
+      - PASSTHROUGH
+`)
+
+	project, err := loadComposeProject(dir, lookupFrom(map[string]string{"PASSTHROUGH": "shell"}))
+	if err != nil {
+		t.Fatalf("loadComposeProject failed: %v", err)
+	}
+	if len(project.Files) != 2 || !strings.HasSuffix(project.Files[1], "docker-compose.override.yml") {
+		t.Fatalf("expected base and override files, got %v", project.Files)
+	}
+	if got := strings.Join(project.ServiceNames(), ","); got != "db,web" {
+		t.Fatalf("expected profiled service to be disabled, got %s", got)
+	}
+	if len(project.Disabled) != 1 || project.Disabled[0] != "flower" {
+		t.Fatalf("unexpected disabled services: %v", project.Disabled)
+	}
+
+	web, _ := project.Service("web")
+	if web.Build == nil || web.Build.Target != "dev" || web.Command != "python manage.py runserver 0.0.0.0:8000" {
+		t.Fatalf("unexpected web build/command: %#v", web)
+	}
+	if strings.Join(web.Ports, ",") != "8001:8000,5678:5678" {
+		t.Fatalf("expected interpolated and appended ports, got %v", web.Ports)
+	}
+	if strings.Join(web.DependsOn, ",") != "db" {
+		t.Fatalf("unexpected depends_on: %v", web.DependsOn)
+	}
+	want := map[string]string{
+		"DJANGO_SETTINGS_MODULE": "config.settings",
+		"DATABASE_PASSWORD":      "s3cret",
+		"SECRET_KEY":             "from-file",
+		"DEBUG":                  "1",
+		"PASSTHROUGH":            "shell",
+	}
+	for key, value := range want {
+		if web.Environment[key] != value {
+			t.Fatalf("expected %s=%q, got %q (env: %v)", key, value, web.Environment[key], web.Environment)
+		}
+	}
+
+	db, _ := project.Service("db")
+	if db.Image != "postgres:16" || db.Healthcheck == nil || db.Healthcheck.Retries != 5 || db.Healthcheck.Test[0] != "CMD-SHELL" {
+		t.Fatalf("unexpected db service: %#v", db)
+	}
+
+	withProfile, err := loadComposeProject(dir, lookupFrom(map[string]string{"COMPOSE_PROFILES": "monitoring"}))
+	if err != nil {
+		t.Fatalf("loadComposeProject with profile failed: %v", err)
+	}
+	if _, ok := withProfile.Service("flower"); !ok {
+		t.Fatalf("expected flower to be active with its profile, got %v", withProfile.ServiceNames())
+	}
+}
+
+func TestLoadComposeProjectHonoursComposeFile(t *testing.T) {
+	dir := t.TempDir()
+	writeComposeFixture(t, dir, "docker-compose.yml", "services:\n  web:\n    image: base\n")
+	writeComposeFixture(t, dir, "docker-compose.override.yml", "services:\n  web:\n    image: override\n")
+	writeComposeFixture(t, dir, "compose.prod.yml", "services:\n  web:\n    image: prod\n")
+
+	project, err := loadComposeProject(dir, lookupFrom(map[string]string{
+		"COMPOSE_FILE":           "docker-compose.yml;compose.prod.yml",
+		"COMPOSE_PATH_SEPARATOR": ";",
+	}))
+	if err != nil {
+		t.Fatalf("loadComposeProject failed: %v", err)
+	}
+	if len(project.Files) != 2 || !strings.HasSuffix(project.Files[1], "compose.prod.yml") {
+		t.Fatalf("expected COMPOSE_FILE to replace override discovery, got %v", project.Files)
+	}
+	if web, _ := project.Service("web"); web.Image != "prod" {
+		t.Fatalf("expected last file to win, got %q", web.Image)
+	}
+	if args := strings.Join(project.FileArgs(), " "); strings.Count(args, "-f ") != 2 {
+		t.Fatalf("expected a -f flag per file, got %q", args)
+	}
+}
+
+func TestLoadComposeProjectResolvesBindMounts(t *testing.T) {
+	dir := t.TempDir()
+	writeComposeFixture(t, dir, "docker-compose.yml", `services:
+  web:
+    image: app
+    volumes:
+      - .:/app
+      - ./static:/srv/static:ro
+      - pgdata:/var/lib/postgresql/data
+      - type: bind
+        source: ./config
+        target: /etc/app
+      - ~/.aws:/root/.aws:ro
+volumes:
+  pgdata:
+`)
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	project, err := loadComposeProject(dir, lookupFrom(nil))
+	if err != nil {
+		t.Fatalf("loadComposeProject failed: %v", err)
+	}
+	web, _ := project.Service("web")
+	if len(web.Mounts) != 4 {
+		t.Fatalf("expected four bind mounts, got %+v", web.Mounts)
+	}
+	if web.Mounts[0] != (ComposeMount{Source: dir, Target: "/app"}) {
+		t.Fatalf("expected the project root mounted at /app, got %+v", web.Mounts[0])
+	}
+	if !web.Mounts[1].ReadOnly || web.Mounts[2].Source != filepath.Join(dir, "config") {
+		t.Fatalf("unexpected mounts %+v", web.Mounts[1:])
+	}
+	if web.Mounts[3].Source != filepath.Join(home, ".aws") {
+		t.Fatalf("expected ~ expanded to the home directory, got %+v", web.Mounts[3])
+	}
+}
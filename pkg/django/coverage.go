@@ -0,0 +1,155 @@
+package django
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// CoverageReportPath is where `coverage json` writes its report, relative to the project root
+var CoverageReportPath = filepath.Join(".lazy-django", "coverage.json")
+
+// CoverageFile is the coverage of one source file
+type CoverageFile struct {
+	Path         string // As reported by coverage, usually relative to the project root
+	App          string
+	Statements   int
+	Missed       int
+	Percent      float64
+	MissingLines []int
+}
+
+// CoverageApp aggregates the files of one app
+type CoverageApp struct {
+	Name       string
+	Statements int
+	Missed     int
+	Percent    float64
+}
+
+// CoverageReport is a parsed `coverage json` report
+type CoverageReport struct {
+	Files   []CoverageFile
+	Percent float64
+}
+
+type coverageJSON struct {
+	Files map[string]struct {
+		MissingLines []int `json:"missing_lines"`
+		Summary      struct {
+			NumStatements  int     `json:"num_statements"`
+			MissingLines   int     `json:"missing_lines"`
+			PercentCovered float64 `json:"percent_covered"`
+		} `json:"summary"`
+	} `json:"files"`
+	Totals struct {
+		PercentCovered float64 `json:"percent_covered"`
+	} `json:"totals"`
+}
+
+// ParseCoverageJSON reads a `coverage json` report. Files are assigned to the app whose
+// directory contains them, or to their top-level directory.
+func ParseCoverageJSON(data []byte, apps []App) (*CoverageReport, error) {
+	var raw coverageJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid coverage report: %w", err)
+	}
+	report := &CoverageReport{Percent: raw.Totals.PercentCovered}
+	for path, file := range raw.Files {
+		missing := append([]int(nil), file.MissingLines...)
+		sort.Ints(missing)
+		report.Files = append(report.Files, CoverageFile{
+			Path:         path,
+			App:          coverageAppFor(path, apps),
+			Statements:   file.Summary.NumStatements,
+			Missed:       file.Summary.MissingLines,
+			Percent:      file.Summary.PercentCovered,
+			MissingLines: missing,
+		})
+	}
+	sort.Slice(report.Files, func(i, j int) bool { return report.Files[i].Path < report.Files[j].Path })
+	return report, nil
+}
+
+func coverageAppFor(path string, apps []App) string {
+	path = filepath.ToSlash(path)
+	best := ""
+	bestLen := -1
+	for _, app := range apps {
+		dir := filepath.ToSlash(app.Path)
+		if (strings.HasPrefix(path, dir+"/") || strings.Contains(path, "/"+dir+"/")) && len(dir) > bestLen {
+			best, bestLen = app.Name, len(dir)
+		}
+	}
+	if best != "" {
+		return best
+	}
+	if dir, _, ok := strings.Cut(strings.TrimPrefix(path, "/"), "/"); ok {
+		return dir
+	}
+	return "."
+}
+
+// Apps aggregates file coverage per app, in name order
+func (r *CoverageReport) Apps() []CoverageApp {
+	byName := make(map[string]*CoverageApp)
+	var names []string
+	for _, file := range r.Files {
+		app, ok := byName[file.App]
+		if !ok {
+			app = &CoverageApp{Name: file.App}
+			byName[file.App] = app
+			names = append(names, file.App)
+		}
+		app.Statements += file.Statements
+		app.Missed += file.Missed
+	}
+	sort.Strings(names)
+	apps := make([]CoverageApp, 0, len(names))
+	for _, name := range names {
+		app := byName[name]
+		app.Percent = 100
+		if app.Statements > 0 {
+			app.Percent = 100 * float64(app.Statements-app.Missed) / float64(app.Statements)
+		}
+		apps = append(apps, *app)
+	}
+	return apps
+}
+
+// LineRanges collapses sorted line numbers into ranges: [3 4 5 9] -> "3-5, 9"
+func LineRanges(lines []int) string {
+	var parts []string
+	for i := 0; i < len(lines); {
+		j := i
+		for j+1 < len(lines) && lines[j+1] == lines[j]+1 {
+			j++
+		}
+		if j == i {
+			parts = append(parts, fmt.Sprintf("%d", lines[i]))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", lines[i], lines[j]))
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ", ")
+}
+
+// CoverageJSONCommand exports the data of the last `coverage run` to CoverageReportPath
+func (p *Project) CoverageJSONCommand(ctx context.Context) *exec.Cmd {
+	return p.ActiveExecutor().Python(ctx, nil, "-m", "coverage", "json", "-o", filepath.ToSlash(CoverageReportPath))
+}
+
+// ReadCoverageReport parses the report written by CoverageJSONCommand
+func (p *Project) ReadCoverageReport() (*CoverageReport, error) {
+	data, err := os.ReadFile(filepath.Join(p.RootDir, CoverageReportPath))
+	if err != nil {
+		return nil, err
+	}
+	return ParseCoverageJSON(data, p.Apps)
+}
@@ -0,0 +1,44 @@
+package django
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseCoverageJSON(t *testing.T) {
+	data := []byte(`{
+  "files": {
+    "blog/views.py": {"missing_lines": [30, 12, 13, 14], "summary": {"num_statements": 40, "missing_lines": 4, "percent_covered": 90.0}},
+    "blog/models.py": {"missing_lines": [], "summary": {"num_statements": 10, "missing_lines": 0, "percent_covered": 100.0}},
+    "mysite/settings.py": {"missing_lines": [5], "summary": {"num_statements": 10, "missing_lines": 5, "percent_covered": 50.0}}
+  },
+  "totals": {"percent_covered": 83.3}
+}`)
+	report, err := ParseCoverageJSON(data, []App{{Name: "blog", Path: "blog"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Files) != 3 || report.Files[1].Path != "blog/views.py" {
+		t.Fatalf("expected files in path order, got %+v", report.Files)
+	}
+	if got := LineRanges(report.Files[1].MissingLines); got != "12-14, 30" {
+		t.Fatalf("unexpected missing ranges %q", got)
+	}
+
+	apps := report.Apps()
+	if len(apps) != 2 || apps[0].Name != "blog" || apps[0].Statements != 50 || apps[0].Missed != 4 || apps[0].Percent != 92 {
+		t.Fatalf("unexpected blog coverage %+v", apps)
+	}
+	if apps[1].Name != "mysite" || apps[1].Percent != 50 {
+		t.Fatalf("expected files outside apps grouped by directory, got %+v", apps[1])
+	}
+}
+
+func TestTestCommandWithCoverage(t *testing.T) {
+	project := &Project{RootDir: t.TempDir(), ManagePyPath: "manage.py"}
+	cmd := project.TestCommand(context.Background(), TestRunOptions{Coverage: true})
+	if args := strings.Join(cmd.Args, " "); !strings.HasSuffix(args, "-m coverage run manage.py test -v 2") {
+		t.Fatalf("unexpected coverage command %q", args)
+	}
+}
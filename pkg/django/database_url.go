@@ -0,0 +1,214 @@
+package django
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// databaseURLEngines maps dj-database-url schemes to Django backends
+var databaseURLEngines = map[string]string{
+	"postgres":        "django.db.backends.postgresql",
+	"postgresql":      "django.db.backends.postgresql",
+	"pgsql":           "django.db.backends.postgresql",
+	"psql":            "django.db.backends.postgresql",
+	"postgis":         "django.contrib.gis.db.backends.postgis",
+	"mysql":           "django.db.backends.mysql",
+	"mysql2":          "django.db.backends.mysql",
+	"mysqlgis":        "django.contrib.gis.db.backends.mysql",
+	"mysql-connector": "mysql.connector.django",
+	"mariadb":         "django.db.backends.mysql",
+	"sqlite":          "django.db.backends.sqlite3",
+	"spatialite":      "django.contrib.gis.db.backends.spatialite",
+	"oracle":          "django.db.backends.oracle",
+	"oraclegis":       "django.contrib.gis.db.backends.oracle",
+}
+
+var databaseURLDefaultPorts = map[string]string{
+	"django.db.backends.postgresql":          "5432",
+	"django.contrib.gis.db.backends.postgis": "5432",
+	"django.db.backends.mysql":               "3306",
+	"django.contrib.gis.db.backends.mysql":   "3306",
+	"mysql.connector.django":                 "3306",
+	"django.db.backends.oracle":              "1521",
+	"django.contrib.gis.db.backends.oracle":  "1521",
+}
+
+// settingsDatabaseURLPattern matches dj_database_url.config/parse and django-environ env.db calls
+var settingsDatabaseURLPattern = regexp.MustCompile(`(?:dj_database_url\.(?:config|parse)|env\.db(?:_url)?)\s*\(`)
+
+var settingsDefaultURLPattern = regexp.MustCompile(`(?:default\s*=\s*)?['"]([a-z][a-z0-9+.-]*://[^'"]*)['"]`)
+
+// ParseDatabaseURL converts a dj-database-url style URL into DatabaseInfo.
+// Query parameters are kept as connection options.
+func ParseDatabaseURL(raw string) (DatabaseInfo, error) {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil {
+		return DatabaseInfo{}, fmt.Errorf("invalid database URL: %w", err)
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	// SQLAlchemy-style driver suffixes such as postgresql+psycopg carry no extra meaning here.
+	if idx := strings.Index(scheme, "+"); idx > 0 {
+		scheme = scheme[:idx]
+	}
+	engine, ok := databaseURLEngines[scheme]
+	if !ok {
+		return DatabaseInfo{}, fmt.Errorf("unsupported database URL scheme %q", u.Scheme)
+	}
+
+	info := DatabaseInfo{Engine: engine, IsUsable: true}
+	if strings.Contains(engine, "sqlite") || strings.Contains(engine, "spatialite") {
+		switch {
+		case u.Host == ":memory:" || u.Path == "" || u.Path == "/" || u.Path == "/:memory:":
+			info.Name = ":memory:"
+		default:
+			info.Name = strings.TrimPrefix(u.Path, "/")
+		}
+	} else {
+		info.Name, _ = url.PathUnescape(strings.TrimPrefix(u.EscapedPath(), "/"))
+		info.Host = u.Hostname()
+		info.Port = u.Port()
+		if info.Port == "" && info.Host != "" {
+			info.Port = databaseURLDefaultPorts[engine]
+		}
+		if u.User != nil {
+			info.User = u.User.Username()
+			if password, ok := u.User.Password(); ok {
+				info.Password = password
+			}
+		}
+	}
+
+	query := u.Query()
+	if len(query) > 0 {
+		info.Options = make(map[string]string, len(query))
+		for key, values := range query {
+			info.Options[key] = values[len(values)-1]
+		}
+		// A host query parameter overrides the authority, e.g. unix sockets.
+		if host := info.Options["host"]; host != "" && info.Host == "" {
+			info.Host = host
+			delete(info.Options, "host")
+		}
+	}
+	return info, nil
+}
+
+// databaseURLCandidate is a DATABASE_URL value and where it was found
+type databaseURLCandidate struct {
+	value  string
+	source string
+}
+
+// databaseURLCandidates lists DATABASE_URL values in the order Django would most likely see them.
+// Containerized projects read compose env first since manage.py runs inside the service.
+func databaseURLCandidates(p *Project, lookupEnv func(string) (string, bool)) []databaseURLCandidate {
+	var candidates []databaseURLCandidate
+	add := func(value, source string) {
+		if value = strings.TrimSpace(value); value != "" {
+			candidates = append(candidates, databaseURLCandidate{value: value, source: source})
+		}
+	}
+
+	fromCompose := func() {
+		if p.Compose != nil {
+			add(p.Compose.Environment(p.DockerService)["DATABASE_URL"], "compose service "+p.DockerService)
+		}
+	}
+	fromProcess := func() {
+		if value, ok := lookupEnv("DATABASE_URL"); ok {
+			add(value, "process environment")
+		}
+	}
+
+	if p.HasDocker {
+		fromCompose()
+		fromProcess()
+	} else {
+		fromProcess()
+	}
+	if content, err := os.ReadFile(filepath.Join(p.RootDir, ".env")); err == nil {
+		add(parseDotEnv(string(content))["DATABASE_URL"], ".env")
+	}
+	if !p.HasDocker {
+		fromCompose()
+	}
+	return candidates
+}
+
+// resolveDatabaseURL fills DatabaseInfo from the first parseable DATABASE_URL.
+// When Django already reported the live settings, only the password is taken,
+// and only if the URL points at the same database.
+func resolveDatabaseURL(p *Project, fromDjango bool, lookupEnv func(string) (string, bool)) {
+	for _, candidate := range databaseURLCandidates(p, lookupEnv) {
+		info, err := ParseDatabaseURL(candidate.value)
+		if err != nil {
+			continue
+		}
+		info.Source = "DATABASE_URL (" + candidate.source + ")"
+		if info.Password != "" {
+			info.PasswordSource = info.Source
+		}
+
+		if fromDjango {
+			if p.Database.Password == "" && info.Password != "" && info.Name == p.Database.Name &&
+				(p.Database.Host == "" || info.Host == p.Database.Host) {
+				p.Database.Password = info.Password
+				p.Database.PasswordSource = info.PasswordSource
+			}
+			return
+		}
+		p.Database = info
+		return
+	}
+}
+
+// parseSettingsDatabaseURL reads the default URL from dj_database_url / django-environ calls
+func parseSettingsDatabaseURL(line string) (DatabaseInfo, bool) {
+	loc := settingsDatabaseURLPattern.FindStringIndex(line)
+	if loc == nil {
+		return DatabaseInfo{}, false
+	}
+	match := settingsDefaultURLPattern.FindStringSubmatch(line[loc[1]:])
+	if match == nil {
+		return DatabaseInfo{}, false
+	}
+	raw := match[1]
+	templated := strings.Contains(raw, "{")
+	if templated {
+		// f-string defaults such as f"sqlite:///{BASE_DIR / 'db.sqlite3'}" only reveal the scheme.
+		raw = raw[:strings.Index(raw, "://")+3]
+	}
+	info, err := ParseDatabaseURL(raw)
+	if err != nil {
+		return DatabaseInfo{}, false
+	}
+	if templated {
+		info.Name = ""
+	}
+	info.Source = "settings default URL"
+	if info.Password != "" {
+		info.PasswordSource = info.Source
+	}
+	return info, true
+}
+
+// OptionsString renders connection options as sorted key=value pairs
+func (d DatabaseInfo) OptionsString() string {
+	keys := make([]string, 0, len(d.Options))
+	for key := range d.Options {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, key+"="+d.Options[key])
+	}
+	return strings.Join(parts, " ")
+}
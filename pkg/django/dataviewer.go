@@ -1,19 +1,30 @@
 package django
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
 )
 
 type commandRunner interface {
 	RunCommand(args ...string) (string, error)
 }
 
+// contextCommandRunner is a commandRunner that supports cancellation and per-operation timeouts
+type contextCommandRunner interface {
+	commandRunner
+	RunCommandContext(ctx context.Context, args ...string) (string, error)
+	OperationContext(parent context.Context, op string) (context.Context, context.CancelFunc)
+}
+
 // DataViewer provides methods for viewing and editing model data
 type DataViewer struct {
 	project commandRunner
+	ctx     context.Context
 }
 
 // NewDataViewer creates a new data viewer
@@ -21,9 +32,31 @@ func NewDataViewer(project commandRunner) *DataViewer {
 	return &DataViewer{project: project}
 }
 
+// WithContext returns a copy of the viewer whose scripts are cancelled with ctx
+func (dv *DataViewer) WithContext(ctx context.Context) *DataViewer {
+	next := *dv
+	next.ctx = ctx
+	return &next
+}
+
+// runShell runs `manage.py shell -c code`, bounded by the shell timeout when the runner supports it
+func (dv *DataViewer) runShell(code string) (string, error) {
+	runner, ok := dv.project.(contextCommandRunner)
+	if !ok {
+		return dv.project.RunCommand("shell", "-c", code)
+	}
+	parent := dv.ctx
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := runner.OperationContext(parent, config.TimeoutShell)
+	defer cancel()
+	return runner.RunCommandContext(ctx, "shell", "-c", code)
+}
+
 // runPythonScript executes Python code and returns parsed JSON result
 func (dv *DataViewer) runPythonScript(code string) (map[string]interface{}, error) {
-	output, err := dv.project.RunCommand("shell", "-c", code)
+	output, err := dv.runShell(code)
 	if err != nil {
 		out := strings.TrimSpace(output)
 		if out == "" {
@@ -1,6 +1,7 @@
 package django
 
 import (
+	"context"
 	"testing"
 )
 
@@ -18,6 +19,10 @@ func (m *MockProject) RunCommand(args ...string) (string, error) {
 	return m.mockResponse, nil
 }
 
+func (m *MockProject) RunCommandContext(ctx context.Context, args ...string) (string, error) {
+	return m.RunCommand(args...)
+}
+
 // TestDataViewer tests the data viewer functionality
 func TestDataViewer(t *testing.T) {
 	// Create mock project that simulates Django commands
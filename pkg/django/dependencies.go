@@ -17,6 +17,8 @@ type DependencyStatus struct {
 // DependencyReport is a complete dependency check result for a project.
 type DependencyReport struct {
 	ProjectRoot     string             `json:"project_root"`
+	Executor        string             `json:"executor,omitempty"`
+	ComposeError    string             `json:"compose_error,omitempty"`
 	Dependencies    []DependencyStatus `json:"dependencies"`
 	MissingRequired int                `json:"missing_required"`
 	MissingOptional int                `json:"missing_optional"`
@@ -32,6 +34,12 @@ func (r DependencyReport) String() string {
 	var b strings.Builder
 
 	fmt.Fprintf(&b, "Dependency Report (%s)\n", r.ProjectRoot)
+	if r.Executor != "" {
+		fmt.Fprintf(&b, "executor: %s\n", r.Executor)
+	}
+	if r.ComposeError != "" {
+		fmt.Fprintf(&b, "compose: %s (service detection fell back to a line scan)\n", r.ComposeError)
+	}
 	for _, dep := range r.Dependencies {
 		state := "ok"
 		if !dep.Available {
@@ -59,6 +67,13 @@ func (r DependencyReport) String() string {
 	return b.String()
 }
 
+// executorLaunchers maps executors to the command they shell out to, beyond python and docker
+var executorLaunchers = map[string]string{
+	ExecutorUV:     "uv",
+	ExecutorPoetry: "poetry",
+	ExecutorSSH:    "ssh",
+}
+
 func buildDependencyReport(project *Project, hasCmd func(string) bool) DependencyReport {
 	if project == nil {
 		return DependencyReport{
@@ -77,7 +92,8 @@ func buildDependencyReport(project *Project, hasCmd func(string) bool) Dependenc
 	}
 
 	report := DependencyReport{
-		ProjectRoot: project.RootDir,
+		ProjectRoot:  project.RootDir,
+		ComposeError: project.ComposeError,
 	}
 
 	hasPython := hasCmd("python") || hasCmd("python3")
@@ -109,6 +125,19 @@ func buildDependencyReport(project *Project, hasCmd func(string) bool) Dependenc
 		Reason:    "Required for Docker-managed Django projects and container actions.",
 	})
 
+	if project.Executor != nil {
+		report.Executor = project.ExecutorLabel()
+		if launcher := executorLaunchers[project.Executor.Name()]; launcher != "" {
+			report.Dependencies = append(report.Dependencies, DependencyStatus{
+				Name:       launcher,
+				Required:   true,
+				Available:  hasCmd(launcher),
+				Reason:     fmt.Sprintf("Runs manage.py for the %s executor.", project.Executor.Name()),
+				Workaround: "Install it, or pick another backend under Project > Execution Backend.",
+			})
+		}
+	}
+
 	report.Dependencies = append(report.Dependencies, DependencyStatus{
 		Name:       "git",
 		Required:   false,
@@ -1,6 +1,7 @@
 package django
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,8 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
 )
 
 // Project represents a Django project
@@ -21,14 +24,29 @@ type Project struct {
 	Migrations        []Migration
 	Database          DatabaseInfo
 	HasDocker         bool
-	DockerService     string // Docker service name for django (e.g., "web", "django")
-	DockerComposeFile string // Path to compose file (docker-compose.yml / compose.yaml)
+	DockerService     string          // Docker service name for django (e.g., "web", "django")
+	DockerComposeFile string          // Path to compose file (docker-compose.yml / compose.yaml)
+	Compose           *ComposeProject // Merged compose services; nil without a compose file
+	ComposeError      string          // Why the compose files could not be parsed; Compose then holds a scanned fallback
 	HasUV             bool
 	HasPoetry         bool
 	HasPytest         bool
-	InstalledApps     []string
-	Middleware        []string
-	ServerRunning     bool
+	Executor          Executor               // How manage.py runs; see ConfigureExecutor
+	ExecutorConfig    config.ExecutorConfig  // Backend selection from .lazy-django/config.json
+	Timeouts          config.TimeoutConfig   // Per-operation limits from .lazy-django/config.json
+	Server            config.ServerConfig    // Dev server bind address from .lazy-django/config.json
+	ProcessConfig     config.ProcessesConfig // Auxiliary processes from .lazy-django/config.json
+	ExecutorError     string                 // Why the configured backend could not be used
+	fallbackExecutor  Executor               // local executor used when an auto-detected docker backend was unreachable at configuration
+	// DockerServiceFound is called, possibly from a background goroutine, when a command only
+	// worked in another compose service. The owner of p should apply it with PromoteDockerService
+	// on its own goroutine; when nil, RunCommandContext promotes the service in place.
+	DockerServiceFound func(service string)
+	InstalledApps      []string
+	Middleware         []string
+	WSGIApplication    string // settings.WSGI_APPLICATION, e.g. mysite.wsgi.application
+	ASGIApplication    string // settings.ASGI_APPLICATION
+	ServerRunning      bool
 }
 
 // DiscoverOptions controls the depth of project discovery.
@@ -36,6 +54,9 @@ type DiscoverOptions struct {
 	// DeepScan runs Django management commands to hydrate settings, models, and migrations.
 	// When false, discovery is filesystem-only and significantly faster.
 	DeepScan bool
+	// Executor overrides the configured execution backend for this run, e.g. from --executor.
+	// LAZY_DJANGO_EXECUTOR is used when it is empty.
+	Executor string
 }
 
 // App represents a Django app
@@ -62,17 +83,16 @@ type Migration struct {
 
 // DatabaseInfo contains database configuration
 type DatabaseInfo struct {
-	Engine   string
-	Name     string
-	Host     string
-	Port     string
-	User     string
-	IsUsable bool
-}
-
-type composeServiceSpec struct {
-	name string
-	body []string
+	Engine         string
+	Name           string
+	Host           string
+	Port           string
+	User           string
+	Password       string            `json:"-"`
+	PasswordSource string            // Where Password came from, e.g. "DATABASE_URL (.env)"
+	Options        map[string]string // Connection options from DATABASE_URL query parameters
+	Source         string            // Where the configuration came from when not read from Django
+	IsUsable       bool
 }
 
 // DiscoverProject finds Django project in current or parent directories
@@ -111,13 +131,22 @@ func buildProjectWithOptions(rootDir, managePy string, opts DiscoverOptions) (*P
 	// Check for Docker first
 	// Find compose file (support docker-compose.yml, docker-compose.yaml, compose.yaml)
 	proj.DockerComposeFile = findComposeFile(rootDir)
+	if compose, err := LoadComposeProject(rootDir); err != nil {
+		proj.ComposeError = err.Error()
+		if content, readErr := os.ReadFile(proj.DockerComposeFile); readErr == nil {
+			proj.Compose = &ComposeProject{Files: []string{proj.DockerComposeFile}, Services: scanComposeServices(string(content))}
+		}
+	} else if compose != nil {
+		proj.Compose = compose
+		proj.DockerComposeFile = compose.Files[0]
+	}
 	if proj.DockerComposeFile != "" || fileExists(filepath.Join(rootDir, "Dockerfile")) {
 		proj.HasDocker = true
 	}
 
 	// Discover Docker service if compose file found
 	if proj.DockerComposeFile != "" {
-		proj.DockerService = findDjangoService(proj.DockerComposeFile)
+		proj.DockerService = findDjangoService(proj.Compose)
 	}
 
 	// Discover apps
@@ -129,8 +158,27 @@ func buildProjectWithOptions(rootDir, managePy string, opts DiscoverOptions) (*P
 	// Check for other tools
 	proj.HasUV = commandExists("uv")
 	proj.HasPoetry = fileExists(filepath.Join(rootDir, "poetry.lock"))
-	proj.HasPytest = fileExists(filepath.Join(rootDir, "pytest.ini")) ||
-		fileExists(filepath.Join(rootDir, "pyproject.toml"))
+	proj.HasPytest = detectPytest(rootDir)
+
+	// Select how manage.py runs: opts.Executor, then LAZY_DJANGO_EXECUTOR, override the project config.
+	executorCfg := config.ExecutorConfig{}
+	if projectCfg, err := config.LoadProjectConfig(rootDir); err == nil {
+		executorCfg = projectCfg.Executor
+		proj.Timeouts = projectCfg.Timeouts
+		proj.Server = projectCfg.Server
+		proj.ProcessConfig = projectCfg.Processes
+	} else {
+		proj.ExecutorError = err.Error()
+	}
+	if backend := strings.TrimSpace(opts.Executor); backend != "" {
+		executorCfg.Backend = backend
+	} else if backend := strings.TrimSpace(os.Getenv("LAZY_DJANGO_EXECUTOR")); backend != "" {
+		executorCfg.Backend = backend
+	}
+	if err := proj.ConfigureExecutor(executorCfg); err != nil {
+		proj.ExecutorError = err.Error()
+		_ = proj.ConfigureExecutor(config.ExecutorConfig{})
+	}
 
 	if opts.DeepScan {
 		// Discover settings and database info.
@@ -184,6 +232,23 @@ func discoverApps(rootDir string) ([]App, error) {
 }
 
 // fileExists checks if file exists
+// detectPytest reports whether the project configures pytest rather than Django's test runner
+func detectPytest(rootDir string) bool {
+	if fileExists(filepath.Join(rootDir, "pytest.ini")) || fileExists(filepath.Join(rootDir, "conftest.py")) {
+		return true
+	}
+	for name, marker := range map[string]string{
+		"pyproject.toml": "[tool.pytest",
+		"setup.cfg":      "[tool:pytest]",
+		"tox.ini":        "[pytest]",
+	} {
+		if data, err := os.ReadFile(filepath.Join(rootDir, name)); err == nil && strings.Contains(string(data), marker) {
+			return true
+		}
+	}
+	return false
+}
+
 func fileExists(path string) bool {
 	_, err := os.Stat(path)
 	return err == nil
@@ -221,39 +286,61 @@ func extractLikelySettingsModule(line string) string {
 	return ""
 }
 
-// RunCommand executes Django management command
+// RunCommand executes a Django management command through the project's executor,
+// bounded by the configured command timeout
 func (p *Project) RunCommand(args ...string) (string, error) {
-	if p.HasDocker && p.DockerService != "" && isDockerAvailable() {
-		output, err := p.runDockerCommandForService(p.DockerService, args...)
-		if err == nil || !shouldRetryWithAlternateDockerService(output, err) {
-			return output, err
-		}
+	ctx, cancel := p.OperationContext(context.Background(), config.TimeoutCommand)
+	defer cancel()
+	return p.RunCommandContext(ctx, args...)
+}
 
-		for _, service := range findDjangoServiceCandidates(p.DockerComposeFile) {
-			service = strings.TrimSpace(service)
-			if service == "" || service == p.DockerService {
-				continue
-			}
+// RunCommandContext executes a Django management command, killing its process group when
+// ctx is cancelled. Errors caused by ctx wrap context.Canceled or context.DeadlineExceeded.
+func (p *Project) RunCommandContext(ctx context.Context, args ...string) (string, error) {
+	executor := p.ActiveExecutor()
+	output, err := runExecutorCommand(ctx, executor, args...)
 
-			nextOutput, nextErr := p.runDockerCommandForService(service, args...)
-			if nextErr == nil {
-				// Promote a working service for subsequent commands.
-				p.DockerService = service
-				return nextOutput, nil
-			}
-			if !shouldRetryWithAlternateDockerService(nextOutput, nextErr) {
-				return nextOutput, nextErr
-			}
-			output, err = nextOutput, nextErr
+	compose, ok := executor.(*composeExecutor)
+	if err == nil || ctx.Err() != nil || !ok || compose.run || p.ExecutorConfig.Service != "" || !shouldRetryWithAlternateDockerService(output, err) {
+		return output, err
+	}
+
+	for _, service := range findDjangoServiceCandidates(p.Compose) {
+		service = strings.TrimSpace(service)
+		if service == "" || service == compose.service {
+			continue
 		}
 
-		return output, err
+		alternate := &composeExecutor{service: service, fileArgs: compose.fileArgs, rootDir: compose.rootDir}
+		nextOutput, nextErr := runExecutorCommand(ctx, alternate, args...)
+		if nextErr == nil {
+			// Promote a working service for subsequent commands.
+			if p.DockerServiceFound != nil {
+				p.DockerServiceFound(service)
+			} else {
+				p.PromoteDockerService(service)
+			}
+			return nextOutput, nil
+		}
+		if ctx.Err() != nil || !shouldRetryWithAlternateDockerService(nextOutput, nextErr) {
+			return nextOutput, nextErr
+		}
+		output, err = nextOutput, nextErr
 	}
 
-	cmd := exec.Command(pythonBinary(), append([]string{p.ManagePyPath}, args...)...)
-	cmd.Dir = p.RootDir
-	output, err := cmd.CombinedOutput()
-	return string(output), err
+	return output, err
+}
+
+func runExecutorCommand(ctx context.Context, executor Executor, args ...string) (string, error) {
+	output, err := executor.Command(ctx, args...).CombinedOutput()
+	return string(output), contextError(ctx, err)
+}
+
+// runDiscoveryCommand runs a read-only introspection command bounded by the discovery timeout
+func (p *Project) runDiscoveryCommand(args ...string) (string, error) {
+	ctx, cancel := p.OperationContext(context.Background(), config.TimeoutDiscovery)
+	defer cancel()
+	return p.RunCommandContext(ctx, args...)
 }
 
 func shouldRetryWithAlternateDockerService(output string, err error) bool {
@@ -284,48 +371,14 @@ func shouldRetryWithAlternateDockerService(output string, err error) bool {
 	return false
 }
 
-func (p *Project) runDockerCommandForService(service string, args ...string) (string, error) {
-	cmd := p.buildDockerCommandForService(service, args...)
-	cmd.Dir = p.RootDir
-	output, err := cmd.CombinedOutput()
-	return string(output), err
-}
-
 // buildDockerCommand creates a Docker-based manage.py command
 func (p *Project) buildDockerCommand(args ...string) *exec.Cmd {
-	return p.buildDockerCommandForService(p.DockerService, args...)
-}
-
-func (p *Project) buildDockerCommandForService(service string, args ...string) *exec.Cmd {
-	service = strings.TrimSpace(service)
-	if service == "" {
-		service = p.DockerService
-	}
-
-	composeArgs := []string{"compose"}
-	if p.DockerComposeFile != "" {
-		composeArgs = append(composeArgs, "-f", p.DockerComposeFile)
-	}
-	composeArgs = append(composeArgs, "exec", "-T", service, "python", "manage.py")
-	composeArgs = append(composeArgs, args...)
-
-	// Try docker (v2) first, fallback to docker-compose
-	if dockerPath, err := exec.LookPath("docker"); err == nil {
-		return exec.Command(dockerPath, composeArgs...)
-	}
-	if dcPath, err := exec.LookPath("docker-compose"); err == nil {
-		// Remove "compose" from args for docker-compose v1
-		dcArgs := composeArgs[1:]
-		return exec.Command(dcPath, dcArgs...)
-	}
-
-	// Fallback to direct python if docker tools not available
-	return exec.Command(pythonBinary(), append([]string{p.ManagePyPath}, args...)...)
+	return (&composeExecutor{service: p.DockerService, fileArgs: p.ComposeFileArgs(), rootDir: p.RootDir}).Command(context.Background(), args...)
 }
 
 // GetMigrations returns list of migrations for an app
 func (p *Project) GetMigrations(appName string) ([]string, error) {
-	output, err := p.RunCommand("showmigrations", appName, "--list")
+	output, err := p.runDiscoveryCommand("showmigrations", appName, "--list")
 	if err != nil {
 		return nil, err
 	}
@@ -346,15 +399,16 @@ func (p *Project) DiscoverSettings() {
 	if module := os.Getenv("DJANGO_SETTINGS_MODULE"); module != "" {
 		p.SettingsModule = module
 	} else {
-		p.SettingsModule = findSettingsModule(p.RootDir)
+		p.SettingsModule = findSettingsModule(p.RootDir, p.Compose)
 	}
 
 	// Try Django shell first (most accurate)
-	cmd := `import json; from django.conf import settings; print(json.dumps({"apps": list(settings.INSTALLED_APPS), "middleware": list(getattr(settings, "MIDDLEWARE", [])), "debug": getattr(settings, "DEBUG", False), "databases": {k: {"ENGINE": v.get("ENGINE", ""), "NAME": v.get("NAME", ""), "HOST": v.get("HOST", ""), "PORT": str(v.get("PORT", "")), "USER": v.get("USER", "")} for k, v in settings.DATABASES.items()}}))`
-	output, err := p.RunCommand("shell", "-c", cmd)
+	cmd := `import json; from django.conf import settings; print(json.dumps({"apps": list(settings.INSTALLED_APPS), "middleware": list(getattr(settings, "MIDDLEWARE", [])), "debug": getattr(settings, "DEBUG", False), "wsgi": getattr(settings, "WSGI_APPLICATION", None) or "", "asgi": getattr(settings, "ASGI_APPLICATION", None) or "", "databases": {k: {"ENGINE": v.get("ENGINE", ""), "NAME": v.get("NAME", ""), "HOST": v.get("HOST", ""), "PORT": str(v.get("PORT", "")), "USER": v.get("USER", "")} for k, v in settings.DATABASES.items()}}))`
+	output, err := p.runDiscoveryCommand("shell", "-c", cmd)
 	if err == nil {
 		p.parseSettingsJSON(output)
 	}
+	fromDjango := p.Database.IsUsable
 
 	// Fallback: parse settings files directly if Django shell failed
 	if len(p.InstalledApps) == 0 || !p.Database.IsUsable {
@@ -362,9 +416,12 @@ func (p *Project) DiscoverSettings() {
 	}
 
 	// If we have Docker, try to resolve database environment variables from compose
-	if p.HasDocker && p.DockerComposeFile != "" {
+	if p.HasDocker && p.Compose != nil && !fromDjango {
 		resolveDockerDatabaseEnv(p)
 	}
+
+	// DATABASE_URL (dj-database-url / django-environ) wins over guesses from settings files.
+	resolveDatabaseURL(p, fromDjango, os.LookupEnv)
 }
 
 // parseSettingsJSON extracts settings from Django shell JSON output
@@ -399,6 +456,13 @@ func (p *Project) parseSettingsJSON(output string) {
 		}
 	}
 
+	if wsgi, ok := data["wsgi"].(string); ok && wsgi != "" {
+		p.WSGIApplication = wsgi
+	}
+	if asgi, ok := data["asgi"].(string); ok && asgi != "" {
+		p.ASGIApplication = asgi
+	}
+
 	// Extract database config
 	if databases, ok := data["databases"].(map[string]interface{}); ok {
 		if defaultDB, ok := databases["default"].(map[string]interface{}); ok {
@@ -426,7 +490,7 @@ func (p *Project) parseSettingsJSON(output string) {
 func (p *Project) DiscoverModels() {
 	// Use Django's introspection to get actual models
 	cmd := `import json; from django.apps import apps; models_data = [{"app": m._meta.app_label, "name": m.__name__, "fields": len(m._meta.fields)} for m in apps.get_models()]; print(json.dumps(models_data))`
-	output, err := p.RunCommand("shell", "-c", cmd)
+	output, err := p.runDiscoveryCommand("shell", "-c", cmd)
 
 	if err == nil {
 		var modelsData []map[string]interface{}
@@ -509,7 +573,7 @@ func parseModelsFile(path string, appName string) []Model {
 
 // DiscoverMigrations finds all migrations in the project
 func (p *Project) DiscoverMigrations() {
-	output, err := p.RunCommand("showmigrations", "--list")
+	output, err := p.runDiscoveryCommand("showmigrations", "--list")
 	if err == nil {
 		// Try Django command first
 		var currentApp string
@@ -571,91 +635,39 @@ func (p *Project) DiscoverMigrations() {
 	}
 }
 
-// GetURLPatterns returns URL patterns from Django
-func (p *Project) GetURLPatterns() ([]string, error) {
-	cmd := `from django.urls import get_resolver; from django.urls.resolvers import URLPattern, URLResolver; def show_urls(patterns, prefix=''):
-    for pattern in patterns:
-        if isinstance(pattern, URLPattern):
-            print(f"{prefix}{pattern.pattern}")
-        elif isinstance(pattern, URLResolver):
-            show_urls(pattern.url_patterns, prefix + str(pattern.pattern))
-show_urls(get_resolver().url_patterns)`
-	output, err := p.RunCommand("shell", "-c", strings.ReplaceAll(cmd, "\n", "; "))
-	if err != nil {
-		return nil, err
-	}
-	return strings.Split(strings.TrimSpace(output), "\n"), nil
-}
-
-// IsServerRunning checks if dev server is running
+// IsServerRunning checks whether something is listening on the dev server port
 func (p *Project) IsServerRunning() bool {
-	// Check if port 8000 is in use (basic check)
-	cmd := exec.Command("lsof", "-i", ":8000", "-sTCP:LISTEN")
-	err := cmd.Run()
-	return err == nil
+	host, port := p.ServerAddress()
+	return !PortAvailable(host, port)
 }
 
-func leadingSpaces(line string) int {
-	return len(line) - len(strings.TrimLeft(line, " "))
-}
-
-func stripYAMLComment(line string) string {
-	// Keep this conservative; compose service names do not need quote-aware parsing.
-	if idx := strings.Index(line, "#"); idx >= 0 {
-		return line[:idx]
+// ComposeFileArgs returns the -f flags for docker compose, covering overrides when the
+// compose model loaded and falling back to the base file otherwise
+func (p *Project) ComposeFileArgs() []string {
+	if p.Compose != nil && len(p.Compose.Files) > 0 {
+		return p.Compose.FileArgs()
 	}
-	return line
+	if p.DockerComposeFile != "" {
+		return []string{"-f", p.DockerComposeFile}
+	}
+	return nil
 }
 
-func parseComposeServices(composeContent string) []composeServiceSpec {
-	lines := strings.Split(composeContent, "\n")
-	inServices := false
-	servicesIndent := -1
-
-	var services []composeServiceSpec
-	current := -1
-	for _, raw := range lines {
-		line := stripYAMLComment(raw)
-		if strings.TrimSpace(line) == "" {
-			continue
-		}
-
-		indent := leadingSpaces(line)
-		trimmed := strings.TrimSpace(line)
-
-		if !inServices {
-			if trimmed == "services:" {
-				inServices = true
-				servicesIndent = indent
-			}
-			continue
-		}
-
-		if indent <= servicesIndent {
-			break
-		}
-
-		if indent == servicesIndent+2 && strings.HasSuffix(trimmed, ":") {
-			name := strings.TrimSpace(strings.TrimSuffix(trimmed, ":"))
-			if name == "" {
-				continue
-			}
-			services = append(services, composeServiceSpec{name: name})
-			current = len(services) - 1
-			continue
-		}
-
-		if current >= 0 && indent > servicesIndent+2 {
-			services[current].body = append(services[current].body, trimmed)
-		}
+// composeServiceText flattens the fields service scoring looks at
+func composeServiceText(service ComposeService) string {
+	parts := []string{service.Image, service.Command, service.Entrypoint}
+	for key, value := range service.Environment {
+		parts = append(parts, key+"="+value)
 	}
-
-	return services
+	if service.Healthcheck != nil {
+		parts = append(parts, service.Healthcheck.Test...)
+	}
+	return strings.Join(parts, "\n")
 }
 
-func scoreDjangoService(spec composeServiceSpec) int {
-	name := strings.ToLower(strings.TrimSpace(spec.name))
-	body := strings.ToLower(strings.Join(spec.body, "\n"))
+func scoreDjangoService(service ComposeService) int {
+	name := strings.ToLower(strings.TrimSpace(service.Name))
+	body := strings.ToLower(composeServiceText(service))
 	score := 0
 
 	switch name {
@@ -706,23 +718,15 @@ func scoreDjangoService(spec composeServiceSpec) int {
 	if strings.Contains(body, "redis-server") || strings.Contains(body, "postgres:") {
 		score -= 120
 	}
+	if service.Build != nil {
+		score += 20
+	}
 
 	return score
 }
 
-func findDjangoServiceCandidates(composePath string) []string {
-	composePath = strings.TrimSpace(composePath)
-	if composePath == "" {
-		return nil
-	}
-
-	content, err := os.ReadFile(composePath)
-	if err != nil {
-		return nil
-	}
-
-	specs := parseComposeServices(string(content))
-	if len(specs) == 0 {
+func findDjangoServiceCandidates(compose *ComposeProject) []string {
+	if compose == nil || len(compose.Services) == 0 {
 		return nil
 	}
 
@@ -730,11 +734,11 @@ func findDjangoServiceCandidates(composePath string) []string {
 		name  string
 		score int
 	}
-	ranked := make([]rankedService, 0, len(specs))
-	for _, spec := range specs {
+	ranked := make([]rankedService, 0, len(compose.Services))
+	for _, service := range compose.Services {
 		ranked = append(ranked, rankedService{
-			name:  spec.name,
-			score: scoreDjangoService(spec),
+			name:  service.Name,
+			score: scoreDjangoService(service),
 		})
 	}
 
@@ -761,9 +765,9 @@ func findDjangoServiceCandidates(composePath string) []string {
 	return names
 }
 
-// findDjangoService finds the Django service name in docker-compose.yml
-func findDjangoService(composePath string) string {
-	candidates := findDjangoServiceCandidates(composePath)
+// findDjangoService picks the compose service most likely to run Django
+func findDjangoService(compose *ComposeProject) string {
+	candidates := findDjangoServiceCandidates(compose)
 	if len(candidates) == 0 {
 		return "web"
 	}
@@ -771,33 +775,14 @@ func findDjangoService(composePath string) string {
 }
 
 // findSettingsModule recursively searches for Django settings module
-func findSettingsModule(rootDir string) string {
+func findSettingsModule(rootDir string, compose *ComposeProject) string {
 	var settingsModule string
 
-	// First try to read from compose.yaml for DJANGO_SETTINGS_MODULE
-	composeFile := findComposeFile(rootDir)
-	if composeFile != "" {
-		content, err := os.ReadFile(composeFile)
-		if err == nil {
-			lines := strings.Split(string(content), "\n")
-			for _, line := range lines {
-				if strings.Contains(line, "DJANGO_SETTINGS_MODULE") {
-					trimmed := strings.TrimSpace(strings.Split(line, "#")[0])
-					trimmed = strings.TrimPrefix(trimmed, "- ")
-
-					var module string
-					if idx := strings.Index(trimmed, "="); idx >= 0 {
-						module = strings.TrimSpace(trimmed[idx+1:])
-					} else if idx := strings.Index(trimmed, ":"); idx >= 0 {
-						module = strings.TrimSpace(trimmed[idx+1:])
-					}
-
-					module = strings.Trim(module, "\"'")
-					if module != "" {
-						return module
-					}
-				}
-			}
+	// First try the compose environment for DJANGO_SETTINGS_MODULE
+	if compose != nil {
+		env := compose.Environment(findDjangoService(compose))
+		if module := strings.TrimSpace(env["DJANGO_SETTINGS_MODULE"]); module != "" {
+			return module
 		}
 	}
 
@@ -895,85 +880,13 @@ func findComposeFile(rootDir string) string {
 	return ""
 }
 
-// extractEnvFromCompose reads environment variables from compose file and referenced .env files
-func extractEnvFromCompose(composeFile string) map[string]string {
-	env := make(map[string]string)
-
-	content, err := os.ReadFile(composeFile)
-	if err != nil {
-		return env
-	}
-
-	rootDir := filepath.Dir(composeFile)
-	lines := strings.Split(string(content), "\n")
-	inEnvironment := false
-
-	for _, line := range lines {
-		trimmed := strings.TrimSpace(line)
-
-		// Check for env_file references
-		if strings.HasPrefix(trimmed, "env_file:") {
-			// Next lines contain file references
-			continue
-		}
-		if strings.HasPrefix(trimmed, "- ./") || strings.HasPrefix(trimmed, "- ") {
-			// This might be an env file reference
-			envFilePath := strings.TrimPrefix(trimmed, "- ")
-			envFilePath = strings.Trim(envFilePath, "\"'")
-			if strings.HasSuffix(envFilePath, ".env") || strings.Contains(envFilePath, ".env") {
-				fullPath := filepath.Join(rootDir, envFilePath)
-				if envContent, err := os.ReadFile(fullPath); err == nil {
-					for _, envLine := range strings.Split(string(envContent), "\n") {
-						envLine = strings.TrimSpace(envLine)
-						if envLine == "" || strings.HasPrefix(envLine, "#") {
-							continue
-						}
-						if idx := strings.Index(envLine, "="); idx > 0 {
-							key := strings.TrimSpace(envLine[:idx])
-							value := strings.TrimSpace(envLine[idx+1:])
-							env[key] = value
-						}
-					}
-				}
-			}
-		}
-
-		// Check for environment section
-		if strings.HasPrefix(trimmed, "environment:") {
-			inEnvironment = true
-			continue
-		}
-
-		// Parse environment variables
-		if inEnvironment {
-			// End of environment section
-			if trimmed != "" && !strings.HasPrefix(trimmed, "-") && !strings.Contains(trimmed, "=") && strings.HasSuffix(trimmed, ":") {
-				inEnvironment = false
-				continue
-			}
-
-			// Parse environment variable line
-			if strings.HasPrefix(trimmed, "- ") {
-				envVar := strings.TrimPrefix(trimmed, "- ")
-				if idx := strings.Index(envVar, "="); idx > 0 {
-					key := strings.TrimSpace(envVar[:idx])
-					value := strings.TrimSpace(envVar[idx+1:])
-					env[key] = value
-				}
-			}
-		}
-	}
-
-	return env
-}
-
 // resolveDockerDatabaseEnv resolves database configuration from Docker environment
 func resolveDockerDatabaseEnv(p *Project) {
-	if p.DockerComposeFile == "" {
+	if p.Compose == nil {
 		return
 	}
 
-	env := extractEnvFromCompose(p.DockerComposeFile)
+	env := p.Compose.Environment(p.DockerService)
 
 	// Try to resolve database configuration from environment variables
 	if dbHost, ok := env["DB_HOST"]; ok && dbHost != "" {
@@ -992,10 +905,22 @@ func resolveDockerDatabaseEnv(p *Project) {
 	if dbUser, ok := env["DB_USER"]; ok && dbUser != "" {
 		p.Database.User = dbUser
 	}
+	if dbPassword, ok := env["DB_PASSWORD"]; ok && dbPassword != "" {
+		p.Database.Password = dbPassword
+		p.Database.PasswordSource = "DB_PASSWORD (compose service " + p.DockerService + ")"
+	}
 
-	// If DB_HOST is set, assume PostgreSQL
+	// If DB_HOST is set, use DB_ENGINE when given and otherwise guess from the port
 	if p.Database.Host != "" && p.Database.Engine == "" {
-		p.Database.Engine = "django.db.backends.postgresql"
+		switch {
+		case env["DB_ENGINE"] != "":
+			p.Database.Engine = env["DB_ENGINE"]
+		case p.Database.Port == "3306":
+			p.Database.Engine = "django.db.backends.mysql"
+		default:
+			p.Database.Engine = "django.db.backends.postgresql"
+		}
+		p.Database.Source = "DB_* variables (compose service " + p.DockerService + ")"
 	}
 
 	// Handle sqlite fallback case - check for DB_LOCAL
@@ -1094,6 +1019,27 @@ func parseSettingsFileRecursive(filePath string, p *Project, parsedFiles map[str
 	parseSettingsContent(lines, p)
 }
 
+// settingsStringAssignment reads `NAME = "value"` from a settings line
+func settingsStringAssignment(line, name string) (string, bool) {
+	rest, ok := strings.CutPrefix(line, name)
+	if !ok {
+		return "", false
+	}
+	rest, ok = strings.CutPrefix(strings.TrimSpace(rest), "=")
+	if !ok {
+		return "", false
+	}
+	value := strings.TrimSpace(rest)
+	if len(value) < 2 || (value[0] != '\'' && value[0] != '"') {
+		return "", false
+	}
+	end := strings.IndexByte(value[1:], value[0])
+	if end < 0 {
+		return "", false
+	}
+	return value[1 : end+1], true
+}
+
 // parseSettingsContent extracts configuration from settings file lines
 func parseSettingsContent(lines []string, p *Project) {
 	inDatabases := false
@@ -1109,6 +1055,13 @@ func parseSettingsContent(lines []string, p *Project) {
 			continue
 		}
 
+		if value, ok := settingsStringAssignment(trimmed, "WSGI_APPLICATION"); ok {
+			p.WSGIApplication = value
+		}
+		if value, ok := settingsStringAssignment(trimmed, "ASGI_APPLICATION"); ok {
+			p.ASGIApplication = value
+		}
+
 		// Track DATABASES dictionary
 		if strings.HasPrefix(trimmed, "DATABASES") && strings.Contains(trimmed, "=") {
 			inDatabases = true
@@ -1117,6 +1070,10 @@ func parseSettingsContent(lines []string, p *Project) {
 		if inDatabases {
 			bracketDepth += strings.Count(line, "{") - strings.Count(line, "}")
 
+			if info, ok := parseSettingsDatabaseURL(trimmed); ok {
+				p.Database = info
+			}
+
 			// Extract ENGINE
 			if strings.Contains(trimmed, "'ENGINE'") || strings.Contains(trimmed, "\"ENGINE\"") {
 				if idx := strings.Index(trimmed, ":"); idx > 0 {
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -294,7 +295,12 @@ services:
 		t.Fatalf("failed to write compose file: %v", err)
 	}
 
-	candidates := findDjangoServiceCandidates(composePath)
+	project, err := loadComposeProject(tmpDir, func(string) (string, bool) { return "", false })
+	if err != nil {
+		t.Fatalf("failed to load compose project: %v", err)
+	}
+
+	candidates := findDjangoServiceCandidates(project)
 	if len(candidates) == 0 {
 		t.Fatal("expected at least one django service candidate")
 	}
@@ -302,11 +308,68 @@ services:
 		t.Fatalf("expected django-app as top candidate, got %q (all: %v)", candidates[0], candidates)
 	}
 
-	if got := findDjangoService(composePath); got != "django-app" {
+	if got := findDjangoService(project); got != "django-app" {
 		t.Fatalf("expected django-app as selected service, got %q", got)
 	}
 }
 
+func TestDiscoverProjectExecutorOverride(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "manage.py"), []byte("import sys\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("LAZY_DJANGO_EXECUTOR", "poetry")
+
+	project, err := DiscoverProjectWithOptions(tmpDir, DiscoverOptions{Executor: "uv"})
+	if err != nil {
+		t.Fatalf("DiscoverProjectWithOptions failed: %v", err)
+	}
+	if project.ExecutorConfig.Backend != "uv" || project.ExecutorLabel() != "uv run" {
+		t.Fatalf("expected the option to win over the environment, got %q (%s)", project.ExecutorConfig.Backend, project.ExecutorLabel())
+	}
+
+	project, err = DiscoverProjectWithOptions(tmpDir, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("DiscoverProjectWithOptions failed: %v", err)
+	}
+	if project.ExecutorConfig.Backend != "poetry" {
+		t.Fatalf("expected LAZY_DJANGO_EXECUTOR without the option, got %q", project.ExecutorConfig.Backend)
+	}
+}
+
+func TestDiscoverProjectFallsBackOnInvalidCompose(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tmpDir, "manage.py"), []byte("import sys\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	compose := `services:
+  db:
+    image: postgres:16
+  app:
+    build: .
+    command: python manage.py runserver 0.0.0.0:8000
+    environment:
+      SECRET: "unterminated
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, "compose.yaml"), []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	project, err := DiscoverProjectWithOptions(tmpDir, DiscoverOptions{})
+	if err != nil {
+		t.Fatalf("DiscoverProjectWithOptions failed: %v", err)
+	}
+	if !strings.Contains(project.ComposeError, "compose.yaml") {
+		t.Fatalf("expected the parse error to be kept, got %q", project.ComposeError)
+	}
+	if project.DockerService != "app" {
+		t.Fatalf("expected the line scan to find app, got %q", project.DockerService)
+	}
+	if report := buildDependencyReport(project, func(string) bool { return true }); !strings.Contains(report.String(), "compose: ") {
+		t.Fatalf("expected the doctor report to mention the compose error:\n%s", report.String())
+	}
+}
+
 func TestShouldRetryWithAlternateDockerService(t *testing.T) {
 	err := errors.New("exit status 1")
 	if !shouldRetryWithAlternateDockerService(`service "web" is not running`, err) {
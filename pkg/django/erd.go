@@ -0,0 +1,330 @@
+package django
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// ER diagram output formats
+const (
+	ERFormatMermaid  = "mermaid"
+	ERFormatDOT      = "dot"
+	ERFormatPlantUML = "plantuml"
+)
+
+// ERField is a concrete column shown on an ER entity
+type ERField struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	PrimaryKey bool   `json:"primary_key"`
+}
+
+// ERModel is an entity in the ER diagram
+type ERModel struct {
+	App    string    `json:"app"`
+	Name   string    `json:"name"`
+	Module string    `json:"module"`
+	Fields []ERField `json:"fields"`
+}
+
+// ERRelation is an outbound relation between two models
+type ERRelation struct {
+	From  string `json:"from"` // app.Model
+	To    string `json:"to"`
+	Field string `json:"field"`
+	Kind  string `json:"kind"` // many_to_one, one_to_one, many_to_many
+	Null  bool   `json:"null"`
+}
+
+// ERGraph holds models and their relations
+type ERGraph struct {
+	Models    []ERModel    `json:"models"`
+	Relations []ERRelation `json:"relations"`
+}
+
+// ERFilter narrows an ER graph before rendering
+type ERFilter struct {
+	Apps        []string // app labels to include; empty includes all
+	Focus       string   // app.Model to center on; empty disables
+	Depth       int      // relation hops from Focus
+	HideContrib bool
+}
+
+// Label returns the app.Model label
+func (m ERModel) Label() string {
+	return m.App + "." + m.Name
+}
+
+var contribAppLabels = map[string]bool{
+	"admin":        true,
+	"auth":         true,
+	"contenttypes": true,
+	"sessions":     true,
+	"messages":     true,
+	"staticfiles":  true,
+	"sites":        true,
+	"flatpages":    true,
+	"redirects":    true,
+}
+
+// IsContrib reports whether the model ships with django.contrib
+func (m ERModel) IsContrib() bool {
+	if m.Module != "" {
+		return strings.HasPrefix(m.Module, "django.contrib.")
+	}
+	return contribAppLabels[m.App]
+}
+
+const erGraphScript = `
+import json
+from django.apps import apps
+
+try:
+    models = []
+    relations = []
+    for model in apps.get_models():
+        meta = model._meta
+        label = f'{meta.app_label}.{model.__name__}'
+        models.append({
+            'app': meta.app_label,
+            'name': model.__name__,
+            'module': model.__module__,
+            'fields': [{'name': f.name, 'type': f.get_internal_type(), 'primary_key': bool(f.primary_key)} for f in meta.concrete_fields],
+        })
+        for field in list(meta.concrete_fields) + list(meta.local_many_to_many):
+            if not field.is_relation or field.related_model is None:
+                continue
+            kind = 'many_to_many' if field.many_to_many else ('one_to_one' if field.one_to_one else 'many_to_one')
+            target = field.related_model
+            relations.append({
+                'from': label,
+                'to': f'{target._meta.app_label}.{target.__name__}',
+                'field': field.name,
+                'kind': kind,
+                'null': bool(field.null),
+            })
+    print(json.dumps({'models': models, 'relations': relations}))
+except Exception as e:
+    print(json.dumps({'error': str(e)}))
+`
+
+// GetERGraph loads every installed model and its outbound relations
+func (dv *DataViewer) GetERGraph() (*ERGraph, error) {
+	result, err := dv.runPythonScript(erGraphScript)
+	if err != nil {
+		return nil, fmt.Errorf("load models failed: %w", err)
+	}
+
+	var graph ERGraph
+	if err := mapToStruct(result, &graph); err != nil {
+		return nil, fmt.Errorf("failed to parse models: %w", err)
+	}
+	return &graph, nil
+}
+
+// Filter returns the subgraph selected by f, or an error when f.Focus names no model
+func (g ERGraph) Filter(f ERFilter) (ERGraph, error) {
+	if f.Focus != "" && !slices.ContainsFunc(g.Models, func(m ERModel) bool { return m.Label() == f.Focus }) {
+		return ERGraph{}, fmt.Errorf("focus model %q not found (expected app_label.ModelName)", f.Focus)
+	}
+
+	apps := make(map[string]bool, len(f.Apps))
+	for _, app := range f.Apps {
+		apps[app] = true
+	}
+
+	allowed := make(map[string]bool, len(g.Models))
+	for _, m := range g.Models {
+		if f.HideContrib && m.IsContrib() && m.Label() != f.Focus {
+			continue
+		}
+		if len(apps) > 0 && !apps[m.App] && m.Label() != f.Focus {
+			continue
+		}
+		allowed[m.Label()] = true
+	}
+
+	if f.Focus != "" {
+		neighbours := make(map[string][]string)
+		for _, r := range g.Relations {
+			if allowed[r.From] && allowed[r.To] {
+				neighbours[r.From] = append(neighbours[r.From], r.To)
+				neighbours[r.To] = append(neighbours[r.To], r.From)
+			}
+		}
+
+		reached := map[string]bool{f.Focus: true}
+		frontier := []string{f.Focus}
+		for hop := 0; hop < f.Depth && len(frontier) > 0; hop++ {
+			var next []string
+			for _, label := range frontier {
+				for _, other := range neighbours[label] {
+					if !reached[other] {
+						reached[other] = true
+						next = append(next, other)
+					}
+				}
+			}
+			frontier = next
+		}
+		allowed = reached
+	}
+
+	var out ERGraph
+	for _, m := range g.Models {
+		if allowed[m.Label()] {
+			out.Models = append(out.Models, m)
+		}
+	}
+	for _, r := range g.Relations {
+		if allowed[r.From] && allowed[r.To] {
+			out.Relations = append(out.Relations, r)
+		}
+	}
+	sort.SliceStable(out.Models, func(i, j int) bool { return out.Models[i].Label() < out.Models[j].Label() })
+	return out, nil
+}
+
+// NormalizeERFormat maps a user-supplied format name to one of the ERFormat constants
+func NormalizeERFormat(format string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case ERFormatMermaid, "":
+		return ERFormatMermaid, nil
+	case ERFormatDOT, "graphviz":
+		return ERFormatDOT, nil
+	case ERFormatPlantUML, "puml":
+		return ERFormatPlantUML, nil
+	default:
+		return "", fmt.Errorf("unknown diagram format %q (expected mermaid, dot, or plantuml)", format)
+	}
+}
+
+// RenderERDiagram renders the graph as Mermaid, Graphviz DOT, or PlantUML text
+func RenderERDiagram(g ERGraph, format string) (string, error) {
+	normalized, err := NormalizeERFormat(format)
+	if err != nil {
+		return "", err
+	}
+	switch normalized {
+	case ERFormatDOT:
+		return renderDOTER(g), nil
+	case ERFormatPlantUML:
+		return renderPlantUMLER(g), nil
+	default:
+		return renderMermaidER(g), nil
+	}
+}
+
+// ERFileExtension returns the conventional file extension for a diagram format
+func ERFileExtension(format string) string {
+	normalized, _ := NormalizeERFormat(format)
+	switch normalized {
+	case ERFormatDOT:
+		return ".dot"
+	case ERFormatPlantUML:
+		return ".puml"
+	default:
+		return ".mmd"
+	}
+}
+
+func erIdentifier(label string) string {
+	return strings.ReplaceAll(label, ".", "_")
+}
+
+func mermaidCardinality(r ERRelation) string {
+	switch r.Kind {
+	case "one_to_one":
+		if r.Null {
+			return "|o--||"
+		}
+		return "||--||"
+	case "many_to_many":
+		return "}o--o{"
+	default:
+		if r.Null {
+			return "}o--o|"
+		}
+		return "}o--||"
+	}
+}
+
+func renderMermaidER(g ERGraph) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "erDiagram")
+	for _, m := range g.Models {
+		fmt.Fprintf(&b, "    %s {\n", erIdentifier(m.Label()))
+		for _, f := range m.Fields {
+			if f.PrimaryKey {
+				fmt.Fprintf(&b, "        %s %s PK\n", f.Type, f.Name)
+			} else {
+				fmt.Fprintf(&b, "        %s %s\n", f.Type, f.Name)
+			}
+		}
+		fmt.Fprintln(&b, "    }")
+	}
+	for _, r := range g.Relations {
+		fmt.Fprintf(&b, "    %s %s %s : %s\n", erIdentifier(r.From), mermaidCardinality(r), erIdentifier(r.To), r.Field)
+	}
+	return b.String()
+}
+
+func escapeDOTRecord(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "|", `\|`, "<", `\<`, ">", `\>`)
+	return replacer.Replace(text)
+}
+
+func renderDOTER(g ERGraph) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "digraph ER {")
+	fmt.Fprintln(&b, "    rankdir=LR;")
+	fmt.Fprintln(&b, "    node [shape=record, fontname=\"Helvetica\"];")
+	for _, m := range g.Models {
+		rows := make([]string, 0, len(m.Fields))
+		for _, f := range m.Fields {
+			row := fmt.Sprintf("%s : %s", f.Name, f.Type)
+			if f.PrimaryKey {
+				row += " (PK)"
+			}
+			rows = append(rows, escapeDOTRecord(row)+`\l`)
+		}
+		fmt.Fprintf(&b, "    %q [label=\"{%s|%s}\"];\n", m.Label(), escapeDOTRecord(m.Label()), strings.Join(rows, ""))
+	}
+	for _, r := range g.Relations {
+		style := ""
+		if r.Kind == "many_to_many" {
+			style = ", dir=both"
+		}
+		fmt.Fprintf(&b, "    %q -> %q [label=%q%s];\n", r.From, r.To, r.Field, style)
+	}
+	fmt.Fprintln(&b, "}")
+	return b.String()
+}
+
+func renderPlantUMLER(g ERGraph) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, "@startuml")
+	for _, m := range g.Models {
+		fmt.Fprintf(&b, "entity \"%s\" as %s {\n", m.Label(), erIdentifier(m.Label()))
+		var rest []ERField
+		for _, f := range m.Fields {
+			if f.PrimaryKey {
+				fmt.Fprintf(&b, "  * %s : %s\n", f.Name, f.Type)
+			} else {
+				rest = append(rest, f)
+			}
+		}
+		fmt.Fprintln(&b, "  --")
+		for _, f := range rest {
+			fmt.Fprintf(&b, "  %s : %s\n", f.Name, f.Type)
+		}
+		fmt.Fprintln(&b, "}")
+	}
+	for _, r := range g.Relations {
+		fmt.Fprintf(&b, "%s %s %s : %s\n", erIdentifier(r.From), mermaidCardinality(r), erIdentifier(r.To), r.Field)
+	}
+	fmt.Fprintln(&b, "@enduml")
+	return b.String()
+}
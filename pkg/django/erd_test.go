@@ -0,0 +1,107 @@
+package django
+
+import (
+	"strings"
+	"testing"
+)
+
+func erLabels(g ERGraph) string {
+	labels := make([]string, 0, len(g.Models))
+	for _, m := range g.Models {
+		labels = append(labels, m.Label())
+	}
+	return strings.Join(labels, ",")
+}
+
+func TestERGraphFilter(t *testing.T) {
+	graph := ERGraph{
+		Models: []ERModel{
+			{App: "blog", Name: "Post", Module: "blog.models"},
+			{App: "blog", Name: "Comment", Module: "blog.models"},
+			{App: "shop", Name: "Order", Module: "shop.models"},
+			{App: "auth", Name: "User", Module: "django.contrib.auth.models"},
+		},
+		Relations: []ERRelation{
+			{From: "blog.Post", To: "auth.User", Field: "author", Kind: "many_to_one"},
+			{From: "blog.Comment", To: "blog.Post", Field: "post", Kind: "many_to_one"},
+			{From: "shop.Order", To: "auth.User", Field: "customer", Kind: "many_to_one", Null: true},
+		},
+	}
+	filter := func(f ERFilter) ERGraph {
+		t.Helper()
+		filtered, err := graph.Filter(f)
+		if err != nil {
+			t.Fatalf("Filter(%+v) returned error: %v", f, err)
+		}
+		return filtered
+	}
+
+	if got := erLabels(filter(ERFilter{HideContrib: true})); got != "blog.Comment,blog.Post,shop.Order" {
+		t.Fatalf("unexpected hide-contrib models: %s", got)
+	}
+	if got := erLabels(filter(ERFilter{Apps: []string{"blog"}})); got != "blog.Comment,blog.Post" {
+		t.Fatalf("unexpected app-filtered models: %s", got)
+	}
+
+	focused := filter(ERFilter{Focus: "blog.Post", Depth: 1})
+	if got := erLabels(focused); got != "auth.User,blog.Comment,blog.Post" {
+		t.Fatalf("unexpected depth-1 models: %s", got)
+	}
+	if len(focused.Relations) != 2 {
+		t.Fatalf("expected relations between kept models only, got %#v", focused.Relations)
+	}
+
+	if got := erLabels(filter(ERFilter{Focus: "blog.Post", Depth: 2})); got != "auth.User,blog.Comment,blog.Post,shop.Order" {
+		t.Fatalf("unexpected depth-2 models: %s", got)
+	}
+	if got := erLabels(filter(ERFilter{Focus: "blog.Post", Depth: 2, HideContrib: true})); got != "blog.Comment,blog.Post" {
+		t.Fatalf("expected contrib models to stop traversal, got %s", got)
+	}
+	if _, err := graph.Filter(ERFilter{Focus: "blog.Pst"}); err == nil || !strings.Contains(err.Error(), `"blog.Pst"`) {
+		t.Fatalf("expected an error naming the unknown focus model, got %v", err)
+	}
+}
+
+func TestRenderERDiagramFormats(t *testing.T) {
+	graph := ERGraph{
+		Models: []ERModel{
+			{App: "blog", Name: "Comment", Module: "blog.models", Fields: []ERField{{Name: "id", Type: "BigAutoField", PrimaryKey: true}}},
+			{App: "blog", Name: "Post", Module: "blog.models", Fields: []ERField{{Name: "id", Type: "BigAutoField", PrimaryKey: true}, {Name: "title", Type: "CharField"}}},
+		},
+		Relations: []ERRelation{{From: "blog.Comment", To: "blog.Post", Field: "post", Kind: "many_to_one"}},
+	}
+
+	mermaid, err := RenderERDiagram(graph, "mermaid")
+	if err != nil {
+		t.Fatalf("mermaid render failed: %v", err)
+	}
+	for _, want := range []string{"erDiagram", "blog_Post {", "BigAutoField id PK", "blog_Comment }o--|| blog_Post : post"} {
+		if !strings.Contains(mermaid, want) {
+			t.Fatalf("mermaid output missing %q:\n%s", want, mermaid)
+		}
+	}
+
+	dot, err := RenderERDiagram(graph, "dot")
+	if err != nil {
+		t.Fatalf("dot render failed: %v", err)
+	}
+	for _, want := range []string{"digraph ER {", `"blog.Post" [label="{blog.Post|id : BigAutoField (PK)\ltitle : CharField\l}"];`, `"blog.Comment" -> "blog.Post" [label="post"];`} {
+		if !strings.Contains(dot, want) {
+			t.Fatalf("dot output missing %q:\n%s", want, dot)
+		}
+	}
+
+	puml, err := RenderERDiagram(graph, "plantuml")
+	if err != nil {
+		t.Fatalf("plantuml render failed: %v", err)
+	}
+	for _, want := range []string{"@startuml", `entity "blog.Post" as blog_Post {`, "  * id : BigAutoField", "@enduml"} {
+		if !strings.Contains(puml, want) {
+			t.Fatalf("plantuml output missing %q:\n%s", want, puml)
+		}
+	}
+
+	if _, err := RenderERDiagram(graph, "svg"); err == nil {
+		t.Fatal("expected unknown format error")
+	}
+}
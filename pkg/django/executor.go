@@ -0,0 +1,481 @@
+package django
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+// Execution backends
+const (
+	ExecutorPython     = "python"
+	ExecutorVenv       = "venv"
+	ExecutorUV         = "uv"
+	ExecutorPoetry     = "poetry"
+	ExecutorDockerExec = "docker-exec"
+	ExecutorDockerRun  = "docker-run"
+	ExecutorSSH        = "ssh"
+)
+
+// ExecutorBackends lists every backend name in menu order
+var ExecutorBackends = []string{
+	ExecutorPython,
+	ExecutorVenv,
+	ExecutorUV,
+	ExecutorPoetry,
+	ExecutorDockerExec,
+	ExecutorDockerRun,
+	ExecutorSSH,
+}
+
+// Executor builds manage.py commands for one execution backend
+type Executor interface {
+	// Name is the backend constant, e.g. ExecutorUV
+	Name() string
+	// Label is a short description for the status bar
+	Label() string
+	// Command returns a command running `manage.py args...` that is killed, with its
+	// process group, when ctx is done
+	Command(ctx context.Context, args ...string) *exec.Cmd
+	// Python returns a command running `python args...` with extra environment variables,
+	// e.g. `python -m gunicorn`
+	Python(ctx context.Context, env map[string]string, args ...string) *exec.Cmd
+	// Remote reports whether commands see a different filesystem than the host
+	Remote() bool
+	// CopyIn makes a local file readable by manage.py and returns the path to use
+	CopyIn(ctx context.Context, localPath string) (string, func(), error)
+	// Stop asks a started command to exit, killing it when force is set. Container backends
+	// also signal the process inside the container, which outlives a stopped docker client.
+	Stop(cmd *exec.Cmd, force bool) error
+}
+
+type localExecutor struct {
+	name     string
+	label    string
+	prefix   []string // launcher before manage.py, e.g. ["uv", "run", "python"]
+	rootDir  string
+	managePy string
+}
+
+func (e *localExecutor) Name() string  { return e.name }
+func (e *localExecutor) Label() string { return e.label }
+func (e *localExecutor) Remote() bool  { return false }
+
+func (e *localExecutor) Command(ctx context.Context, args ...string) *exec.Cmd {
+	return e.Python(ctx, nil, append([]string{e.managePy}, args...)...)
+}
+
+func (e *localExecutor) Python(ctx context.Context, env map[string]string, args ...string) *exec.Cmd {
+	cmdArgs := append(append([]string{}, e.prefix[1:]...), args...)
+	cmd := CommandContext(ctx, e.prefix[0], cmdArgs...)
+	cmd.Dir = e.rootDir
+	for _, key := range sortedEnvKeys(env) {
+		cmd.Env = append(cmd.Env, key+"="+env[key])
+	}
+	return cmd
+}
+
+func (e *localExecutor) CopyIn(ctx context.Context, localPath string) (string, func(), error) {
+	return localPath, func() {}, nil
+}
+
+func (e *localExecutor) Stop(cmd *exec.Cmd, force bool) error {
+	return stopProcessGroup(cmd, force)
+}
+
+type composeExecutor struct {
+	run      bool // docker compose run --rm instead of exec
+	service  string
+	fileArgs []string
+	rootDir  string
+	mounts   []ComposeMount // the service's bind mounts, used to reach host files in run mode
+}
+
+func (e *composeExecutor) Name() string {
+	if e.run {
+		return ExecutorDockerRun
+	}
+	return ExecutorDockerExec
+}
+
+func (e *composeExecutor) Label() string {
+	if e.run {
+		return "docker run " + e.service
+	}
+	return "docker exec " + e.service
+}
+
+func (e *composeExecutor) Remote() bool { return true }
+
+func (e *composeExecutor) Command(ctx context.Context, args ...string) *exec.Cmd {
+	return e.Python(ctx, nil, append([]string{"manage.py"}, args...)...)
+}
+
+func (e *composeExecutor) Python(ctx context.Context, env map[string]string, args ...string) *exec.Cmd {
+	// Each command carries a run ID in its environment (and, for one-off containers, its name)
+	// so Stop can find the process it started inside the container.
+	runID := nextRemoteRunID()
+	composeArgs := append([]string{"compose"}, e.fileArgs...)
+	if e.run {
+		composeArgs = append(composeArgs, "run", "--rm", "-T", "--name", runID)
+	} else {
+		composeArgs = append(composeArgs, "exec", "-T")
+	}
+	composeArgs = append(composeArgs, "-e", remoteRunEnv+"="+runID)
+	for _, key := range sortedEnvKeys(env) {
+		composeArgs = append(composeArgs, "-e", key+"="+env[key])
+	}
+	composeArgs = append(composeArgs, e.service, "python")
+	composeArgs = append(composeArgs, args...)
+	cmd := e.composeCommand(ctx, composeArgs...)
+	// Killing the docker client leaves the command running in the container, so cancelling
+	// ctx kills it there as well.
+	cmd.Cancel = func() error { return e.Stop(cmd, true) }
+	return cmd
+}
+
+// composeCommand runs `docker compose args...`, falling back to docker-compose v1
+func (e *composeExecutor) composeCommand(ctx context.Context, composeArgs ...string) *exec.Cmd {
+	var cmd *exec.Cmd
+	if dockerPath, err := exec.LookPath("docker"); err == nil {
+		cmd = CommandContext(ctx, dockerPath, composeArgs...)
+	} else if dcPath, err := exec.LookPath("docker-compose"); err == nil {
+		// Remove "compose" from args for docker-compose v1
+		cmd = CommandContext(ctx, dcPath, composeArgs[1:]...)
+	} else {
+		cmd = CommandContext(ctx, "docker", composeArgs...)
+	}
+	cmd.Dir = e.rootDir
+	return cmd
+}
+
+func (e *composeExecutor) Stop(cmd *exec.Cmd, force bool) error {
+	var remoteErr error
+	if cmd != nil && cmd.Process != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), remoteStopTimeout)
+		defer cancel()
+		if stop := e.stopCommand(ctx, cmd, force); stop != nil {
+			if output, err := stop.CombinedOutput(); err != nil {
+				remoteErr = fmt.Errorf("failed to stop the process in service %s: %s - %w", e.service, strings.TrimSpace(string(output)), err)
+			}
+		}
+	}
+	return errors.Join(remoteErr, stopProcessGroup(cmd, force))
+}
+
+// stopCommand signals the process cmd started in the container: one-off containers are
+// signalled by name, exec'd processes (and their children, such as the autoreloader) are
+// found by the run ID in their environment
+func (e *composeExecutor) stopCommand(ctx context.Context, cmd *exec.Cmd, force bool) *exec.Cmd {
+	runID := remoteRunID(cmd)
+	if runID == "" {
+		return nil
+	}
+	signal := "TERM"
+	if force {
+		signal = "KILL"
+	}
+	if e.run {
+		stop := CommandContext(ctx, "docker", "kill", "--signal", signal, runID)
+		stop.Dir = e.rootDir
+		return stop
+	}
+	script := fmt.Sprintf(`for p in /proc/[0-9]*; do if tr '\000' '\n' < "$p/environ" 2>/dev/null | grep -qx '%s=%s'; then kill -%s "${p#/proc/}" 2>/dev/null; fi; done; true`,
+		remoteRunEnv, runID, signal)
+	composeArgs := append(append([]string{"compose"}, e.fileArgs...), "exec", "-T", e.service, "sh", "-c", script)
+	return e.composeCommand(ctx, composeArgs...)
+}
+
+func (e *composeExecutor) CopyIn(ctx context.Context, localPath string) (string, func(), error) {
+	if e.run {
+		// A one-off container has no stable filesystem to copy into; reach the file through a bind mount.
+		absPath, err := filepath.Abs(localPath)
+		if err != nil {
+			return "", nil, err
+		}
+		containerPath, ok := mountedContainerPath(e.mounts, absPath)
+		if !ok {
+			return "", nil, fmt.Errorf("%s is not bind-mounted into service %s; docker-run cannot read it", localPath, e.service)
+		}
+		return containerPath, func() {}, nil
+	}
+	remotePath := fmt.Sprintf("/tmp/lazy-django-%d%s", time.Now().UnixNano(), filepath.Ext(localPath))
+	cpArgs := append(append([]string{"compose"}, e.fileArgs...), "cp", localPath, fmt.Sprintf("%s:%s", e.service, remotePath))
+	cpCmd := CommandContext(ctx, "docker", cpArgs...)
+	cpCmd.Dir = e.rootDir
+	if output, err := cpCmd.CombinedOutput(); err != nil {
+		return "", nil, contextError(ctx, fmt.Errorf("failed to copy file into container: %s - %w", strings.TrimSpace(string(output)), err))
+	}
+	cleanup := func() {
+		rmArgs := append(append([]string{"compose"}, e.fileArgs...), "exec", "-T", e.service, "rm", "-f", remotePath)
+		_ = exec.Command("docker", rmArgs...).Run()
+	}
+	return remotePath, cleanup, nil
+}
+
+// remoteRunEnv names the environment variable that tags commands run in containers
+const remoteRunEnv = "LAZY_DJANGO_RUN"
+
+// remoteStopTimeout bounds the docker call that signals a process inside a container
+const remoteStopTimeout = 10 * time.Second
+
+var remoteRunCounter atomic.Uint64
+
+func nextRemoteRunID() string {
+	return fmt.Sprintf("lazy-django-%d-%d", os.Getpid(), remoteRunCounter.Add(1))
+}
+
+// remoteRunID returns the run ID a compose command was tagged with
+func remoteRunID(cmd *exec.Cmd) string {
+	for _, arg := range cmd.Args {
+		if id, ok := strings.CutPrefix(arg, remoteRunEnv+"="); ok {
+			return id
+		}
+	}
+	return ""
+}
+
+type sshExecutor struct {
+	host    string
+	dir     string
+	python  string
+	rootDir string
+}
+
+func (e *sshExecutor) Name() string  { return ExecutorSSH }
+func (e *sshExecutor) Label() string { return "ssh " + e.host }
+func (e *sshExecutor) Remote() bool  { return true }
+
+func (e *sshExecutor) Command(ctx context.Context, args ...string) *exec.Cmd {
+	return e.Python(ctx, nil, append([]string{"manage.py"}, args...)...)
+}
+
+func (e *sshExecutor) Python(ctx context.Context, env map[string]string, args ...string) *exec.Cmd {
+	var words []string
+	for _, key := range sortedEnvKeys(env) {
+		words = append(words, key+"="+shellQuote(env[key]))
+	}
+	words = append(words, e.python)
+	for _, arg := range args {
+		words = append(words, shellQuote(arg))
+	}
+	remote := strings.Join(words, " ")
+	if e.dir != "" {
+		remote = "cd " + shellQuote(e.dir) + " && " + remote
+	}
+	cmd := CommandContext(ctx, "ssh", "-T", e.host, remote)
+	cmd.Dir = e.rootDir
+	return cmd
+}
+
+func (e *sshExecutor) Stop(cmd *exec.Cmd, force bool) error {
+	return stopProcessGroup(cmd, force)
+}
+
+func (e *sshExecutor) CopyIn(ctx context.Context, localPath string) (string, func(), error) {
+	remotePath := fmt.Sprintf("/tmp/lazy-django-%d%s", time.Now().UnixNano(), filepath.Ext(localPath))
+	if output, err := CommandContext(ctx, "scp", "-q", localPath, e.host+":"+remotePath).CombinedOutput(); err != nil {
+		return "", nil, contextError(ctx, fmt.Errorf("failed to copy file to %s: %s - %w", e.host, strings.TrimSpace(string(output)), err))
+	}
+	cleanup := func() {
+		_ = exec.Command("ssh", "-T", e.host, "rm -f "+shellQuote(remotePath)).Run()
+	}
+	return remotePath, cleanup, nil
+}
+
+func sortedEnvKeys(env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// shellQuote single-quotes a word for a POSIX shell
+func shellQuote(word string) string {
+	if word != "" && strings.IndexFunc(word, func(r rune) bool {
+		return !(r == '-' || r == '_' || r == '.' || r == '/' || r == ':' || r == '=' || r == ',' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9'))
+	}) < 0 {
+		return word
+	}
+	return "'" + strings.ReplaceAll(word, "'", `'\''`) + "'"
+}
+
+// findVirtualenvPython returns the interpreter of an active or project-local virtualenv
+func findVirtualenvPython(rootDir string, lookupEnv func(string) (string, bool)) string {
+	bin, exe := "bin", "python"
+	if runtime.GOOS == "windows" {
+		bin, exe = "Scripts", "python.exe"
+	}
+
+	var dirs []string
+	if active, ok := lookupEnv("VIRTUAL_ENV"); ok && strings.TrimSpace(active) != "" {
+		dirs = append(dirs, active)
+	}
+	dirs = append(dirs, filepath.Join(rootDir, ".venv"), filepath.Join(rootDir, "venv"))
+	for _, dir := range dirs {
+		if python := filepath.Join(dir, bin, exe); fileExists(python) {
+			return python
+		}
+	}
+	return ""
+}
+
+// NewExecutor builds the executor for cfg, auto-detecting the backend when cfg.Backend is empty
+func NewExecutor(p *Project, cfg config.ExecutorConfig) (Executor, error) {
+	backend := strings.ToLower(strings.TrimSpace(cfg.Backend))
+	if backend == "" || backend == "auto" {
+		backend = detectExecutorBackend(p, os.LookupEnv)
+	}
+
+	local := func(name, label string, prefix ...string) Executor {
+		return &localExecutor{name: name, label: label, prefix: prefix, rootDir: p.RootDir, managePy: p.ManagePyPath}
+	}
+	python := strings.TrimSpace(cfg.Python)
+
+	switch backend {
+	case ExecutorPython:
+		if python == "" {
+			python = pythonBinary()
+		}
+		return local(ExecutorPython, "python", python), nil
+	case ExecutorVenv:
+		if python == "" {
+			python = findVirtualenvPython(p.RootDir, os.LookupEnv)
+		}
+		if python == "" {
+			return nil, fmt.Errorf("no virtualenv found (.venv, venv, or VIRTUAL_ENV)")
+		}
+		label := "venv"
+		if rel, err := filepath.Rel(p.RootDir, python); err == nil && !strings.HasPrefix(rel, "..") {
+			label = "venv " + filepath.Dir(filepath.Dir(rel))
+		}
+		return local(ExecutorVenv, label, python), nil
+	case ExecutorUV:
+		return local(ExecutorUV, "uv run", "uv", "run", "python"), nil
+	case ExecutorPoetry:
+		return local(ExecutorPoetry, "poetry run", "poetry", "run", "python"), nil
+	case ExecutorDockerExec, ExecutorDockerRun:
+		service := strings.TrimSpace(cfg.Service)
+		if service == "" {
+			service = p.DockerService
+		}
+		if service == "" {
+			return nil, fmt.Errorf("%s requires a compose service", backend)
+		}
+		executor := &composeExecutor{run: backend == ExecutorDockerRun, service: service, fileArgs: p.ComposeFileArgs(), rootDir: p.RootDir}
+		if p.Compose != nil {
+			if spec, ok := p.Compose.Service(service); ok {
+				executor.mounts = spec.Mounts
+			}
+		}
+		return executor, nil
+	case ExecutorSSH:
+		if strings.TrimSpace(cfg.Host) == "" {
+			return nil, fmt.Errorf("ssh executor requires a host")
+		}
+		if python == "" {
+			python = "python"
+		}
+		return &sshExecutor{host: strings.TrimSpace(cfg.Host), dir: strings.TrimSpace(cfg.Dir), python: python, rootDir: p.RootDir}, nil
+	default:
+		return nil, fmt.Errorf("unknown executor %q (expected %s)", cfg.Backend, strings.Join(ExecutorBackends, ", "))
+	}
+}
+
+// detectExecutorBackend picks a backend from the project layout
+func detectExecutorBackend(p *Project, lookupEnv func(string) (string, bool)) string {
+	switch {
+	case p.HasDocker && p.DockerComposeFile != "" && p.DockerService != "":
+		return ExecutorDockerExec
+	case findVirtualenvPython(p.RootDir, lookupEnv) != "":
+		return ExecutorVenv
+	case p.HasUV && fileExists(filepath.Join(p.RootDir, "uv.lock")):
+		return ExecutorUV
+	case p.HasPoetry && commandExists("poetry"):
+		return ExecutorPoetry
+	default:
+		return ExecutorPython
+	}
+}
+
+// ConfigureExecutor selects the execution backend from cfg, keeping the previous one on error.
+// An auto-detected docker backend falls back to a local interpreter when the Docker daemon is
+// not reachable; that is decided here, once, so commands only read the result.
+func (p *Project) ConfigureExecutor(cfg config.ExecutorConfig) error {
+	executor, err := NewExecutor(p, cfg)
+	if err != nil {
+		return err
+	}
+	p.ExecutorConfig = cfg
+	p.Executor = executor
+	p.fallbackExecutor = nil
+	if backend := strings.TrimSpace(cfg.Backend); backend == "" || backend == "auto" {
+		if _, ok := executor.(*composeExecutor); ok && !isDockerAvailable() {
+			fallback := &Project{RootDir: p.RootDir, ManagePyPath: p.ManagePyPath, HasUV: p.HasUV, HasPoetry: p.HasPoetry}
+			if local, err := NewExecutor(fallback, config.ExecutorConfig{}); err == nil {
+				p.fallbackExecutor = local
+			}
+		}
+	}
+	return nil
+}
+
+// PromoteDockerService makes service the compose service for later commands
+func (p *Project) PromoteDockerService(service string) {
+	p.DockerService = service
+	if strings.TrimSpace(p.ExecutorConfig.Service) == "" {
+		_ = p.ConfigureExecutor(p.ExecutorConfig)
+	}
+}
+
+// executor returns the configured executor, building it on first use
+func (p *Project) executor() Executor {
+	if p.Executor == nil {
+		if err := p.ConfigureExecutor(p.ExecutorConfig); err != nil {
+			_ = p.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorPython})
+		}
+	}
+	return p.Executor
+}
+
+// ActiveExecutor returns the executor commands run through: the configured one, or its local
+// fallback while docker was unavailable
+func (p *Project) ActiveExecutor() Executor {
+	executor := p.executor()
+	if p.fallbackExecutor != nil {
+		return p.fallbackExecutor
+	}
+	return executor
+}
+
+// ExecutorLabel describes the execution backend commands actually use
+func (p *Project) ExecutorLabel() string {
+	if fallback := p.fallbackExecutor; fallback != nil {
+		return fallback.Label() + " (docker unavailable)"
+	}
+	return p.executor().Label()
+}
+
+// RunsRemotely reports whether manage.py runs in a container or on another host
+func (p *Project) RunsRemotely() bool {
+	return p.ActiveExecutor().Remote()
+}
+
+// ManageCommand returns a command running manage.py through the active executor.
+// The process runs in its own group so it can be stopped with KillProcessGroup.
+func (p *Project) ManageCommand(args ...string) *exec.Cmd {
+	return p.ActiveExecutor().Command(context.Background(), args...)
+}
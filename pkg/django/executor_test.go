@@ -0,0 +1,209 @@
+package django
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+func TestNewExecutorCommands(t *testing.T) {
+	root := t.TempDir()
+	project := &Project{
+		RootDir:           root,
+		ManagePyPath:      filepath.Join(root, "manage.py"),
+		HasDocker:         true,
+		DockerService:     "web",
+		DockerComposeFile: filepath.Join(root, "compose.yaml"),
+	}
+
+	cases := []struct {
+		cfg    config.ExecutorConfig
+		want   string
+		remote bool
+	}{
+		{config.ExecutorConfig{Backend: "python", Python: "/usr/bin/python3"}, "/usr/bin/python3 " + project.ManagePyPath + " migrate --plan", false},
+		{config.ExecutorConfig{Backend: "uv"}, "uv run python " + project.ManagePyPath + " migrate --plan", false},
+		{config.ExecutorConfig{Backend: "poetry"}, "poetry run python " + project.ManagePyPath + " migrate --plan", false},
+		{config.ExecutorConfig{Backend: "docker-exec"}, "compose -f " + project.DockerComposeFile + " exec -T -e LAZY_DJANGO_RUN=ID web python manage.py migrate --plan", true},
+		{config.ExecutorConfig{Backend: "docker-run", Service: "worker"}, "compose -f " + project.DockerComposeFile + " run --rm -T --name ID -e LAZY_DJANGO_RUN=ID worker python manage.py migrate --plan", true},
+		{config.ExecutorConfig{Backend: "ssh", Host: "dev@box", Dir: "/srv/my app"}, "ssh -T dev@box cd '/srv/my app' && python manage.py migrate --plan", true},
+	}
+	for _, tc := range cases {
+		executor, err := NewExecutor(project, tc.cfg)
+		if err != nil {
+			t.Fatalf("NewExecutor(%+v) failed: %v", tc.cfg, err)
+		}
+		cmd := executor.Command(context.Background(), "migrate", "--plan")
+		got := strings.Join(cmd.Args, " ")
+		if runID := remoteRunID(cmd); runID != "" {
+			got = strings.ReplaceAll(got, runID, "ID")
+		}
+		if !strings.HasSuffix(got, tc.want) {
+			t.Fatalf("%s: expected command ending %q, got %q", tc.cfg.Backend, tc.want, got)
+		}
+		if cmd.Dir != root {
+			t.Fatalf("%s: expected command to run from project root, got %q", tc.cfg.Backend, cmd.Dir)
+		}
+		if executor.Remote() != tc.remote {
+			t.Fatalf("%s: expected remote=%v", tc.cfg.Backend, tc.remote)
+		}
+	}
+
+	if _, err := NewExecutor(project, config.ExecutorConfig{Backend: "ssh"}); err == nil {
+		t.Fatal("expected ssh without host to fail")
+	}
+	if _, err := NewExecutor(project, config.ExecutorConfig{Backend: "conda"}); err == nil {
+		t.Fatal("expected unknown backend to fail")
+	}
+}
+
+func TestPromoteDockerService(t *testing.T) {
+	root := t.TempDir()
+	project := &Project{
+		RootDir:           root,
+		ManagePyPath:      filepath.Join(root, "manage.py"),
+		HasDocker:         true,
+		DockerService:     "web",
+		DockerComposeFile: filepath.Join(root, "compose.yaml"),
+	}
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: "docker-exec"}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+
+	project.PromoteDockerService("app")
+	cmd := project.ActiveExecutor().Command(context.Background(), "check")
+	if got := strings.Join(cmd.Args, " "); !strings.Contains(got, " app python manage.py check") {
+		t.Fatalf("expected promoted service in %q", got)
+	}
+
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: "docker-exec", Service: "web"}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+	project.PromoteDockerService("worker")
+	cmd = project.ActiveExecutor().Command(context.Background(), "check")
+	if got := strings.Join(cmd.Args, " "); !strings.Contains(got, " web python manage.py check") {
+		t.Fatalf("expected the configured service to win in %q", got)
+	}
+}
+
+func TestComposeStopCommand(t *testing.T) {
+	project := &Project{RootDir: t.TempDir(), DockerService: "web", DockerComposeFile: "compose.yaml", HasDocker: true}
+	executor, err := NewExecutor(project, config.ExecutorConfig{Backend: ExecutorDockerExec})
+	if err != nil {
+		t.Fatalf("NewExecutor failed: %v", err)
+	}
+	compose := executor.(*composeExecutor)
+	cmd := compose.Python(context.Background(), nil, "manage.py", "runserver", "0.0.0.0:8000")
+	runID := remoteRunID(cmd)
+	if runID == "" {
+		t.Fatalf("expected the command to carry a run ID: %v", cmd.Args)
+	}
+
+	stop := compose.stopCommand(context.Background(), cmd, false)
+	got := strings.Join(stop.Args, " ")
+	for _, want := range []string{"compose -f compose.yaml exec -T web sh -c ", "'LAZY_DJANGO_RUN=" + runID + "'", "kill -TERM "} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("expected %q in stop command %q", want, got)
+		}
+	}
+	if got := strings.Join(compose.stopCommand(context.Background(), cmd, true).Args, " "); !strings.Contains(got, "kill -KILL ") {
+		t.Fatalf("expected a forced stop to send SIGKILL, got %q", got)
+	}
+
+	compose.run = true
+	cmd = compose.Python(context.Background(), nil, "manage.py", "runserver")
+	if got := strings.Join(compose.stopCommand(context.Background(), cmd, false).Args, " "); !strings.HasSuffix(got, "kill --signal TERM "+remoteRunID(cmd)) {
+		t.Fatalf("expected docker-run to signal its one-off container, got %q", got)
+	}
+	if compose.stopCommand(context.Background(), exec.Command("make", "runserver"), false) != nil {
+		t.Fatal("expected no remote stop for a command without a run ID")
+	}
+}
+
+func TestDockerRunCopyInUsesBindMounts(t *testing.T) {
+	root := t.TempDir()
+	project := &Project{
+		RootDir:       root,
+		DockerService: "web",
+		Compose: &ComposeProject{Services: []ComposeService{{
+			Name: "web",
+			Mounts: []ComposeMount{
+				{Source: root, Target: "/app"},
+				{Source: filepath.Join(root, "data"), Target: "/srv/data"},
+			},
+		}}},
+	}
+	executor, err := NewExecutor(project, config.ExecutorConfig{Backend: ExecutorDockerRun})
+	if err != nil {
+		t.Fatalf("NewExecutor failed: %v", err)
+	}
+
+	got, cleanup, err := executor.CopyIn(context.Background(), filepath.Join(root, "data", "fixtures", "users.json"))
+	if err != nil {
+		t.Fatalf("CopyIn failed: %v", err)
+	}
+	cleanup()
+	if got != "/srv/data/fixtures/users.json" {
+		t.Fatalf("expected the longest mount to win, got %q", got)
+	}
+	if got, _, err := executor.CopyIn(context.Background(), filepath.Join(root, "dump.sql")); err != nil || got != "/app/dump.sql" {
+		t.Fatalf("expected the project mount to map, got %q %v", got, err)
+	}
+	if _, _, err := executor.CopyIn(context.Background(), filepath.Join(os.TempDir(), "elsewhere.sql")); err == nil {
+		t.Fatal("expected an error for a file outside every bind mount")
+	}
+}
+
+func TestDetectExecutorBackend(t *testing.T) {
+	root := t.TempDir()
+	noEnv := func(string) (string, bool) { return "", false }
+	project := &Project{RootDir: root}
+
+	if got := detectExecutorBackend(project, noEnv); got != ExecutorPython {
+		t.Fatalf("expected bare python fallback, got %s", got)
+	}
+
+	project.HasUV = true
+	if err := os.WriteFile(filepath.Join(root, "uv.lock"), nil, 0644); err != nil {
+		t.Fatalf("failed to write uv.lock: %v", err)
+	}
+	if got := detectExecutorBackend(project, noEnv); got != ExecutorUV {
+		t.Fatalf("expected uv with uv.lock, got %s", got)
+	}
+
+	venvPython := filepath.Join(root, ".venv", "bin", "python")
+	if err := os.MkdirAll(filepath.Dir(venvPython), 0755); err != nil {
+		t.Fatalf("failed to create venv: %v", err)
+	}
+	if err := os.WriteFile(venvPython, nil, 0755); err != nil {
+		t.Fatalf("failed to write venv python: %v", err)
+	}
+	if got := detectExecutorBackend(project, noEnv); got != ExecutorVenv {
+		t.Fatalf("expected venv to win over uv, got %s", got)
+	}
+
+	project.HasDocker, project.DockerService, project.DockerComposeFile = true, "web", filepath.Join(root, "compose.yaml")
+	if got := detectExecutorBackend(project, noEnv); got != ExecutorDockerExec {
+		t.Fatalf("expected docker exec for compose projects, got %s", got)
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"migrate":        "migrate",
+		"--settings=a.b": "--settings=a.b",
+		"":               "''",
+		"a b":            "'a b'",
+		"it's":           `'it'\''s'`,
+	}
+	for input, want := range cases {
+		if got := shellQuote(input); got != want {
+			t.Fatalf("shellQuote(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package django
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// MigrationFinding flags a risky operation or statement in a migration
+type MigrationFinding struct {
+	Kind    string // "irreversible" or "locking"
+	Message string
+}
+
+// MigrationSQLEntry is the sqlmigrate output and review findings for one migration
+type MigrationSQLEntry struct {
+	App      string
+	Name     string
+	SQL      string
+	Error    string
+	Findings []MigrationFinding
+}
+
+// MigrationSQLReport aggregates SQL previews for all unapplied migrations
+type MigrationSQLReport struct {
+	Engine  string
+	Entries []MigrationSQLEntry
+}
+
+var (
+	migrationOperationPattern = regexp.MustCompile(`(?:migrations\.)?\b(RunPython|RunSQL|RemoveField|DeleteModel)\s*\(`)
+	pythonKeywordArgPattern   = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_]*)\s*=[^=]`)
+	sqlWhitespacePattern      = regexp.MustCompile(`\s+`)
+)
+
+type lockingRule struct {
+	pattern *regexp.Regexp
+	exclude *regexp.Regexp
+	message string
+}
+
+var postgresLockingRules = []lockingRule{
+	{
+		pattern: regexp.MustCompile(`(?i)^CREATE\s+(UNIQUE\s+)?INDEX\b`),
+		exclude: regexp.MustCompile(`(?i)\bCONCURRENTLY\b`),
+		message: "CREATE INDEX without CONCURRENTLY blocks writes",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\bALTER\s+COLUMN\s+\S+\s+(SET\s+DATA\s+)?TYPE\b`),
+		message: "column type change rewrites the table under ACCESS EXCLUSIVE lock",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\bSET\s+NOT\s+NULL\b`),
+		message: "SET NOT NULL scans the table under ACCESS EXCLUSIVE lock",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\bADD\s+CONSTRAINT\b.*\b(FOREIGN\s+KEY|CHECK)\b`),
+		exclude: regexp.MustCompile(`(?i)\bNOT\s+VALID\b`),
+		message: "constraint is validated while the table is locked (consider NOT VALID)",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\bADD\s+CONSTRAINT\b.*\bUNIQUE\b`),
+		message: "UNIQUE constraint builds an index while blocking writes",
+	},
+}
+
+var mysqlLockingRules = []lockingRule{
+	{
+		pattern: regexp.MustCompile(`(?i)^ALTER\s+TABLE\b.*\b(MODIFY|CHANGE)\b`),
+		message: "column change may copy the table and block writes",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\b(ADD|DROP)\s+PRIMARY\s+KEY\b`),
+		message: "primary key change rebuilds the table",
+	},
+	{
+		pattern: regexp.MustCompile(`(?i)\bADD\s+CONSTRAINT\b.*\bFOREIGN\s+KEY\b`),
+		message: "foreign key creation locks both tables",
+	},
+}
+
+// String returns a reviewable text report
+func (r MigrationSQLReport) String() string {
+	var b strings.Builder
+
+	irreversible, locking, failed := r.Counts()
+	fmt.Fprintf(&b, "Migration SQL Report (%d unapplied)\n", len(r.Entries))
+	fmt.Fprintf(&b, "irreversible: %d  locking: %d  errors: %d\n", irreversible, locking, failed)
+	if len(r.Entries) == 0 {
+		fmt.Fprintln(&b, "\nNo unapplied migrations.")
+		return b.String()
+	}
+
+	for _, entry := range r.Entries {
+		fmt.Fprintf(&b, "\n== %s.%s ==\n", entry.App, entry.Name)
+		for _, finding := range entry.Findings {
+			fmt.Fprintf(&b, "! [%s] %s\n", finding.Kind, finding.Message)
+		}
+		if entry.Error != "" {
+			fmt.Fprintf(&b, "sqlmigrate failed: %s\n", entry.Error)
+			continue
+		}
+		sql := strings.TrimSpace(entry.SQL)
+		if sql == "" {
+			sql = "-- (no SQL)"
+		}
+		fmt.Fprintln(&b, sql)
+	}
+	return b.String()
+}
+
+// Counts returns totals of irreversible findings, locking findings, and failures
+func (r MigrationSQLReport) Counts() (int, int, int) {
+	irreversible, locking, failed := 0, 0, 0
+	for _, entry := range r.Entries {
+		if entry.Error != "" {
+			failed++
+		}
+		for _, finding := range entry.Findings {
+			switch finding.Kind {
+			case "irreversible":
+				irreversible++
+			case "locking":
+				locking++
+			}
+		}
+	}
+	return irreversible, locking, failed
+}
+
+// pythonCallArgs returns the argument text of a call whose "(" is at openIdx
+func pythonCallArgs(source string, openIdx int) string {
+	depth := 0
+	var quote byte
+	for i := openIdx; i < len(source); i++ {
+		c := source[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+			if depth == 0 {
+				return source[openIdx+1 : i]
+			}
+		}
+	}
+	return source[openIdx+1:]
+}
+
+// splitPythonArgs splits call arguments on top-level commas
+func splitPythonArgs(args string) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+	for i := 0; i < len(args); i++ {
+		c := args[i]
+		if quote != 0 {
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '\'', '"':
+			quote = c
+		case '(', '[', '{':
+			depth++
+		case ')', ']', '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, strings.TrimSpace(args[start:i]))
+				start = i + 1
+			}
+		}
+	}
+	if tail := strings.TrimSpace(args[start:]); tail != "" {
+		parts = append(parts, tail)
+	}
+	return parts
+}
+
+// hasReverseArgument reports whether a RunPython/RunSQL call supplies a reverse
+func hasReverseArgument(args []string, keyword string) bool {
+	positional := 0
+	for _, arg := range args {
+		if match := pythonKeywordArgPattern.FindStringSubmatch(arg); match != nil {
+			if match[1] == keyword {
+				return true
+			}
+			continue
+		}
+		positional++
+	}
+	return positional >= 2
+}
+
+func compactPython(text string) string {
+	text = sqlWhitespacePattern.ReplaceAllString(strings.TrimSpace(text), " ")
+	if len(text) > 80 {
+		text = text[:77] + "..."
+	}
+	return text
+}
+
+// analyzeMigrationOperations flags irreversible and data-losing operations in migration source
+func analyzeMigrationOperations(source string) []MigrationFinding {
+	var findings []MigrationFinding
+	for _, loc := range migrationOperationPattern.FindAllStringSubmatchIndex(source, -1) {
+		op := source[loc[2]:loc[3]]
+		argsText := pythonCallArgs(source, loc[1]-1)
+		args := splitPythonArgs(argsText)
+
+		switch op {
+		case "RunPython":
+			if !hasReverseArgument(args, "reverse_code") {
+				findings = append(findings, MigrationFinding{Kind: "irreversible", Message: "RunPython without reverse_code"})
+			}
+		case "RunSQL":
+			if !hasReverseArgument(args, "reverse_sql") {
+				findings = append(findings, MigrationFinding{Kind: "irreversible", Message: "RunSQL without reverse_sql"})
+			}
+		case "RemoveField", "DeleteModel":
+			findings = append(findings, MigrationFinding{
+				Kind:    "irreversible",
+				Message: fmt.Sprintf("%s(%s) drops data that reversing cannot restore", op, compactPython(argsText)),
+			})
+		}
+	}
+	return findings
+}
+
+// splitSQLStatements strips comments and splits SQL on semicolons
+func splitSQLStatements(sql string) []string {
+	var cleaned []string
+	for _, line := range strings.Split(sql, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "--") {
+			continue
+		}
+		cleaned = append(cleaned, line)
+	}
+
+	var statements []string
+	for _, stmt := range strings.Split(strings.Join(cleaned, "\n"), ";") {
+		stmt = sqlWhitespacePattern.ReplaceAllString(strings.TrimSpace(stmt), " ")
+		if stmt != "" {
+			statements = append(statements, stmt)
+		}
+	}
+	return statements
+}
+
+func lockingRulesForEngine(engine string) []lockingRule {
+	engine = strings.ToLower(engine)
+	switch {
+	case strings.Contains(engine, "postg"):
+		return postgresLockingRules
+	case strings.Contains(engine, "mysql"):
+		return mysqlLockingRules
+	default:
+		return nil
+	}
+}
+
+// analyzeMigrationSQL flags potentially locking DDL for Postgres and MySQL
+func analyzeMigrationSQL(sql, engine string) []MigrationFinding {
+	rules := lockingRulesForEngine(engine)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var findings []MigrationFinding
+	for _, stmt := range splitSQLStatements(sql) {
+		for _, rule := range rules {
+			if !rule.pattern.MatchString(stmt) {
+				continue
+			}
+			if rule.exclude != nil && rule.exclude.MatchString(stmt) {
+				continue
+			}
+			findings = append(findings, MigrationFinding{
+				Kind:    "locking",
+				Message: fmt.Sprintf("%s: %s", rule.message, compactPython(stmt)),
+			})
+		}
+	}
+	return findings
+}
+
+func buildMigrationSQLReport(
+	migrations []Migration,
+	files map[string][]MigrationFile,
+	engine string,
+	readFile func(path string) (string, error),
+	run func(args ...string) (string, error),
+) MigrationSQLReport {
+	report := MigrationSQLReport{Engine: engine}
+	for _, m := range migrations {
+		if m.Applied {
+			continue
+		}
+
+		entry := MigrationSQLEntry{App: m.App, Name: m.Name}
+		for _, file := range files[m.App] {
+			if file.Name != m.Name || file.Path == "" {
+				continue
+			}
+			if source, err := readFile(file.Path); err == nil {
+				entry.Findings = append(entry.Findings, analyzeMigrationOperations(source)...)
+			}
+		}
+
+		output, err := run("sqlmigrate", m.App, m.Name)
+		if err != nil {
+			entry.Error = strings.TrimSpace(fmt.Sprintf("%v %s", err, strings.TrimSpace(output)))
+		} else {
+			entry.SQL = output
+			entry.Findings = append(entry.Findings, analyzeMigrationSQL(output, engine)...)
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	return report
+}
+
+// BuildMigrationSQLReport runs sqlmigrate for every unapplied migration and reviews the result
+func (p *Project) BuildMigrationSQLReport() MigrationSQLReport {
+	readFile := func(path string) (string, error) {
+		content, err := os.ReadFile(path)
+		return string(content), err
+	}
+	return buildMigrationSQLReport(p.Migrations, p.LoadMigrationFiles(), p.Database.Engine, readFile, p.RunCommand)
+}
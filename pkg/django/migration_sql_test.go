@@ -0,0 +1,116 @@
+package django
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeMigrationOperations(t *testing.T) {
+	source := `
+class Migration(migrations.Migration):
+    operations = [
+        migrations.RunPython(forwards),
+        migrations.RunPython(forwards, backwards),
+        migrations.RunPython(forwards, reverse_code=migrations.RunPython.noop),
+        migrations.RunSQL("UPDATE blog_post SET slug = lower(title)"),
+        migrations.RunSQL(sql="CREATE VIEW v AS SELECT 1", reverse_sql="DROP VIEW v"),
+        migrations.RemoveField(
+            model_name='post',
+            name='legacy',
+        ),
+    ]
+`
+	findings := analyzeMigrationOperations(source)
+	if len(findings) != 3 {
+		t.Fatalf("expected 3 findings, got %#v", findings)
+	}
+	if findings[0].Message != "RunPython without reverse_code" {
+		t.Fatalf("unexpected RunPython finding: %#v", findings[0])
+	}
+	if findings[1].Message != "RunSQL without reverse_sql" {
+		t.Fatalf("unexpected RunSQL finding: %#v", findings[1])
+	}
+	if !strings.Contains(findings[2].Message, "RemoveField(model_name='post', name='legacy',)") {
+		t.Fatalf("unexpected RemoveField finding: %#v", findings[2])
+	}
+}
+
+func TestAnalyzeMigrationSQL(t *testing.T) {
+	sql := `BEGIN;
+--
+-- Add field slug to post
+--
+ALTER TABLE "blog_post" ADD COLUMN "slug" varchar(50) DEFAULT '' NOT NULL;
+ALTER TABLE "blog_post" ALTER COLUMN "slug" DROP DEFAULT;
+CREATE INDEX "blog_post_slug_idx" ON "blog_post" ("slug");
+CREATE INDEX CONCURRENTLY "blog_post_title_idx" ON "blog_post" ("title");
+ALTER TABLE "blog_post" ALTER COLUMN "views" TYPE bigint USING "views"::bigint;
+ALTER TABLE "blog_post" ADD CONSTRAINT "blog_post_author_fk" FOREIGN KEY ("author_id") REFERENCES "auth_user" ("id") DEFERRABLE INITIALLY DEFERRED;
+COMMIT;`
+
+	findings := analyzeMigrationSQL(sql, "django.db.backends.postgresql")
+	if len(findings) != 3 {
+		t.Fatalf("expected 3 locking findings, got %#v", findings)
+	}
+	for _, finding := range findings {
+		if finding.Kind != "locking" {
+			t.Fatalf("unexpected finding kind: %#v", finding)
+		}
+	}
+	if !strings.HasPrefix(findings[0].Message, "CREATE INDEX without CONCURRENTLY") {
+		t.Fatalf("unexpected first finding: %#v", findings[0])
+	}
+
+	if findings := analyzeMigrationSQL(sql, "django.db.backends.sqlite3"); len(findings) != 0 {
+		t.Fatalf("expected sqlite to skip locking analysis, got %#v", findings)
+	}
+
+	mysql := "ALTER TABLE `blog_post` MODIFY `views` bigint NOT NULL;"
+	if findings := analyzeMigrationSQL(mysql, "django.db.backends.mysql"); len(findings) != 1 {
+		t.Fatalf("expected mysql column change finding, got %#v", findings)
+	}
+}
+
+func TestBuildMigrationSQLReport(t *testing.T) {
+	migrations := []Migration{
+		{App: "blog", Name: "0001_initial", Applied: true},
+		{App: "blog", Name: "0002_backfill", Applied: false},
+		{App: "shop", Name: "0001_initial", Applied: false},
+	}
+	files := map[string][]MigrationFile{
+		"blog": {{App: "blog", Name: "0002_backfill", Path: "blog/migrations/0002_backfill.py"}},
+	}
+	readFile := func(path string) (string, error) {
+		return "operations = [migrations.RunPython(backfill)]", nil
+	}
+
+	var calls []string
+	run := func(args ...string) (string, error) {
+		calls = append(calls, strings.Join(args, " "))
+		if args[1] == "shop" {
+			return "CommandError: no such app", errors.New("exit status 1")
+		}
+		return "BEGIN;\nCOMMIT;\n", nil
+	}
+
+	report := buildMigrationSQLReport(migrations, files, "sqlite3", readFile, run)
+	if len(report.Entries) != 2 {
+		t.Fatalf("expected entries for unapplied migrations only, got %#v", report.Entries)
+	}
+	if len(calls) != 2 || calls[0] != "sqlmigrate blog 0002_backfill" {
+		t.Fatalf("unexpected sqlmigrate calls: %v", calls)
+	}
+
+	irreversible, locking, failed := report.Counts()
+	if irreversible != 1 || locking != 0 || failed != 1 {
+		t.Fatalf("unexpected counts: irreversible=%d locking=%d failed=%d", irreversible, locking, failed)
+	}
+
+	text := report.String()
+	for _, want := range []string{"Migration SQL Report (2 unapplied)", "== blog.0002_backfill ==", "! [irreversible] RunPython without reverse_code", "sqlmigrate failed:"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("report missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,483 @@
+package django
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// MigrationKey identifies a migration by app label and name
+type MigrationKey struct {
+	App  string
+	Name string
+}
+
+// MigrationFile is a migration parsed from disk
+type MigrationFile struct {
+	App          string // app label
+	Name         string
+	Path         string
+	Dependencies []MigrationKey
+	Replaces     []MigrationKey
+}
+
+// MigrationHealth summarizes migration conflicts and drift
+type MigrationHealth struct {
+	ConflictingLeaves map[string][]string // app label -> leaf migration names
+	MissingMigrations bool
+	MissingOutput     string
+	Unapplied         int
+	CheckError        string
+}
+
+var migrationTuplePattern = regexp.MustCompile(`\(\s*['"]([^'"]+)['"]\s*,\s*['"]([^'"]+)['"]\s*\)`)
+
+// HasIssues reports whether any migration problem was detected
+func (h MigrationHealth) HasIssues() bool {
+	return len(h.ConflictingLeaves) > 0 || h.MissingMigrations || h.Unapplied > 0
+}
+
+// ConflictingApps returns the sorted app labels with more than one leaf
+func (h MigrationHealth) ConflictingApps() []string {
+	apps := make([]string, 0, len(h.ConflictingLeaves))
+	for app := range h.ConflictingLeaves {
+		apps = append(apps, app)
+	}
+	sort.Strings(apps)
+	return apps
+}
+
+// appLabel returns the default Django label for a dotted app name
+func appLabel(appName string) string {
+	if idx := strings.LastIndex(appName, "."); idx >= 0 {
+		return appName[idx+1:]
+	}
+	return appName
+}
+
+// locatePythonListAssignment returns the span of `name = [...]` in source:
+// the statement start, the body start, and the index of the closing bracket.
+func locatePythonListAssignment(source, name string) (int, int, int, bool) {
+	pattern := regexp.MustCompile(`(?m)^[ \t]*` + regexp.QuoteMeta(name) + `\s*=\s*[\[(]`)
+	loc := pattern.FindStringIndex(source)
+	if loc == nil {
+		return 0, 0, 0, false
+	}
+
+	depth := 0
+	for i := loc[1] - 1; i < len(source); i++ {
+		switch source[i] {
+		case '[', '(':
+			depth++
+		case ']', ')':
+			depth--
+			if depth == 0 {
+				return loc[0], loc[1], i, true
+			}
+		}
+	}
+	return loc[0], loc[1], len(source), true
+}
+
+// extractPythonListAssignment returns the body of `name = [...]` in source
+func extractPythonListAssignment(source, name string) string {
+	_, bodyStart, end, ok := locatePythonListAssignment(source, name)
+	if !ok {
+		return ""
+	}
+	return source[bodyStart:end]
+}
+
+func parseMigrationKeys(body string) []MigrationKey {
+	var keys []MigrationKey
+	for _, match := range migrationTuplePattern.FindAllStringSubmatch(body, -1) {
+		keys = append(keys, MigrationKey{App: match[1], Name: match[2]})
+	}
+	return keys
+}
+
+// parseMigrationSource extracts dependencies and replaces from a migration file
+func parseMigrationSource(app, name, source string) MigrationFile {
+	return MigrationFile{
+		App:          app,
+		Name:         name,
+		Dependencies: parseMigrationKeys(extractPythonListAssignment(source, "dependencies")),
+		Replaces:     parseMigrationKeys(extractPythonListAssignment(source, "replaces")),
+	}
+}
+
+// LoadMigrationFiles parses migration files on disk for every discovered app
+func (p *Project) LoadMigrationFiles() map[string][]MigrationFile {
+	result := make(map[string][]MigrationFile)
+	for _, app := range p.Apps {
+		migrationsDir := filepath.Join(p.RootDir, app.Path, "migrations")
+		files, err := os.ReadDir(migrationsDir)
+		if err != nil {
+			continue
+		}
+
+		label := appLabel(app.Name)
+		for _, file := range files {
+			if file.IsDir() || !strings.HasSuffix(file.Name(), ".py") || file.Name() == "__init__.py" {
+				continue
+			}
+			path := filepath.Join(migrationsDir, file.Name())
+			content, err := os.ReadFile(path)
+			if err != nil {
+				continue
+			}
+			migration := parseMigrationSource(label, strings.TrimSuffix(file.Name(), ".py"), string(content))
+			migration.Path = path
+			result[label] = append(result[label], migration)
+		}
+	}
+	return result
+}
+
+// findLeafMigrations returns the leaf migrations of a single app's graph.
+// Migrations replaced by a squashed migration are folded into it.
+func findLeafMigrations(app string, migrations []MigrationFile) []string {
+	replacedBy := make(map[string]string)
+	for _, m := range migrations {
+		for _, r := range m.Replaces {
+			if r.App == app {
+				replacedBy[r.Name] = m.Name
+			}
+		}
+	}
+
+	hasChild := make(map[string]bool)
+	for _, m := range migrations {
+		if _, replaced := replacedBy[m.Name]; replaced {
+			continue
+		}
+		for _, dep := range m.Dependencies {
+			if dep.App != app {
+				continue
+			}
+			parent := dep.Name
+			if squashed, ok := replacedBy[parent]; ok {
+				parent = squashed
+			}
+			hasChild[parent] = true
+		}
+	}
+
+	var leaves []string
+	for _, m := range migrations {
+		if _, replaced := replacedBy[m.Name]; replaced {
+			continue
+		}
+		if !hasChild[m.Name] {
+			leaves = append(leaves, m.Name)
+		}
+	}
+	sort.Strings(leaves)
+	return leaves
+}
+
+// findConflictingLeaves returns apps whose migration graph has multiple leaves
+func findConflictingLeaves(graph map[string][]MigrationFile) map[string][]string {
+	conflicts := make(map[string][]string)
+	for app, migrations := range graph {
+		if leaves := findLeafMigrations(app, migrations); len(leaves) > 1 {
+			conflicts[app] = leaves
+		}
+	}
+	return conflicts
+}
+
+// DetectMigrationConflicts finds apps with more than one leaf migration on disk
+func (p *Project) DetectMigrationConflicts() map[string][]string {
+	return findConflictingLeaves(p.LoadMigrationFiles())
+}
+
+// parseMakemigrationsCheck interprets `makemigrations --check --dry-run` results
+func parseMakemigrationsCheck(output string, err error) (bool, error) {
+	if err == nil {
+		return false, nil
+	}
+	if strings.Contains(output, "Migrations for") {
+		return true, nil
+	}
+	return false, err
+}
+
+// CheckMissingMigrations reports whether models have changes without migrations
+func (p *Project) CheckMissingMigrations() (bool, string, error) {
+	output, err := p.runDiscoveryCommand("makemigrations", "--check", "--dry-run")
+	missing, checkErr := parseMakemigrationsCheck(output, err)
+	return missing, strings.TrimSpace(output), checkErr
+}
+
+// CheckMigrationHealth combines leaf conflict, drift, and unapplied checks
+func (p *Project) CheckMigrationHealth() MigrationHealth {
+	health := MigrationHealth{
+		ConflictingLeaves: p.DetectMigrationConflicts(),
+	}
+	for _, m := range p.Migrations {
+		if !m.Applied {
+			health.Unapplied++
+		}
+	}
+
+	missing, output, err := p.CheckMissingMigrations()
+	health.MissingMigrations = missing
+	if missing {
+		health.MissingOutput = output
+	}
+	if err != nil {
+		health.CheckError = err.Error()
+	}
+	return health
+}
+
+// SquashPlan describes a squashmigrations run for a contiguous range of an app
+type SquashPlan struct {
+	App          string
+	Start        string // empty squashes from the first migration
+	End          string
+	Replaces     []string
+	SquashedName string
+}
+
+// Args returns the manage.py arguments that execute the plan
+func (s SquashPlan) Args() []string {
+	args := []string{"squashmigrations", "--noinput", s.App}
+	if s.Start != "" {
+		args = append(args, s.Start)
+	}
+	return append(args, s.End)
+}
+
+// AppMigrationNames returns the ordered migration names recorded for an app
+func (p *Project) AppMigrationNames(app string) []string {
+	var names []string
+	for _, m := range p.Migrations {
+		if m.App == app {
+			names = append(names, m.Name)
+		}
+	}
+	return names
+}
+
+// BuildSquashPlan validates a squash range against the app's known migrations
+func (p *Project) BuildSquashPlan(app, start, end string) (SquashPlan, error) {
+	names := p.AppMigrationNames(app)
+	if len(names) == 0 {
+		return SquashPlan{}, fmt.Errorf("no migrations found for app %s", app)
+	}
+
+	startIdx := 0
+	if start != "" {
+		startIdx = slices.Index(names, start)
+		if startIdx < 0 {
+			return SquashPlan{}, fmt.Errorf("unknown start migration %s.%s", app, start)
+		}
+	}
+	endIdx := slices.Index(names, end)
+	if endIdx < 0 {
+		return SquashPlan{}, fmt.Errorf("unknown end migration %s.%s", app, end)
+	}
+	if endIdx <= startIdx {
+		return SquashPlan{}, fmt.Errorf("squash range must contain at least two migrations")
+	}
+
+	plan := SquashPlan{
+		App:      app,
+		Start:    start,
+		End:      end,
+		Replaces: append([]string(nil), names[startIdx:endIdx+1]...),
+	}
+	// Mirrors the default name chosen by Django's squashmigrations command.
+	if start != "" {
+		plan.SquashedName = fmt.Sprintf("%s_squashed_%s", start, end)
+	} else {
+		plan.SquashedName = fmt.Sprintf("0001_squashed_%s", end)
+	}
+	return plan, nil
+}
+
+// SquashedMigration is the migration file generated by a squash plan
+type SquashedMigration struct {
+	Path         string
+	Source       string
+	Replaces     []MigrationKey
+	Dependencies []MigrationKey
+	Operations   int
+}
+
+var squashedOperationPattern = regexp.MustCompile(`(?m)^\s*migrations\.\w+\(`)
+
+// SquashedMigrationPath returns where squashmigrations writes the plan's new migration
+func (p *Project) SquashedMigrationPath(plan SquashPlan) (string, error) {
+	for _, app := range p.Apps {
+		if appLabel(app.Name) == plan.App {
+			return filepath.Join(p.RootDir, app.Path, "migrations", plan.SquashedName+".py"), nil
+		}
+	}
+	return "", fmt.Errorf("no migrations directory found for app %s", plan.App)
+}
+
+// ReadSquashedMigration parses a generated squashed migration for review
+func ReadSquashedMigration(path string) (SquashedMigration, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return SquashedMigration{}, err
+	}
+	source := string(content)
+	parsed := parseMigrationSource("", "", source)
+	return SquashedMigration{
+		Path:         path,
+		Source:       source,
+		Replaces:     parsed.Replaces,
+		Dependencies: parsed.Dependencies,
+		Operations:   len(squashedOperationPattern.FindAllString(extractPythonListAssignment(source, "operations"), -1)),
+	}, nil
+}
+
+// PrunableSquash is a squashed migration whose replaced migrations can be deleted
+type PrunableSquash struct {
+	App            string
+	Name           string
+	Path           string
+	Replaces       []MigrationKey
+	ReplacedPaths  []string // original migration files still on disk
+	DependentPaths []string // files depending on a replaced migration
+}
+
+// findPrunableSquashes returns squashed migrations whose replaces list is fully applied
+func findPrunableSquashes(graph map[string][]MigrationFile, applied map[MigrationKey]bool) []PrunableSquash {
+	var result []PrunableSquash
+	for app, migrations := range graph {
+		byName := make(map[string]MigrationFile, len(migrations))
+		for _, m := range migrations {
+			byName[m.Name] = m
+		}
+
+		for _, squash := range migrations {
+			// The loader only reports a squashed migration as applied once every
+			// migration it replaces is applied, so this is enough to prune safely.
+			if len(squash.Replaces) == 0 || !applied[MigrationKey{App: app, Name: squash.Name}] {
+				continue
+			}
+
+			prunable := PrunableSquash{App: app, Name: squash.Name, Path: squash.Path, Replaces: squash.Replaces}
+			replaced := make(map[MigrationKey]bool, len(squash.Replaces))
+			for _, r := range squash.Replaces {
+				replaced[r] = true
+				if original, ok := byName[r.Name]; ok && r.App == app {
+					prunable.ReplacedPaths = append(prunable.ReplacedPaths, original.Path)
+				}
+			}
+
+			for _, others := range graph {
+				for _, m := range others {
+					if replaced[MigrationKey{App: m.App, Name: m.Name}] || (m.App == app && m.Name == squash.Name) {
+						continue
+					}
+					for _, dep := range m.Dependencies {
+						if replaced[dep] {
+							prunable.DependentPaths = append(prunable.DependentPaths, m.Path)
+							break
+						}
+					}
+				}
+			}
+			sort.Strings(prunable.DependentPaths)
+			result = append(result, prunable)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].App != result[j].App {
+			return result[i].App < result[j].App
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// FindPrunableSquashes lists squashed migrations that are applied and ready to prune
+func (p *Project) FindPrunableSquashes() []PrunableSquash {
+	applied := make(map[MigrationKey]bool)
+	for _, m := range p.Migrations {
+		if m.Applied {
+			applied[MigrationKey{App: m.App, Name: m.Name}] = true
+		}
+	}
+	return findPrunableSquashes(p.LoadMigrationFiles(), applied)
+}
+
+// rewriteSquashedDependencies points dependencies on replaced migrations at the squash
+func rewriteSquashedDependencies(source string, replaces []MigrationKey, squash MigrationKey) string {
+	_, bodyStart, end, ok := locatePythonListAssignment(source, "dependencies")
+	if !ok {
+		return source
+	}
+
+	replaced := make(map[MigrationKey]bool, len(replaces))
+	for _, r := range replaces {
+		replaced[r] = true
+	}
+
+	body := migrationTuplePattern.ReplaceAllStringFunc(source[bodyStart:end], func(tuple string) string {
+		match := migrationTuplePattern.FindStringSubmatch(tuple)
+		if !replaced[MigrationKey{App: match[1], Name: match[2]}] {
+			return tuple
+		}
+		return fmt.Sprintf("(%q, %q)", squash.App, squash.Name)
+	})
+	return source[:bodyStart] + body + source[end:]
+}
+
+// removeReplacesAssignment drops the `replaces = [...]` statement from a migration
+func removeReplacesAssignment(source string) string {
+	start, _, end, ok := locatePythonListAssignment(source, "replaces")
+	if !ok {
+		return source
+	}
+	end++
+	if end < len(source) && source[end] == '\n' {
+		end++
+	}
+	// Drop the blank line that usually separates class attributes.
+	if next := strings.IndexByte(source[end:], '\n'); next >= 0 && strings.TrimSpace(source[end:end+next]) == "" {
+		end += next + 1
+	}
+	return source[:start] + source[end:]
+}
+
+// PruneSquashedMigration deletes replaced migrations, updates dependents, and drops `replaces`
+func (p *Project) PruneSquashedMigration(squash PrunableSquash) error {
+	key := MigrationKey{App: squash.App, Name: squash.Name}
+	for _, path := range squash.DependentPaths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		updated := rewriteSquashedDependencies(string(content), squash.Replaces, key)
+		if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+			return fmt.Errorf("failed to update %s: %w", path, err)
+		}
+	}
+
+	content, err := os.ReadFile(squash.Path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", squash.Path, err)
+	}
+	if err := os.WriteFile(squash.Path, []byte(removeReplacesAssignment(string(content))), 0644); err != nil {
+		return fmt.Errorf("failed to update %s: %w", squash.Path, err)
+	}
+
+	for _, path := range squash.ReplacedPaths {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete %s: %w", path, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,286 @@
+package django
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMigrationSource(t *testing.T) {
+	source := `from django.conf import settings
+from django.db import migrations
+
+
+class Migration(migrations.Migration):
+
+    replaces = [("blog", "0001_initial"), ("blog", "0002_post_title")]
+
+    dependencies = [
+        migrations.swappable_dependency(settings.AUTH_USER_MODEL),
+        ('auth', '0012_alter_user_first_name_max_length'),
+        ("blog", "0001_initial"),
+    ]
+
+    operations = []
+`
+	migration := parseMigrationSource("blog", "0003_squashed", source)
+
+	wantDeps := []MigrationKey{
+		{App: "auth", Name: "0012_alter_user_first_name_max_length"},
+		{App: "blog", Name: "0001_initial"},
+	}
+	if !reflect.DeepEqual(migration.Dependencies, wantDeps) {
+		t.Fatalf("unexpected dependencies: %#v", migration.Dependencies)
+	}
+	if len(migration.Replaces) != 2 || migration.Replaces[1].Name != "0002_post_title" {
+		t.Fatalf("unexpected replaces: %#v", migration.Replaces)
+	}
+}
+
+func TestFindLeafMigrations(t *testing.T) {
+	linear := []MigrationFile{
+		{App: "blog", Name: "0001_initial"},
+		{App: "blog", Name: "0002_post", Dependencies: []MigrationKey{{App: "blog", Name: "0001_initial"}}},
+	}
+	if leaves := findLeafMigrations("blog", linear); !reflect.DeepEqual(leaves, []string{"0002_post"}) {
+		t.Fatalf("expected single leaf, got %v", leaves)
+	}
+
+	conflicting := append(linear, MigrationFile{
+		App:          "blog",
+		Name:         "0002_comment",
+		Dependencies: []MigrationKey{{App: "blog", Name: "0001_initial"}},
+	})
+	if leaves := findLeafMigrations("blog", conflicting); !reflect.DeepEqual(leaves, []string{"0002_comment", "0002_post"}) {
+		t.Fatalf("expected two leaves, got %v", leaves)
+	}
+}
+
+func TestFindLeafMigrationsFoldsSquashedMigrations(t *testing.T) {
+	migrations := []MigrationFile{
+		{App: "blog", Name: "0001_initial"},
+		{App: "blog", Name: "0002_post", Dependencies: []MigrationKey{{App: "blog", Name: "0001_initial"}}},
+		{App: "blog", Name: "0001_squashed_0002_post", Replaces: []MigrationKey{
+			{App: "blog", Name: "0001_initial"},
+			{App: "blog", Name: "0002_post"},
+		}},
+		{App: "blog", Name: "0003_tag", Dependencies: []MigrationKey{{App: "blog", Name: "0002_post"}}},
+	}
+	if leaves := findLeafMigrations("blog", migrations); !reflect.DeepEqual(leaves, []string{"0003_tag"}) {
+		t.Fatalf("expected squashed graph to have single leaf, got %v", leaves)
+	}
+}
+
+func TestDetectMigrationConflictsFromDisk(t *testing.T) {
+	root := t.TempDir()
+	migrationsDir := filepath.Join(root, "blog", "migrations")
+	if err := os.MkdirAll(migrationsDir, 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	files := map[string]string{
+		"__init__.py":      "",
+		"0001_initial.py":  "dependencies = []\n",
+		"0002_post.py":     "dependencies = [('blog', '0001_initial')]\n",
+		"0002_comment.py":  "dependencies = [('blog', '0001_initial')]\n",
+		"0003_unrelated.p": "",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(migrationsDir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+
+	project := &Project{RootDir: root, Apps: []App{{Name: "blog", Path: "blog"}}}
+	conflicts := project.DetectMigrationConflicts()
+	if !reflect.DeepEqual(conflicts["blog"], []string{"0002_comment", "0002_post"}) {
+		t.Fatalf("unexpected conflicts: %#v", conflicts)
+	}
+}
+
+func TestParseMakemigrationsCheck(t *testing.T) {
+	missing, err := parseMakemigrationsCheck("No changes detected", nil)
+	if missing || err != nil {
+		t.Fatalf("expected clean result, got missing=%v err=%v", missing, err)
+	}
+
+	missing, err = parseMakemigrationsCheck("Migrations for 'blog':\n  blog/migrations/0003_post_slug.py", errors.New("exit status 1"))
+	if !missing || err != nil {
+		t.Fatalf("expected missing migrations, got missing=%v err=%v", missing, err)
+	}
+
+	missing, err = parseMakemigrationsCheck("ImproperlyConfigured", errors.New("exit status 1"))
+	if missing || err == nil {
+		t.Fatalf("expected command error, got missing=%v err=%v", missing, err)
+	}
+}
+
+func TestBuildSquashPlan(t *testing.T) {
+	project := &Project{Migrations: []Migration{
+		{App: "blog", Name: "0001_initial", Applied: true},
+		{App: "blog", Name: "0002_post", Applied: true},
+		{App: "blog", Name: "0003_tag", Applied: true},
+		{App: "shop", Name: "0001_initial", Applied: true},
+	}}
+
+	plan, err := project.BuildSquashPlan("blog", "", "0003_tag")
+	if err != nil {
+		t.Fatalf("BuildSquashPlan returned error: %v", err)
+	}
+	if plan.SquashedName != "0001_squashed_0003_tag" || len(plan.Replaces) != 3 {
+		t.Fatalf("unexpected plan: %#v", plan)
+	}
+	if got := plan.Args(); !reflect.DeepEqual(got, []string{"squashmigrations", "--noinput", "blog", "0003_tag"}) {
+		t.Fatalf("unexpected args: %v", got)
+	}
+
+	plan, err = project.BuildSquashPlan("blog", "0002_post", "0003_tag")
+	if err != nil {
+		t.Fatalf("BuildSquashPlan returned error: %v", err)
+	}
+	if plan.SquashedName != "0002_post_squashed_0003_tag" || len(plan.Args()) != 5 {
+		t.Fatalf("unexpected ranged plan: %#v", plan)
+	}
+
+	if _, err := project.BuildSquashPlan("blog", "0003_tag", "0002_post"); err == nil {
+		t.Fatal("expected error for inverted range")
+	}
+	if _, err := project.BuildSquashPlan("shop", "", "0001_initial"); err == nil {
+		t.Fatal("expected error for single-migration range")
+	}
+}
+
+func TestReadSquashedMigration(t *testing.T) {
+	root := t.TempDir()
+	project := &Project{RootDir: root, Apps: []App{{Name: "apps.blog", Path: "apps/blog"}}}
+	plan := SquashPlan{App: "blog", End: "0002_post", SquashedName: "0001_squashed_0002_post"}
+
+	path, err := project.SquashedMigrationPath(plan)
+	if err != nil {
+		t.Fatalf("SquashedMigrationPath returned error: %v", err)
+	}
+	if want := filepath.Join(root, "apps", "blog", "migrations", "0001_squashed_0002_post.py"); path != want {
+		t.Fatalf("expected %s, got %s", want, path)
+	}
+	if _, err := project.SquashedMigrationPath(SquashPlan{App: "shop"}); err == nil {
+		t.Fatal("expected error for unknown app")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	source := `class Migration(migrations.Migration):
+
+    replaces = [('blog', '0001_initial'), ('blog', '0002_post')]
+
+    dependencies = [
+        ('auth', '0012_alter_user_first_name_max_length'),
+    ]
+
+    operations = [
+        migrations.CreateModel(
+            name='Post',
+            fields=[('id', models.BigAutoField(primary_key=True))],
+        ),
+        migrations.AddField(
+            model_name='post',
+            name='title',
+            field=models.CharField(max_length=200),
+        ),
+    ]
+`
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	squashed, err := ReadSquashedMigration(path)
+	if err != nil {
+		t.Fatalf("ReadSquashedMigration returned error: %v", err)
+	}
+	if len(squashed.Replaces) != 2 || len(squashed.Dependencies) != 1 || squashed.Dependencies[0].App != "auth" {
+		t.Fatalf("unexpected header: %#v", squashed)
+	}
+	if squashed.Operations != 2 || squashed.Source != source {
+		t.Fatalf("expected 2 operations and the full source, got %d", squashed.Operations)
+	}
+}
+
+func TestPruneSquashedMigration(t *testing.T) {
+	root := t.TempDir()
+	blogDir := filepath.Join(root, "blog", "migrations")
+	shopDir := filepath.Join(root, "shop", "migrations")
+	for _, dir := range []string{blogDir, shopDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+	}
+
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("write %s: %v", path, err)
+		}
+	}
+	write(filepath.Join(blogDir, "0001_initial.py"), "dependencies = []\n")
+	write(filepath.Join(blogDir, "0002_post.py"), "dependencies = [('blog', '0001_initial')]\n")
+	write(filepath.Join(blogDir, "0001_squashed_0002_post.py"), `class Migration(migrations.Migration):
+
+    replaces = [
+        ('blog', '0001_initial'),
+        ('blog', '0002_post'),
+    ]
+
+    dependencies = []
+`)
+	write(filepath.Join(blogDir, "0003_tag.py"), "dependencies = [('blog', '0002_post')]\n")
+	write(filepath.Join(shopDir, "0001_initial.py"), "dependencies = [\n    ('blog', '0001_initial'),\n]\n")
+
+	project := &Project{
+		RootDir: root,
+		Apps:    []App{{Name: "blog", Path: "blog"}, {Name: "shop", Path: "shop"}},
+		Migrations: []Migration{
+			{App: "blog", Name: "0001_squashed_0002_post", Applied: true},
+			{App: "blog", Name: "0003_tag", Applied: true},
+			{App: "shop", Name: "0001_initial", Applied: true},
+		},
+	}
+
+	prunable := project.FindPrunableSquashes()
+	if len(prunable) != 1 {
+		t.Fatalf("expected one prunable squash, got %#v", prunable)
+	}
+	squash := prunable[0]
+	if len(squash.ReplacedPaths) != 2 || len(squash.DependentPaths) != 2 {
+		t.Fatalf("unexpected prune plan: %#v", squash)
+	}
+
+	if err := project.PruneSquashedMigration(squash); err != nil {
+		t.Fatalf("PruneSquashedMigration returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(blogDir, "0001_initial.py")); !os.IsNotExist(err) {
+		t.Fatal("expected replaced migration to be deleted")
+	}
+	tag, _ := os.ReadFile(filepath.Join(blogDir, "0003_tag.py"))
+	if string(tag) != "dependencies = [(\"blog\", \"0001_squashed_0002_post\")]\n" {
+		t.Fatalf("unexpected rewritten dependency: %q", tag)
+	}
+	shop, _ := os.ReadFile(filepath.Join(shopDir, "0001_initial.py"))
+	if !strings.Contains(string(shop), "0001_squashed_0002_post") {
+		t.Fatalf("expected cross-app dependency to be rewritten: %q", shop)
+	}
+	squashed, _ := os.ReadFile(filepath.Join(blogDir, "0001_squashed_0002_post.py"))
+	if strings.Contains(string(squashed), "replaces") {
+		t.Fatalf("expected replaces to be removed: %q", squashed)
+	}
+	if !strings.Contains(string(squashed), "    dependencies = []") {
+		t.Fatalf("expected remaining attributes to be preserved: %q", squashed)
+	}
+
+	project.Migrations[0].Applied = false
+	if prunable := project.FindPrunableSquashes(); len(prunable) != 0 {
+		t.Fatalf("expected unapplied squash to be skipped, got %#v", prunable)
+	}
+}
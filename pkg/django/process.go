@@ -0,0 +1,59 @@
+package django
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// processWaitDelay bounds how long Wait blocks on output pipes after a cancelled process is killed
+const processWaitDelay = 5 * time.Second
+
+// CommandContext is exec.CommandContext with the process started in its own group.
+// Cancelling ctx kills the whole group, so shells, docker clients and their children all stop.
+func CommandContext(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	SetProcessGroup(cmd)
+	cmd.Cancel = func() error { return KillProcessGroup(cmd) }
+	cmd.WaitDelay = processWaitDelay
+	return cmd
+}
+
+// contextError attributes a command failure to ctx when it was cancelled or timed out,
+// so callers can test it with errors.Is(err, context.Canceled) or context.DeadlineExceeded.
+func contextError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil || errors.Is(err, ctx.Err()) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", ctx.Err(), err)
+}
+
+// OperationContext derives a context bounded by the configured timeout for op
+func (p *Project) OperationContext(parent context.Context, op string) (context.Context, context.CancelFunc) {
+	if parent == nil {
+		parent = context.Background()
+	}
+	if timeout := p.Timeouts.Duration(op); timeout > 0 {
+		return context.WithTimeout(parent, timeout)
+	}
+	return context.WithCancel(parent)
+}
+
+// KillCommand kills a started command through its Cancel hook when it has one, so a command
+// running in a container is killed there too and not just its docker client
+func KillCommand(cmd *exec.Cmd) error {
+	if cmd != nil && cmd.Cancel != nil && cmd.Process != nil {
+		return cmd.Cancel()
+	}
+	return KillProcessGroup(cmd)
+}
+
+// stopProcessGroup sends SIGTERM to cmd's group, or kills it when force is set
+func stopProcessGroup(cmd *exec.Cmd, force bool) error {
+	if force {
+		return KillProcessGroup(cmd)
+	}
+	return TerminateProcessGroup(cmd)
+}
@@ -0,0 +1,47 @@
+//go:build !windows
+
+package django
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// SetProcessGroup makes cmd the leader of a new process group
+func SetProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.Setpgid = true
+}
+
+// KillProcessGroup kills a started command and every process in its group
+func KillProcessGroup(cmd *exec.Cmd) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL); err == nil {
+			return nil
+		}
+	}
+	return cmd.Process.Kill()
+}
+
+// TerminateProcessGroup asks a started command and its group to exit with SIGTERM
+func TerminateProcessGroup(cmd *exec.Cmd) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	if cmd.SysProcAttr != nil && cmd.SysProcAttr.Setpgid {
+		if err := syscall.Kill(-cmd.Process.Pid, syscall.SIGTERM); err == nil {
+			return nil
+		}
+	}
+	return cmd.Process.Signal(syscall.SIGTERM)
+}
+
+// TerminateProcess asks an unrelated process to exit with SIGTERM
+func TerminateProcess(pid int) error {
+	return syscall.Kill(pid, syscall.SIGTERM)
+}
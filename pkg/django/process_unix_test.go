@@ -0,0 +1,73 @@
+//go:build !windows
+
+package django
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+func TestCommandContextKillsProcessGroup(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The background sleep keeps stdout open; only killing the whole group lets Wait return promptly.
+	cmd := CommandContext(ctx, "sh", "-c", "sleep 30 & wait")
+	started := time.Now()
+	_, err := cmd.CombinedOutput()
+	if err == nil {
+		t.Fatal("expected the command to be killed")
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("expected the process group to die with the context, took %v", elapsed)
+	}
+	if err := contextError(ctx, err); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+}
+
+func TestRunCommandContextCancelled(t *testing.T) {
+	root := t.TempDir()
+	managePy := filepath.Join(root, "manage.py")
+	if err := os.WriteFile(managePy, []byte("sleep 30\n"), 0644); err != nil {
+		t.Fatalf("failed to write manage.py: %v", err)
+	}
+	project := &Project{RootDir: root, ManagePyPath: managePy}
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorPython, Python: "sh"}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+	started := time.Now()
+	_, err := project.RunCommandContext(ctx, "migrate")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("expected cancellation to stop the command, took %v", elapsed)
+	}
+}
+
+func TestOperationContextTimeouts(t *testing.T) {
+	project := &Project{Timeouts: config.TimeoutConfig{Shell: "off", Command: "1s"}}
+
+	ctx, cancel := project.OperationContext(context.Background(), config.TimeoutShell)
+	defer cancel()
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("expected no deadline when the shell timeout is off")
+	}
+
+	ctx, cancel = project.OperationContext(context.Background(), config.TimeoutCommand)
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	if !ok || time.Until(deadline) > time.Second {
+		t.Fatalf("expected a 1s deadline, got %v (ok=%v)", deadline, ok)
+	}
+}
@@ -0,0 +1,43 @@
+//go:build windows
+
+package django
+
+import (
+	"os"
+	"os/exec"
+	"strconv"
+	"syscall"
+)
+
+// SetProcessGroup makes cmd the root of a new process group
+func SetProcessGroup(cmd *exec.Cmd) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= syscall.CREATE_NEW_PROCESS_GROUP
+}
+
+// KillProcessGroup kills a started command and its child processes
+func KillProcessGroup(cmd *exec.Cmd) error {
+	if cmd == nil || cmd.Process == nil {
+		return nil
+	}
+	if err := exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run(); err == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
+
+// TerminateProcessGroup stops a started command and its children; Windows has no SIGTERM
+func TerminateProcessGroup(cmd *exec.Cmd) error {
+	return KillProcessGroup(cmd)
+}
+
+// TerminateProcess stops an unrelated process
+func TerminateProcess(pid int) error {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return proc.Kill()
+}
@@ -0,0 +1,102 @@
+package django
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+// procfileCandidates are tried in order when no Procfile is configured
+var procfileCandidates = []string{"Procfile.dev", "Procfile"}
+
+// ParseProcfile reads `name: command` lines as used by foreman, honcho and heroku
+func ParseProcfile(content string) ([]config.ProcessConfig, error) {
+	var processes []config.ProcessConfig
+	seen := make(map[string]bool)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, command, ok := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		command = strings.TrimSpace(command)
+		if !ok || name == "" || command == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("line %d: expected \"name: command\"", lineNo)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("line %d: duplicate process %q", lineNo, name)
+		}
+		seen[name] = true
+		processes = append(processes, config.ProcessConfig{Name: name, Command: command})
+	}
+	return processes, scanner.Err()
+}
+
+// ProcfilePath returns the Procfile processes are imported from, or "" when there is none
+func (p *Project) ProcfilePath() string {
+	if configured := strings.TrimSpace(p.ProcessConfig.Procfile); configured != "" {
+		if !filepath.IsAbs(configured) {
+			configured = filepath.Join(p.RootDir, configured)
+		}
+		return configured
+	}
+	for _, name := range procfileCandidates {
+		path := filepath.Join(p.RootDir, name)
+		if fileExists(path) {
+			return path
+		}
+	}
+	return ""
+}
+
+// Processes returns the Procfile entries followed by configured processes. A configured process
+// with the name of a Procfile entry replaces it.
+func (p *Project) Processes() ([]config.ProcessConfig, error) {
+	var processes []config.ProcessConfig
+	var procfileErr error
+	if path := p.ProcfilePath(); path != "" {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			processes, err = ParseProcfile(string(data))
+		}
+		if err != nil {
+			procfileErr = fmt.Errorf("%s: %w", filepath.Base(path), err)
+		}
+	}
+
+	for _, custom := range p.ProcessConfig.List {
+		if strings.TrimSpace(custom.Name) == "" || strings.TrimSpace(custom.Command) == "" {
+			continue
+		}
+		replaced := false
+		for i := range processes {
+			if processes[i].Name == custom.Name {
+				processes[i] = custom
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			processes = append(processes, custom)
+		}
+	}
+	return processes, procfileErr
+}
+
+// ProcessEnv returns the environment for the index'th process: the profile env plus a PORT
+// following the foreman/honcho convention of 5000, 5100, ...
+func ProcessEnv(process config.ProcessConfig, index int) []string {
+	env := []string{fmt.Sprintf("PORT=%d", 5000+100*index)}
+	for _, key := range sortedEnvKeys(process.Env) {
+		env = append(env, key+"="+process.Env[key])
+	}
+	return env
+}
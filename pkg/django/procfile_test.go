@@ -0,0 +1,60 @@
+package django
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+func TestParseProcfile(t *testing.T) {
+	processes, err := ParseProcfile("# dev processes\nweb: python manage.py runserver $PORT\n\nworker: celery -A mysite worker -l info\ncss:npm run watch:css\n")
+	if err != nil {
+		t.Fatalf("ParseProcfile failed: %v", err)
+	}
+	if len(processes) != 3 || processes[1].Name != "worker" || processes[2].Command != "npm run watch:css" {
+		t.Fatalf("unexpected processes %+v", processes)
+	}
+
+	if _, err := ParseProcfile("web: a\nweb: b\n"); err == nil {
+		t.Fatal("expected an error for duplicate names")
+	}
+	if _, err := ParseProcfile("no separator here\n"); err == nil {
+		t.Fatal("expected an error for a line without a name")
+	}
+}
+
+func TestProjectProcesses(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "Procfile"), []byte("web: gunicorn mysite.wsgi\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "Procfile.dev"), []byte("worker: celery -A mysite worker\nassets: npm run watch\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &Project{
+		RootDir: root,
+		ProcessConfig: config.ProcessesConfig{List: []config.ProcessConfig{
+			{Name: "assets", Command: "npx tailwindcss --watch"},
+			{Name: "rq", Command: "python manage.py rqworker", Env: map[string]string{"PORT": "7000"}},
+		}},
+	}
+
+	processes, err := project.Processes()
+	if err != nil {
+		t.Fatalf("Processes failed: %v", err)
+	}
+	if len(processes) != 3 || processes[0].Name != "worker" || processes[1].Command != "npx tailwindcss --watch" || processes[2].Name != "rq" {
+		t.Fatalf("expected Procfile.dev entries with config overrides, got %+v", processes)
+	}
+	if env := ProcessEnv(processes[2], 2); len(env) != 2 || env[0] != "PORT=5200" || env[1] != "PORT=7000" {
+		t.Fatalf("expected the configured PORT to follow the default, got %v", env)
+	}
+
+	project.ProcessConfig.Procfile = "Procfile"
+	project.ProcessConfig.List = nil
+	if processes, _ := project.Processes(); len(processes) != 1 || processes[0].Name != "web" {
+		t.Fatalf("expected the configured Procfile, got %+v", processes)
+	}
+}
@@ -0,0 +1,258 @@
+package django
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SchemaField describes a concrete or many-to-many model field
+type SchemaField struct {
+	Name         string  `json:"name"`
+	Type         string  `json:"type"`
+	Column       string  `json:"column"`
+	Null         bool    `json:"null"`
+	PrimaryKey   bool    `json:"primary_key"`
+	Unique       bool    `json:"unique"`
+	DBIndex      bool    `json:"db_index"`
+	Default      *string `json:"default"`
+	RelatedModel string  `json:"related_model,omitempty"`
+}
+
+// SchemaIndex describes a Meta.indexes entry
+type SchemaIndex struct {
+	Name   string   `json:"name"`
+	Fields []string `json:"fields"`
+}
+
+// SchemaConstraint describes a Meta.constraints entry
+type SchemaConstraint struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Fields     []string `json:"fields"`
+	Definition string   `json:"definition"`
+}
+
+// SchemaRelation describes an outbound or inbound model relation
+type SchemaRelation struct {
+	Direction string `json:"direction"` // "outbound" or "inbound"
+	Kind      string `json:"kind"`      // many_to_one, one_to_one, many_to_many, one_to_many
+	Field     string `json:"field"`
+	Model     string `json:"model"`
+}
+
+// ModelSchema is the `_meta` view of a model
+type ModelSchema struct {
+	App            string             `json:"app"`
+	Model          string             `json:"model"`
+	DBTable        string             `json:"db_table"`
+	Ordering       []string           `json:"ordering"`
+	UniqueTogether [][]string         `json:"unique_together"`
+	Indexes        []SchemaIndex      `json:"indexes"`
+	Constraints    []SchemaConstraint `json:"constraints"`
+	Managers       []string           `json:"managers"`
+	Fields         []SchemaField      `json:"fields"`
+	Relations      []SchemaRelation   `json:"relations"`
+	DDL            string             `json:"ddl,omitempty"`
+}
+
+const modelSchemaScript = `
+import json
+from django.apps import apps
+from django.db import connection
+from django.db.models.fields import NOT_PROVIDED
+
+def _label(m):
+    if m is None:
+        return ''
+    return f'{m._meta.app_label}.{m.__name__}'
+
+def _default(field):
+    default = getattr(field, 'default', NOT_PROVIDED)
+    if default is NOT_PROVIDED:
+        return None
+    if callable(default):
+        return getattr(default, '__name__', repr(default)) + '()'
+    return repr(default)
+
+def _kind(field):
+    if field.many_to_many:
+        return 'many_to_many'
+    if field.one_to_one:
+        return 'one_to_one'
+    if field.one_to_many:
+        return 'one_to_many'
+    return 'many_to_one'
+
+def _ddl(model):
+    table = model._meta.db_table
+    vendor = connection.vendor
+    with connection.cursor() as cursor:
+        if vendor == 'sqlite':
+            cursor.execute("SELECT sql FROM sqlite_master WHERE tbl_name = %%s AND sql IS NOT NULL", [table])
+            return '\n'.join(row[0] + ';' for row in cursor.fetchall())
+        if vendor == 'mysql':
+            cursor.execute('SHOW CREATE TABLE ' + connection.ops.quote_name(table))
+            return cursor.fetchone()[1] + ';'
+        if vendor == 'postgresql':
+            cursor.execute("SELECT column_name, data_type, character_maximum_length, is_nullable, column_default FROM information_schema.columns WHERE table_name = %%s AND table_schema = current_schema() ORDER BY ordinal_position", [table])
+            cols = []
+            for name, dtype, length, nullable, default in cursor.fetchall():
+                col = f'    "{name}" {dtype}' + (f'({length})' if length else '')
+                if nullable == 'NO':
+                    col += ' NOT NULL'
+                if default is not None:
+                    col += f' DEFAULT {default}'
+                cols.append(col)
+            cursor.execute("SELECT c.conname, pg_get_constraintdef(c.oid) FROM pg_constraint c JOIN pg_class t ON t.oid = c.conrelid WHERE t.relname = %%s", [table])
+            for name, definition in cursor.fetchall():
+                cols.append(f'    CONSTRAINT "{name}" {definition}')
+            lines = [f'CREATE TABLE "{table}" (', ',\n'.join(cols), ');']
+            cursor.execute("SELECT indexdef FROM pg_indexes WHERE tablename = %%s AND schemaname = current_schema()", [table])
+            lines.extend(row[0] + ';' for row in cursor.fetchall())
+            return '\n'.join(lines)
+        description = connection.introspection.get_table_description(cursor, table)
+        return '\n'.join(f'{col.name} null={col.null_ok}' for col in description)
+
+try:
+    model = apps.get_model(%s, %s)
+    meta = model._meta
+    fields = []
+    for field in list(meta.concrete_fields) + list(meta.local_many_to_many):
+        column = field.m2m_db_table() if field.many_to_many else field.column
+        fields.append({
+            'name': field.name,
+            'type': field.get_internal_type(),
+            'column': column or '',
+            'null': bool(field.null),
+            'primary_key': bool(field.primary_key),
+            'unique': bool(field.unique),
+            'db_index': bool(field.db_index),
+            'default': _default(field),
+            'related_model': _label(field.related_model) if field.is_relation else '',
+        })
+
+    relations = []
+    for field in meta.get_fields(include_hidden=False):
+        if not field.is_relation or field.related_model is None:
+            continue
+        if field.auto_created and not field.concrete:
+            relations.append({'direction': 'inbound', 'kind': _kind(field), 'field': field.field.name, 'model': _label(field.related_model)})
+        else:
+            relations.append({'direction': 'outbound', 'kind': _kind(field), 'field': field.name, 'model': _label(field.related_model)})
+
+    payload = {
+        'app': meta.app_label,
+        'model': model.__name__,
+        'db_table': meta.db_table,
+        'ordering': [str(item) for item in meta.ordering],
+        'unique_together': [list(group) for group in meta.unique_together],
+        'indexes': [{'name': index.name or '', 'fields': list(index.fields)} for index in meta.indexes],
+        'constraints': [{'name': c.name, 'type': type(c).__name__, 'fields': list(getattr(c, 'fields', ()) or ()), 'definition': repr(c)} for c in meta.constraints],
+        'managers': [f'{m.name} ({type(m).__name__})' for m in meta.managers],
+        'fields': fields,
+        'relations': relations,
+    }
+    if %s:
+        try:
+            payload['ddl'] = _ddl(model)
+        except Exception as ddl_error:
+            payload['ddl'] = f'-- DDL introspection failed: {ddl_error}'
+    print(json.dumps(payload))
+except Exception as e:
+    print(json.dumps({'error': str(e)}))
+`
+
+// GetModelSchema returns fields, indexes, constraints, and relations from a model's _meta.
+// When includeDDL is set, the table definition is read back from the database.
+func (dv *DataViewer) GetModelSchema(appName, modelName string, includeDDL bool) (*ModelSchema, error) {
+	ddlFlag := "False"
+	if includeDDL {
+		ddlFlag = "True"
+	}
+	pythonCmd := fmt.Sprintf(modelSchemaScript, pythonLiteral(appName), pythonLiteral(modelName), ddlFlag)
+
+	result, err := dv.runPythonScript(pythonCmd)
+	if err != nil {
+		return nil, fmt.Errorf("get schema failed: %w", err)
+	}
+
+	var schema ModelSchema
+	if err := mapToStruct(result, &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	return &schema, nil
+}
+
+// String returns a readable schema summary
+func (s ModelSchema) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s.%s  (table: %s)\n", s.App, s.Model, s.DBTable)
+	if len(s.Ordering) > 0 {
+		fmt.Fprintf(&b, "ordering: %s\n", strings.Join(s.Ordering, ", "))
+	}
+	if len(s.Managers) > 0 {
+		fmt.Fprintf(&b, "managers: %s\n", strings.Join(s.Managers, ", "))
+	}
+
+	fmt.Fprintf(&b, "\nFields (%d)\n", len(s.Fields))
+	for _, field := range s.Fields {
+		flags := make([]string, 0, 5)
+		if field.PrimaryKey {
+			flags = append(flags, "pk")
+		}
+		if field.Null {
+			flags = append(flags, "null")
+		}
+		if field.Unique && !field.PrimaryKey {
+			flags = append(flags, "unique")
+		}
+		if field.DBIndex {
+			flags = append(flags, "index")
+		}
+		if field.Default != nil {
+			flags = append(flags, "default="+*field.Default)
+		}
+		line := fmt.Sprintf("  %-24s %-22s column=%s", field.Name, field.Type, field.Column)
+		if field.RelatedModel != "" {
+			line += " -> " + field.RelatedModel
+		}
+		if len(flags) > 0 {
+			line += "  [" + strings.Join(flags, " ") + "]"
+		}
+		fmt.Fprintln(&b, line)
+	}
+
+	if len(s.Indexes) > 0 || len(s.UniqueTogether) > 0 {
+		fmt.Fprintln(&b, "\nIndexes")
+		for _, index := range s.Indexes {
+			fmt.Fprintf(&b, "  %s (%s)\n", index.Name, strings.Join(index.Fields, ", "))
+		}
+		for _, group := range s.UniqueTogether {
+			fmt.Fprintf(&b, "  unique_together (%s)\n", strings.Join(group, ", "))
+		}
+	}
+
+	if len(s.Constraints) > 0 {
+		fmt.Fprintln(&b, "\nConstraints")
+		for _, constraint := range s.Constraints {
+			fmt.Fprintf(&b, "  %s %s: %s\n", constraint.Type, constraint.Name, constraint.Definition)
+		}
+	}
+
+	if len(s.Relations) > 0 {
+		fmt.Fprintln(&b, "\nRelations")
+		for _, relation := range s.Relations {
+			arrow := "->"
+			if relation.Direction == "inbound" {
+				arrow = "<-"
+			}
+			fmt.Fprintf(&b, "  %s %s %s (%s)\n", arrow, relation.Model, relation.Field, relation.Kind)
+		}
+	}
+
+	if strings.TrimSpace(s.DDL) != "" {
+		fmt.Fprintf(&b, "\nDDL\n%s\n", strings.TrimSpace(s.DDL))
+	}
+	return b.String()
+}
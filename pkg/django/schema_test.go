@@ -0,0 +1,69 @@
+package django
+
+import (
+	"strings"
+	"testing"
+)
+
+type recordingRunner struct {
+	args     []string
+	response string
+}
+
+func (r *recordingRunner) RunCommand(args ...string) (string, error) {
+	r.args = args
+	return r.response, nil
+}
+
+func TestGetModelSchema(t *testing.T) {
+	runner := &recordingRunner{response: `{
+		"app": "blog",
+		"model": "Post",
+		"db_table": "blog_post",
+		"ordering": ["-created"],
+		"unique_together": [["author", "slug"]],
+		"indexes": [{"name": "blog_post_created_idx", "fields": ["-created"]}],
+		"constraints": [{"name": "title_not_empty", "type": "CheckConstraint", "fields": [], "definition": "<CheckConstraint: ...>"}],
+		"managers": ["objects (Manager)"],
+		"fields": [
+			{"name": "id", "type": "BigAutoField", "column": "id", "null": false, "primary_key": true, "unique": true, "db_index": false, "default": null, "related_model": ""},
+			{"name": "author", "type": "ForeignKey", "column": "author_id", "null": true, "primary_key": false, "unique": false, "db_index": true, "default": null, "related_model": "auth.User"},
+			{"name": "status", "type": "CharField", "column": "status", "null": false, "primary_key": false, "unique": false, "db_index": false, "default": "'draft'", "related_model": ""}
+		],
+		"relations": [
+			{"direction": "outbound", "kind": "many_to_one", "field": "author", "model": "auth.User"},
+			{"direction": "inbound", "kind": "one_to_many", "field": "post", "model": "blog.Comment"}
+		],
+		"ddl": "CREATE TABLE \"blog_post\" (...);"
+	}`}
+
+	schema, err := NewDataViewer(runner).GetModelSchema("blog", "Post", true)
+	if err != nil {
+		t.Fatalf("GetModelSchema failed: %v", err)
+	}
+	if len(runner.args) != 3 || runner.args[0] != "shell" || !strings.Contains(runner.args[2], "if True:") {
+		t.Fatalf("expected DDL-enabled shell script, got %v", runner.args[:1])
+	}
+	if schema.DBTable != "blog_post" || len(schema.Fields) != 3 || len(schema.Relations) != 2 {
+		t.Fatalf("unexpected schema: %#v", schema)
+	}
+	if schema.Fields[2].Default == nil || *schema.Fields[2].Default != "'draft'" {
+		t.Fatalf("expected default to be parsed, got %#v", schema.Fields[2].Default)
+	}
+
+	text := schema.String()
+	for _, want := range []string{
+		"blog.Post  (table: blog_post)",
+		"ordering: -created",
+		"-> auth.User  [null index]",
+		"[default='draft']",
+		"unique_together (author, slug)",
+		"CheckConstraint title_not_empty",
+		"<- blog.Comment post (one_to_many)",
+		"DDL\nCREATE TABLE",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("schema text missing %q:\n%s", want, text)
+		}
+	}
+}
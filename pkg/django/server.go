@@ -0,0 +1,328 @@
+package django
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+// DefaultServerPort is the runserver port when none is configured
+const DefaultServerPort = 8000
+
+// Server runners
+const (
+	RunnerRunserver     = "runserver"
+	RunnerRunserverPlus = "runserver_plus"
+	RunnerGunicorn      = "gunicorn"
+	RunnerUvicorn       = "uvicorn"
+	RunnerDaphne        = "daphne"
+)
+
+// ServerRunners lists every runner name
+var ServerRunners = []string{RunnerRunserver, RunnerRunserverPlus, RunnerGunicorn, RunnerUvicorn, RunnerDaphne}
+
+// PortOwner is a process listening on a TCP port
+type PortOwner struct {
+	PID     int    // 0 when the owner could not be identified
+	Command string // Full command line when available, otherwise the process name
+}
+
+// String describes the owner for status lines and prompts
+func (o PortOwner) String() string {
+	switch {
+	case o.PID == 0:
+		return "an unknown process"
+	case o.Command == "":
+		return fmt.Sprintf("PID %d", o.PID)
+	default:
+		return fmt.Sprintf("PID %d (%s)", o.PID, o.Command)
+	}
+}
+
+// ServerAddress returns the runserver bind host and port. Containers and remote hosts
+// bind beyond loopback so the server is reachable from the host.
+func (p *Project) ServerAddress() (string, int) {
+	host := strings.TrimSpace(p.Server.Host)
+	if host == "" {
+		host = "127.0.0.1"
+		if p.RunsRemotely() {
+			host = "0.0.0.0"
+		}
+	}
+	port := p.Server.Port
+	if port <= 0 {
+		port = DefaultServerPort
+	}
+	return host, port
+}
+
+// ServerURL is the browsable address of a server bound to host:port
+func ServerURL(host string, port int) string {
+	if host == "0.0.0.0" || host == "::" || host == "" {
+		host = "127.0.0.1"
+	}
+	return "http://" + net.JoinHostPort(host, strconv.Itoa(port)) + "/"
+}
+
+// managePyArg is how the active executor refers to manage.py
+func (p *Project) managePyArg() string {
+	if local, ok := p.ActiveExecutor().(*localExecutor); ok {
+		return local.managePy
+	}
+	return "manage.py"
+}
+
+// PortAvailable reports whether host:port can be bound right now
+func PortAvailable(host string, port int) bool {
+	listener, err := net.Listen("tcp", net.JoinHostPort(host, strconv.Itoa(port)))
+	if err != nil {
+		return false
+	}
+	_ = listener.Close()
+	return true
+}
+
+// FindFreePort returns the first bindable port after start, or 0 when none of the next 50 are free
+func FindFreePort(host string, start int) int {
+	for port := start + 1; port <= start+50 && port < 65536; port++ {
+		if PortAvailable(host, port) {
+			return port
+		}
+	}
+	return 0
+}
+
+// FindPortOwner returns the process listening on host:port, or nil when the port is free
+func FindPortOwner(host string, port int) *PortOwner {
+	if PortAvailable(host, port) {
+		return nil
+	}
+
+	owner := &PortOwner{}
+	if !commandExists("lsof") {
+		return owner
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	output, _ := exec.CommandContext(ctx, "lsof", "-nP", fmt.Sprintf("-iTCP:%d", port), "-sTCP:LISTEN", "-Fpc").Output()
+	listeners := parseLsofListeners(string(output))
+	if len(listeners) == 0 {
+		return owner
+	}
+	*owner = listeners[0]
+	if args, err := exec.CommandContext(ctx, "ps", "-o", "command=", "-p", strconv.Itoa(owner.PID)).Output(); err == nil {
+		if command := strings.TrimSpace(string(args)); command != "" {
+			owner.Command = command
+		}
+	}
+	return owner
+}
+
+// parseLsofListeners reads `lsof -F pc` output: one p<pid> line per process followed by c<name>
+func parseLsofListeners(output string) []PortOwner {
+	var owners []PortOwner
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) < 2 {
+			continue
+		}
+		switch line[0] {
+		case 'p':
+			pid, err := strconv.Atoi(line[1:])
+			if err != nil {
+				continue
+			}
+			owners = append(owners, PortOwner{PID: pid})
+		case 'c':
+			if len(owners) > 0 && owners[len(owners)-1].Command == "" {
+				owners[len(owners)-1].Command = line[1:]
+			}
+		}
+	}
+	return owners
+}
+
+// ServerProfiles returns the built-in profiles the project can use, followed by configured ones.
+// A configured profile with a built-in name replaces it.
+func (p *Project) ServerProfiles() []config.ServerProfile {
+	profiles := []config.ServerProfile{{Name: RunnerRunserver, Runner: RunnerRunserver}}
+	for _, app := range p.InstalledApps {
+		if app == "django_extensions" {
+			profiles = append(profiles, config.ServerProfile{Name: RunnerRunserverPlus, Runner: RunnerRunserverPlus})
+			break
+		}
+	}
+	if p.ApplicationModule("wsgi") != "" {
+		profiles = append(profiles, config.ServerProfile{Name: RunnerGunicorn, Runner: RunnerGunicorn})
+	}
+	if p.ApplicationModule("asgi") != "" {
+		profiles = append(profiles,
+			config.ServerProfile{Name: RunnerUvicorn, Runner: RunnerUvicorn},
+			config.ServerProfile{Name: RunnerDaphne, Runner: RunnerDaphne},
+		)
+	}
+
+	for _, custom := range p.Server.Profiles {
+		if strings.TrimSpace(custom.Name) == "" {
+			continue
+		}
+		replaced := false
+		for i := range profiles {
+			if profiles[i].Name == custom.Name {
+				profiles[i] = custom
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			profiles = append(profiles, custom)
+		}
+	}
+	return profiles
+}
+
+// ServerProfile returns the named profile; an empty name selects the configured default
+func (p *Project) ServerProfile(name string) (config.ServerProfile, bool) {
+	if name = strings.TrimSpace(name); name == "" {
+		name = strings.TrimSpace(p.Server.Default)
+	}
+	if name == "" {
+		name = RunnerRunserver
+	}
+	for _, profile := range p.ServerProfiles() {
+		if profile.Name == name {
+			return profile, true
+		}
+	}
+	return config.ServerProfile{}, false
+}
+
+// ServerProfileAddress returns the bind host and port for a profile
+func (p *Project) ServerProfileAddress(profile config.ServerProfile) (string, int) {
+	host, port := p.ServerAddress()
+	if strings.TrimSpace(profile.Host) != "" {
+		host = strings.TrimSpace(profile.Host)
+	}
+	if profile.Port > 0 {
+		port = profile.Port
+	}
+	return host, port
+}
+
+// ApplicationModule returns the WSGI or ASGI application as module:attribute, from settings
+// or from a <package>/wsgi.py / asgi.py next to the settings package
+func (p *Project) ApplicationModule(kind string) string {
+	dotted := p.WSGIApplication
+	if kind == "asgi" {
+		dotted = p.ASGIApplication
+	}
+	if dotted == "" {
+		dotted = findApplicationModule(p.RootDir, p.SettingsModule, kind)
+	}
+	idx := strings.LastIndex(dotted, ".")
+	if idx <= 0 {
+		return ""
+	}
+	return dotted[:idx] + ":" + dotted[idx+1:]
+}
+
+// findApplicationModule looks for <package>/<kind>.py, preferring the settings package
+func findApplicationModule(rootDir, settingsModule, kind string) string {
+	var packages []string
+	if settingsModule != "" {
+		packages = append(packages, strings.Split(settingsModule, ".")[0])
+	}
+	if entries, err := os.ReadDir(rootDir); err == nil {
+		for _, entry := range entries {
+			name := entry.Name()
+			if entry.IsDir() && !strings.HasPrefix(name, ".") && name != "venv" && name != "node_modules" {
+				packages = append(packages, name)
+			}
+		}
+	}
+	for _, pkg := range packages {
+		if fileExists(filepath.Join(rootDir, pkg, kind+".py")) {
+			return pkg + "." + kind + ".application"
+		}
+	}
+	return ""
+}
+
+// ServerProfileLabel describes what a profile runs, e.g. "uvicorn mysite.asgi:application"
+func (p *Project) ServerProfileLabel(profile config.ServerProfile) string {
+	switch profile.Runner {
+	case RunnerGunicorn:
+		return "gunicorn " + p.profileApplication(profile, "wsgi")
+	case RunnerUvicorn, RunnerDaphne:
+		return profile.Runner + " " + p.profileApplication(profile, "asgi")
+	default:
+		return "manage.py " + profile.Runner
+	}
+}
+
+func (p *Project) profileApplication(profile config.ServerProfile, kind string) string {
+	if app := strings.TrimSpace(profile.App); app != "" {
+		return app
+	}
+	return p.ApplicationModule(kind)
+}
+
+// ServerCommand builds the command serving profile on host:port through the active executor
+func (p *Project) ServerCommand(profile config.ServerProfile, host string, port int) (*exec.Cmd, error) {
+	reload := profile.Reload == nil || *profile.Reload
+	bind := net.JoinHostPort(host, strconv.Itoa(port))
+	var args []string
+
+	switch profile.Runner {
+	case RunnerRunserver, RunnerRunserverPlus, "":
+		runner := profile.Runner
+		if runner == "" {
+			runner = RunnerRunserver
+		}
+		args = []string{runner, bind}
+		if !reload {
+			args = append(args, "--noreload")
+		}
+		args = append(args, profile.Args...)
+		return p.ActiveExecutor().Python(context.Background(), profile.Env, append([]string{p.managePyArg()}, args...)...), nil
+	case RunnerGunicorn:
+		app := p.profileApplication(profile, "wsgi")
+		if app == "" {
+			return nil, fmt.Errorf("no WSGI application found; set WSGI_APPLICATION or \"app\" on the profile")
+		}
+		args = []string{"-m", "gunicorn", app, "--bind", bind}
+		if reload {
+			args = append(args, "--reload")
+		}
+	case RunnerUvicorn:
+		app := p.profileApplication(profile, "asgi")
+		if app == "" {
+			return nil, fmt.Errorf("no ASGI application found; set ASGI_APPLICATION or \"app\" on the profile")
+		}
+		args = []string{"-m", "uvicorn", app, "--host", host, "--port", strconv.Itoa(port)}
+		if reload {
+			args = append(args, "--reload")
+		}
+	case RunnerDaphne:
+		app := p.profileApplication(profile, "asgi")
+		if app == "" {
+			return nil, fmt.Errorf("no ASGI application found; set ASGI_APPLICATION or \"app\" on the profile")
+		}
+		// daphne has no reloader of its own.
+		args = []string{"-m", "daphne", "-b", host, "-p", strconv.Itoa(port), app}
+	default:
+		return nil, fmt.Errorf("unknown server runner %q (expected %s)", profile.Runner, strings.Join(ServerRunners, ", "))
+	}
+	args = append(args, profile.Args...)
+	return p.ActiveExecutor().Python(context.Background(), profile.Env, args...), nil
+}
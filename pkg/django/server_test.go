@@ -0,0 +1,144 @@
+package django
+
+import (
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+func TestServerAddress(t *testing.T) {
+	project := &Project{RootDir: t.TempDir()}
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorPython}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+	if host, port := project.ServerAddress(); host != "127.0.0.1" || port != DefaultServerPort {
+		t.Fatalf("expected loopback default, got %s:%d", host, port)
+	}
+
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorSSH, Host: "dev@box"}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+	if host, _ := project.ServerAddress(); host != "0.0.0.0" {
+		t.Fatalf("expected remote executors to bind all interfaces, got %s", host)
+	}
+
+	project.Server = config.ServerConfig{Host: "localhost", Port: 8123}
+	if host, port := project.ServerAddress(); host != "localhost" || port != 8123 {
+		t.Fatalf("expected configured address, got %s:%d", host, port)
+	}
+	if url := ServerURL("0.0.0.0", 8123); url != "http://127.0.0.1:8123/" {
+		t.Fatalf("unexpected server URL %q", url)
+	}
+}
+
+func TestParseLsofListeners(t *testing.T) {
+	owners := parseLsofListeners("p4242\ncPython\nf5\np99\ncnode\n")
+	if len(owners) != 2 || owners[0] != (PortOwner{PID: 4242, Command: "Python"}) || owners[1].PID != 99 {
+		t.Fatalf("unexpected lsof parse: %+v", owners)
+	}
+	if owners := parseLsofListeners(""); len(owners) != 0 {
+		t.Fatalf("expected no owners, got %+v", owners)
+	}
+}
+
+func TestFindPortOwner(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen failed: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+
+	owner := FindPortOwner("127.0.0.1", port)
+	if owner == nil {
+		t.Fatal("expected the listening port to have an owner")
+	}
+	if owner.PID != 0 && owner.PID != os.Getpid() {
+		t.Fatalf("expected this test process to own the port, got %+v", owner)
+	}
+	if free := FindFreePort("127.0.0.1", port); free <= port {
+		t.Fatalf("expected a free port after %d, got %d", port, free)
+	}
+
+	_ = listener.Close()
+	if owner := FindPortOwner("127.0.0.1", port); owner != nil {
+		t.Fatalf("expected the port to be free after closing, got %+v", owner)
+	}
+}
+
+func TestServerProfiles(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(root+"/mysite", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(root+"/mysite/asgi.py", []byte("application = None\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := &Project{
+		RootDir:         root,
+		SettingsModule:  "mysite.settings",
+		WSGIApplication: "mysite.wsgi.application",
+		InstalledApps:   []string{"django_extensions"},
+	}
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorPython}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+
+	var names []string
+	for _, profile := range project.ServerProfiles() {
+		names = append(names, profile.Name)
+	}
+	if strings.Join(names, ",") != "runserver,runserver_plus,gunicorn,uvicorn,daphne" {
+		t.Fatalf("unexpected built-in profiles %v", names)
+	}
+	if app := project.ApplicationModule("asgi"); app != "mysite.asgi:application" {
+		t.Fatalf("expected asgi module from mysite/asgi.py, got %q", app)
+	}
+
+	project.Server = config.ServerConfig{
+		Default: "api",
+		Profiles: []config.ServerProfile{
+			{Name: "api", Runner: RunnerUvicorn, Port: 9000, Args: []string{"--workers", "2"}, Env: map[string]string{"DEBUG": "1"}},
+		},
+	}
+	profile, ok := project.ServerProfile("")
+	if !ok || profile.Name != "api" {
+		t.Fatalf("expected the configured default profile, got %+v", profile)
+	}
+	host, port := project.ServerProfileAddress(profile)
+	cmd, err := project.ServerCommand(profile, host, port)
+	if err != nil {
+		t.Fatalf("ServerCommand failed: %v", err)
+	}
+	args := strings.Join(cmd.Args[1:], " ")
+	if args != "-m uvicorn mysite.asgi:application --host 127.0.0.1 --port 9000 --reload --workers 2" {
+		t.Fatalf("unexpected uvicorn args %q", args)
+	}
+	if !slices.Contains(cmd.Env, "DEBUG=1") {
+		t.Fatal("expected profile env on the command")
+	}
+
+	noReload := false
+	cmd, err = project.ServerCommand(config.ServerProfile{Runner: RunnerRunserverPlus, Reload: &noReload}, "127.0.0.1", 8000)
+	if err != nil {
+		t.Fatalf("ServerCommand failed: %v", err)
+	}
+	if args := strings.Join(cmd.Args[2:], " "); args != "runserver_plus 127.0.0.1:8000 --noreload" {
+		t.Fatalf("unexpected runserver_plus args %q", args)
+	}
+	if _, err := project.ServerCommand(config.ServerProfile{Runner: "waitress"}, "127.0.0.1", 8000); err == nil {
+		t.Fatal("expected an error for an unknown runner")
+	}
+}
+
+func TestSettingsStringAssignment(t *testing.T) {
+	if value, ok := settingsStringAssignment(`WSGI_APPLICATION = "mysite.wsgi.application"`, "WSGI_APPLICATION"); !ok || value != "mysite.wsgi.application" {
+		t.Fatalf("unexpected assignment parse %q %v", value, ok)
+	}
+	if _, ok := settingsStringAssignment(`WSGI_APPLICATION_X = "x"`, "WSGI_APPLICATION"); ok {
+		t.Fatal("did not expect a prefix match")
+	}
+}
@@ -1,6 +1,7 @@
 package django
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -9,6 +10,8 @@ import (
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
 )
 
 // Snapshot represents a database snapshot with metadata
@@ -28,6 +31,7 @@ type Snapshot struct {
 type SnapshotManager struct {
 	project      *Project
 	snapshotsDir string
+	ctx          context.Context
 }
 
 func shouldUseDjangoDumpFallback(engine string, hasDocker bool, hasCmd func(string) bool) bool {
@@ -70,8 +74,39 @@ func NewSnapshotManager(project *Project) *SnapshotManager {
 	}
 }
 
-// CreateSnapshot creates a new database snapshot
+// WithContext returns a copy of the manager whose commands are cancelled with ctx
+func (sm *SnapshotManager) WithContext(ctx context.Context) *SnapshotManager {
+	next := *sm
+	next.ctx = ctx
+	return &next
+}
+
+func (sm *SnapshotManager) operationContext() context.Context {
+	if sm.ctx == nil {
+		return context.Background()
+	}
+	return sm.ctx
+}
+
+// command builds an external command that is killed, with its process group, when the operation ends
+func (sm *SnapshotManager) command(name string, args ...string) *exec.Cmd {
+	return CommandContext(sm.operationContext(), name, args...)
+}
+
+// runManage runs a manage.py command within the current operation
+func (sm *SnapshotManager) runManage(args ...string) (string, error) {
+	return sm.project.RunCommandContext(sm.operationContext(), args...)
+}
+
+// CreateSnapshot creates a new database snapshot, bounded by the snapshot timeout
 func (sm *SnapshotManager) CreateSnapshot(name string) (*Snapshot, error) {
+	ctx, cancel := sm.project.OperationContext(sm.operationContext(), config.TimeoutSnapshot)
+	defer cancel()
+	snapshot, err := sm.WithContext(ctx).createSnapshot(name)
+	return snapshot, contextError(ctx, err)
+}
+
+func (sm *SnapshotManager) createSnapshot(name string) (*Snapshot, error) {
 	now := time.Now().UTC()
 	if name == "" {
 		name = fmt.Sprintf("snapshot-%s", now.Format("20060102-150405"))
@@ -201,7 +236,7 @@ func (sm *SnapshotManager) runDBCommand(dbType, cmd string, args []string, envVa
 		return sm.runDockerDBCommand(containerName, cmd, args, envVars, outputFile)
 	}
 
-	command := exec.Command(cmd, args...)
+	command := sm.command(cmd, args...)
 	command.Env = append(os.Environ(), envVars...)
 	output, err := command.CombinedOutput()
 	if err != nil {
@@ -219,7 +254,7 @@ func (sm *SnapshotManager) runDockerDBCommand(container, cmd string, args []stri
 	dockerArgs = append(dockerArgs, container, cmd)
 	dockerArgs = append(dockerArgs, args...)
 
-	command := exec.Command("docker", dockerArgs...)
+	command := sm.command("docker", dockerArgs...)
 	output, err := command.CombinedOutput()
 	if err != nil {
 		return fmt.Errorf("docker %s failed: %s - %w", cmd, string(output), err)
@@ -306,7 +341,7 @@ func (sm *SnapshotManager) dumpSQLite(outputFile string) error {
 
 // dumpDjangoData uses Django's dumpdata (universal fallback)
 func (sm *SnapshotManager) dumpDjangoData(outputFile string) error {
-	output, err := sm.project.RunCommand("dumpdata", "--natural-foreign", "--natural-primary", "--indent", "2")
+	output, err := sm.runManage("dumpdata", "--natural-foreign", "--natural-primary", "--indent", "2")
 	if err != nil {
 		return fmt.Errorf("dumpdata failed: %w", err)
 	}
@@ -314,8 +349,14 @@ func (sm *SnapshotManager) dumpDjangoData(outputFile string) error {
 	return os.WriteFile(outputFile, []byte(output), 0644)
 }
 
-// RestoreSnapshot restores a database snapshot
+// RestoreSnapshot restores a database snapshot, bounded by the snapshot timeout
 func (sm *SnapshotManager) RestoreSnapshot(snapshotID string) error {
+	ctx, cancel := sm.project.OperationContext(sm.operationContext(), config.TimeoutSnapshot)
+	defer cancel()
+	return contextError(ctx, sm.WithContext(ctx).restoreSnapshot(snapshotID))
+}
+
+func (sm *SnapshotManager) restoreSnapshot(snapshotID string) error {
 	snapshot, err := sm.GetSnapshot(snapshotID)
 	if err != nil {
 		return err
@@ -359,7 +400,7 @@ func (sm *SnapshotManager) restorePostgreSQL(dumpFile string) error {
 	if sm.project.HasDocker && config.checkDocker(sm) != "" {
 		containerName := config.checkDocker(sm)
 		tmpFile := "/tmp/restore.sql"
-		copyCmd := exec.Command("docker", "cp", dumpFile, fmt.Sprintf("%s:%s", containerName, tmpFile))
+		copyCmd := sm.command("docker", "cp", dumpFile, fmt.Sprintf("%s:%s", containerName, tmpFile))
 		if err := copyCmd.Run(); err != nil {
 			return fmt.Errorf("failed to copy dump to container: %w", err)
 		}
@@ -380,7 +421,7 @@ func (sm *SnapshotManager) restoreMySQL(dumpFile string) error {
 	if sm.project.HasDocker && config.checkDocker(sm) != "" {
 		containerName := config.checkDocker(sm)
 		tmpFile := "/tmp/restore.sql"
-		copyCmd := exec.Command("docker", "cp", dumpFile, fmt.Sprintf("%s:%s", containerName, tmpFile))
+		copyCmd := sm.command("docker", "cp", dumpFile, fmt.Sprintf("%s:%s", containerName, tmpFile))
 		if err := copyCmd.Run(); err != nil {
 			return fmt.Errorf("failed to copy dump to container: %w", err)
 		}
@@ -396,7 +437,7 @@ func (sm *SnapshotManager) restoreMySQL(dumpFile string) error {
 	}
 
 	// MySQL restore needs stdin redirection
-	cmd := exec.Command(config.restoreCmd, args...)
+	cmd := sm.command(config.restoreCmd, args...)
 	cmd.Env = append(os.Environ(), config.envVars(pw)...)
 	if input, err := os.ReadFile(dumpFile); err == nil {
 		cmd.Stdin = strings.NewReader(string(input))
@@ -426,28 +467,19 @@ func (sm *SnapshotManager) restoreSQLite(dumpFile string) error {
 
 // restoreDjangoData restores using Django's loaddata
 func (sm *SnapshotManager) restoreDjangoData(dumpFile string) error {
-	loadPath := dumpFile
-	cleanup := func() {}
-
-	if sm.project != nil && sm.project.HasDocker && sm.project.DockerComposeFile != "" && sm.project.DockerService != "" {
-		tmpPath := fmt.Sprintf("/tmp/lazy-django-%d%s", time.Now().UnixNano(), filepath.Ext(dumpFile))
-		cpCmd := exec.Command("docker", "compose", "-f", sm.project.DockerComposeFile, "cp", dumpFile, fmt.Sprintf("%s:%s", sm.project.DockerService, tmpPath))
-		if output, err := cpCmd.CombinedOutput(); err != nil {
-			return fmt.Errorf("failed to copy snapshot into container: %s - %w", strings.TrimSpace(string(output)), err)
-		}
-		loadPath = tmpPath
-		cleanup = func() {
-			_ = exec.Command("docker", "compose", "-f", sm.project.DockerComposeFile, "exec", "-T", sm.project.DockerService, "rm", "-f", tmpPath).Run()
-		}
+	// Remote executors (docker, ssh) cannot read host paths, so stage the file first.
+	loadPath, cleanup, err := sm.project.ActiveExecutor().CopyIn(sm.operationContext(), dumpFile)
+	if err != nil {
+		return fmt.Errorf("failed to stage snapshot: %w", err)
 	}
 	defer cleanup()
 
-	_, err := sm.project.RunCommand("flush", "--no-input")
+	_, err = sm.runManage("flush", "--no-input")
 	if err != nil {
 		return fmt.Errorf("flush failed: %w", err)
 	}
 
-	_, err = sm.project.RunCommand("loaddata", loadPath)
+	_, err = sm.runManage("loaddata", loadPath)
 	if err != nil {
 		return fmt.Errorf("loaddata failed: %w", err)
 	}
@@ -529,7 +561,7 @@ func (sm *SnapshotManager) saveMetadata(snapshot *Snapshot) error {
 }
 
 func (sm *SnapshotManager) getCurrentBranch() string {
-	cmd := exec.Command("git", "-C", sm.project.RootDir, "rev-parse", "--abbrev-ref", "HEAD")
+	cmd := sm.command("git", "-C", sm.project.RootDir, "rev-parse", "--abbrev-ref", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -538,7 +570,7 @@ func (sm *SnapshotManager) getCurrentBranch() string {
 }
 
 func (sm *SnapshotManager) getCurrentCommit() string {
-	cmd := exec.Command("git", "-C", sm.project.RootDir, "rev-parse", "HEAD")
+	cmd := sm.command("git", "-C", sm.project.RootDir, "rev-parse", "HEAD")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -547,7 +579,7 @@ func (sm *SnapshotManager) getCurrentCommit() string {
 }
 
 func (sm *SnapshotManager) getAppliedMigrations() ([]string, error) {
-	output, err := sm.project.RunCommand("showmigrations", "--plan")
+	output, err := sm.runManage("showmigrations", "--plan")
 	if err != nil {
 		return nil, err
 	}
@@ -571,7 +603,7 @@ func (sm *SnapshotManager) syncMigrations(targetMigrations []string) error {
 		return nil // Ignore errors in test environments
 	}
 
-	_, err = sm.project.RunCommand("migrate", "--fake")
+	_, err = sm.runManage("migrate", "--fake")
 	return nil // Non-critical error
 }
 
@@ -579,10 +611,11 @@ func (sm *SnapshotManager) syncMigrations(targetMigrations []string) error {
 func (sm *SnapshotManager) getContainerName(serviceName, imageName string) string {
 	// Try compose service first
 	if sm.project.DockerComposeFile != "" {
-		cmd := exec.Command("docker", "compose", "-f", sm.project.DockerComposeFile, "ps", "-q", serviceName)
+		args := append([]string{"compose"}, sm.project.ComposeFileArgs()...)
+		cmd := sm.command("docker", append(args, "ps", "-q", serviceName)...)
 		if output, err := cmd.Output(); err == nil && len(output) > 0 {
 			containerID := strings.TrimSpace(string(output))
-			nameCmd := exec.Command("docker", "inspect", "--format", "{{.Name}}", containerID)
+			nameCmd := sm.command("docker", "inspect", "--format", "{{.Name}}", containerID)
 			if nameOutput, err := nameCmd.Output(); err == nil {
 				return strings.TrimSpace(strings.TrimPrefix(string(nameOutput), "/"))
 			}
@@ -590,7 +623,7 @@ func (sm *SnapshotManager) getContainerName(serviceName, imageName string) strin
 	}
 
 	// Fallback: search by image
-	cmd := exec.Command("docker", "ps", "--filter", fmt.Sprintf("ancestor=%s", imageName), "--format", "{{.Names}}")
+	cmd := sm.command("docker", "ps", "--filter", fmt.Sprintf("ancestor=%s", imageName), "--format", "{{.Names}}")
 	output, err := cmd.Output()
 	if err != nil {
 		return ""
@@ -619,6 +652,11 @@ func (sm *SnapshotManager) getDatabasePassword() string {
 		}
 	}
 
+	// Discovery may have found the password in DATABASE_URL or compose DB_* variables.
+	if sm.project != nil && sm.project.Database.Password != "" {
+		return sm.project.Database.Password
+	}
+
 	// For Docker projects, prefer password values from running database containers.
 	if sm.project != nil && sm.project.HasDocker {
 		if pw := sm.getDatabasePasswordFromContainer(); pw != "" {
@@ -627,8 +665,8 @@ func (sm *SnapshotManager) getDatabasePassword() string {
 	}
 
 	// Try compose/.env extracted values.
-	if sm.project != nil && sm.project.DockerComposeFile != "" {
-		env := extractEnvFromCompose(sm.project.DockerComposeFile)
+	if sm.project != nil && sm.project.Compose != nil {
+		env := sm.project.Compose.Environment(sm.project.DockerService)
 		for _, key := range []string{"DB_PASSWORD", "POSTGRES_PASSWORD", "MYSQL_PASSWORD"} {
 			if pw := strings.TrimSpace(env[key]); pw != "" {
 				return pw
@@ -638,7 +676,7 @@ func (sm *SnapshotManager) getDatabasePassword() string {
 
 	// Extract from Django settings
 	cmd := `import json; from django.conf import settings; print(settings.DATABASES['default'].get('PASSWORD', ''))`
-	output, err := sm.project.RunCommand("shell", "-c", cmd)
+	output, err := sm.runManage("shell", "-c", cmd)
 	if err == nil {
 		return lastNonEmptyLine(output)
 	}
@@ -657,7 +695,7 @@ func (sm *SnapshotManager) getDatabasePasswordFromContainer() string {
 		if container == "" {
 			continue
 		}
-		env := dockerContainerEnv(container)
+		env := dockerContainerEnv(sm.operationContext(), container)
 		for _, key := range []string{"POSTGRES_PASSWORD", "DB_PASSWORD", "MYSQL_PASSWORD"} {
 			if pw := strings.TrimSpace(env[key]); pw != "" {
 				return pw
@@ -667,14 +705,14 @@ func (sm *SnapshotManager) getDatabasePasswordFromContainer() string {
 	return ""
 }
 
-func dockerContainerEnv(container string) map[string]string {
+func dockerContainerEnv(ctx context.Context, container string) map[string]string {
 	result := make(map[string]string)
 	container = strings.TrimSpace(container)
 	if container == "" {
 		return result
 	}
 
-	cmd := exec.Command("docker", "exec", container, "env")
+	cmd := CommandContext(ctx, "docker", "exec", container, "env")
 	output, err := cmd.Output()
 	if err != nil {
 		return result
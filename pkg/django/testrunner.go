@@ -0,0 +1,366 @@
+package django
+
+import (
+	"bufio"
+	"context"
+	"encoding/xml"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Test runners
+const (
+	TestRunnerPytest = "pytest"
+	TestRunnerDjango = "django"
+)
+
+// Test outcomes
+const (
+	TestPassed  = "passed"
+	TestFailed  = "failed"
+	TestError   = "error"
+	TestSkipped = "skipped"
+)
+
+// TestReportPath is where pytest writes its JUnit report, relative to the project root. Inside a
+// container it lands in the mounted project directory, so it can be read back from the host.
+var TestReportPath = filepath.Join(".lazy-django", "test-results.xml")
+
+// TestRunOptions selects which tests to run
+type TestRunOptions struct {
+	Labels   []string // pytest node ids or manage.py test labels; empty runs everything
+	Keyword  string   // -k expression
+	Failed   bool     // pytest --lf; ignored for manage.py test, which gets the failed labels instead
+	Coverage bool     // Wrap the run in `coverage run`
+}
+
+// TestResult is the outcome of one test
+type TestResult struct {
+	ID       string // pytest node id or dotted manage.py test label, usable to rerun the test
+	Module   string // File path for pytest, dotted module for manage.py test
+	Class    string
+	Name     string
+	Status   string
+	Duration time.Duration
+	Message  string
+	Details  string // Failure or error traceback
+}
+
+// TestReport is the parsed outcome of a test run
+type TestReport struct {
+	Runner  string
+	Results []TestResult
+}
+
+// Counts returns how many tests ended in each status
+func (r *TestReport) Counts() map[string]int {
+	counts := make(map[string]int)
+	for _, result := range r.Results {
+		counts[result.Status]++
+	}
+	return counts
+}
+
+// FailedIDs returns the ids of failed and errored tests
+func (r *TestReport) FailedIDs() []string {
+	var ids []string
+	for _, result := range r.Results {
+		if result.Status == TestFailed || result.Status == TestError {
+			ids = append(ids, result.ID)
+		}
+	}
+	return ids
+}
+
+// TestRunner returns the runner used for this project: pytest when it is configured, otherwise manage.py test
+func (p *Project) TestRunner() string {
+	if p.HasPytest {
+		return TestRunnerPytest
+	}
+	return TestRunnerDjango
+}
+
+// TestCommand builds the test command for the active execution backend
+func (p *Project) TestCommand(ctx context.Context, opts TestRunOptions) *exec.Cmd {
+	var prefix []string
+	if opts.Coverage {
+		prefix = []string{"-m", "coverage", "run"}
+	}
+	if p.TestRunner() == TestRunnerPytest {
+		args := append(prefix, "-m", "pytest", "-rA", "--junitxml="+filepath.ToSlash(TestReportPath))
+		if opts.Failed {
+			args = append(args, "--lf")
+		}
+		if opts.Keyword != "" {
+			args = append(args, "-k", opts.Keyword)
+		}
+		args = append(args, opts.Labels...)
+		return p.ActiveExecutor().Python(ctx, nil, args...)
+	}
+
+	args := []string{"test", "-v", "2"}
+	if opts.Keyword != "" {
+		args = append(args, "-k", opts.Keyword)
+	}
+	args = append(args, opts.Labels...)
+	if opts.Coverage {
+		return p.ActiveExecutor().Python(ctx, nil, append(append(prefix, p.managePyArg()), args...)...)
+	}
+	return p.ActiveExecutor().Command(ctx, args...)
+}
+
+// ReadTestReport parses the results of a run that started at startedAt. pytest results come from
+// the JUnit report when it was written by this run, otherwise from the console output.
+func (p *Project) ReadTestReport(output string, startedAt time.Time) *TestReport {
+	if p.TestRunner() == TestRunnerDjango {
+		return &TestReport{Runner: TestRunnerDjango, Results: ParseDjangoTestOutput(output)}
+	}
+	path := filepath.Join(p.RootDir, TestReportPath)
+	if info, err := os.Stat(path); err == nil && !info.ModTime().Before(startedAt.Add(-time.Second)) {
+		if data, err := os.ReadFile(path); err == nil {
+			if results, err := ParseJUnitXML(data, p.RootDir); err == nil {
+				return &TestReport{Runner: TestRunnerPytest, Results: results}
+			}
+		}
+	}
+	return &TestReport{Runner: TestRunnerPytest, Results: ParsePytestSummary(output)}
+}
+
+type junitSuites struct {
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Cases  []junitCase  `xml:"testcase"`
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	File      string        `xml:"file,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitOutcome `xml:"failure"`
+	Error     *junitOutcome `xml:"error"`
+	Skipped   *junitOutcome `xml:"skipped"`
+}
+
+type junitOutcome struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ParseJUnitXML reads a pytest JUnit report. rootDir is used to turn dotted class names back
+// into file paths for node ids.
+func ParseJUnitXML(data []byte, rootDir string) ([]TestResult, error) {
+	var suites junitSuites
+	if err := xml.Unmarshal(data, &suites); err != nil || len(suites.Suites) == 0 {
+		// Reports with a single suite have no <testsuites> wrapper.
+		var suite junitSuite
+		if singleErr := xml.Unmarshal(data, &suite); singleErr != nil {
+			if err != nil {
+				return nil, err
+			}
+			return nil, singleErr
+		}
+		suites.Suites = []junitSuite{suite}
+	}
+
+	var results []TestResult
+	var walk func(junitSuite)
+	walk = func(suite junitSuite) {
+		for _, c := range suite.Cases {
+			results = append(results, junitResult(c, rootDir))
+		}
+		for _, nested := range suite.Suites {
+			walk(nested)
+		}
+	}
+	for _, suite := range suites.Suites {
+		walk(suite)
+	}
+	return results, nil
+}
+
+func junitResult(c junitCase, rootDir string) TestResult {
+	module, class := c.File, ""
+	if module == "" {
+		module, class = splitPytestClassName(c.ClassName, rootDir)
+	} else {
+		dotted := strings.TrimSuffix(strings.ReplaceAll(module, "/", "."), ".py")
+		class = strings.TrimPrefix(strings.TrimPrefix(c.ClassName, dotted), ".")
+	}
+
+	result := TestResult{Module: module, Class: class, Name: c.Name, Status: TestPassed}
+	if seconds, err := strconv.ParseFloat(c.Time, 64); err == nil {
+		result.Duration = time.Duration(seconds * float64(time.Second))
+	}
+	switch {
+	case c.Failure != nil:
+		result.Status, result.Message, result.Details = TestFailed, c.Failure.Message, strings.TrimSpace(c.Failure.Text)
+	case c.Error != nil:
+		result.Status, result.Message, result.Details = TestError, c.Error.Message, strings.TrimSpace(c.Error.Text)
+	case c.Skipped != nil:
+		result.Status, result.Message = TestSkipped, c.Skipped.Message
+	}
+
+	parts := []string{module}
+	if class != "" {
+		parts = append(parts, strings.Split(class, ".")...)
+	}
+	result.ID = strings.Join(append(parts, c.Name), "::")
+	return result
+}
+
+// splitPytestClassName splits "tests.test_models.TestPost" into the test file and class by finding
+// the longest dotted prefix that is a file under rootDir
+func splitPytestClassName(className, rootDir string) (string, string) {
+	parts := strings.Split(className, ".")
+	for i := len(parts); i > 0; i-- {
+		candidate := strings.Join(parts[:i], "/") + ".py"
+		if fileExists(filepath.Join(rootDir, candidate)) {
+			return candidate, strings.Join(parts[i:], ".")
+		}
+	}
+	// Without the sources, assume modules are lowercase and classes are not.
+	for i, part := range parts {
+		if part != "" && part[0] >= 'A' && part[0] <= 'Z' {
+			return strings.Join(parts[:i], "/") + ".py", strings.Join(parts[i:], ".")
+		}
+	}
+	return strings.Join(parts, "/") + ".py", ""
+}
+
+// pytestSummaryPattern matches `-rA` short summary lines such as
+// "FAILED tests/test_x.py::TestA::test_b - AssertionError: ..." and "SKIPPED [1] tests/test_x.py:30: reason"
+var pytestSummaryPattern = regexp.MustCompile(`^(PASSED|FAILED|ERROR|SKIPPED|XFAIL|XPASS) (?:\[\d+\] )?(\S+?)(?::\d+:)?(?: - (.*)| (.*))?$`)
+
+// ParsePytestSummary reads test outcomes from the `-rA` short test summary
+func ParsePytestSummary(output string) []TestResult {
+	var results []TestResult
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		m := pytestSummaryPattern.FindStringSubmatch(strings.TrimSpace(scanner.Text()))
+		if m == nil {
+			continue
+		}
+		status := map[string]string{
+			"PASSED": TestPassed, "XPASS": TestPassed,
+			"FAILED": TestFailed, "ERROR": TestError,
+			"SKIPPED": TestSkipped, "XFAIL": TestSkipped,
+		}[m[1]]
+		parts := strings.Split(m[2], "::")
+		result := TestResult{ID: m[2], Module: parts[0], Name: parts[len(parts)-1], Status: status, Message: m[3] + m[4]}
+		if len(parts) > 2 {
+			result.Class = strings.Join(parts[1:len(parts)-1], ".")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// djangoTestLinePattern matches verbose runner lines: "test_slug (blog.tests.PostTests.test_slug) ... ok".
+// Django before 5.0 prints the class path without the method name.
+var djangoTestLinePattern = regexp.MustCompile(`^(\w+) \(([\w.]+)\)(?:\n?.*?)? \.\.\. (ok|FAIL|ERROR|skipped.*|expected failure|unexpected success)$`)
+
+// djangoFailureHeader matches "FAIL: test_title (blog.tests.PostTests.test_title)"
+var djangoFailureHeader = regexp.MustCompile(`^(FAIL|ERROR): (\w+) \(([\w.]+)\)`)
+
+// ParseDjangoTestOutput reads `manage.py test -v 2` output
+func ParseDjangoTestOutput(output string) []TestResult {
+	lines := strings.Split(strings.ReplaceAll(output, "\r\n", "\n"), "\n")
+	byID := make(map[string]int)
+	var results []TestResult
+
+	for _, line := range lines {
+		m := djangoTestLinePattern.FindStringSubmatch(strings.TrimSpace(line))
+		if m == nil {
+			continue
+		}
+		id, module, class := djangoTestID(m[1], m[2])
+		result := TestResult{ID: id, Module: module, Class: class, Name: m[1]}
+		switch {
+		case m[3] == "ok" || m[3] == "unexpected success":
+			result.Status = TestPassed
+		case m[3] == "FAIL":
+			result.Status = TestFailed
+		case m[3] == "ERROR":
+			result.Status = TestError
+		default:
+			result.Status = TestSkipped
+			result.Message = strings.Trim(strings.TrimPrefix(m[3], "skipped"), " '\"")
+		}
+		byID[id] = len(results)
+		results = append(results, result)
+	}
+
+	// Failure sections: a ==== rule, the header, a ---- rule, then the traceback up to the next rule.
+	for i := 0; i < len(lines); i++ {
+		m := djangoFailureHeader.FindStringSubmatch(lines[i])
+		if m == nil {
+			continue
+		}
+		id, module, class := djangoTestID(m[2], m[3])
+		j := i + 1
+		if j < len(lines) && strings.HasPrefix(lines[j], "---") {
+			j++
+		}
+		start := j
+		for j < len(lines) && !strings.HasPrefix(lines[j], "=====") && !strings.HasPrefix(lines[j], "-----") {
+			j++
+		}
+		details := strings.TrimSpace(strings.Join(lines[start:j], "\n"))
+		idx, ok := byID[id]
+		if !ok {
+			status := TestFailed
+			if m[1] == "ERROR" {
+				status = TestError
+			}
+			byID[id] = len(results)
+			results = append(results, TestResult{ID: id, Module: module, Class: class, Name: m[2], Status: status})
+			idx = len(results) - 1
+		}
+		results[idx].Details = details
+		if lines := strings.Split(details, "\n"); details != "" {
+			results[idx].Message = strings.TrimSpace(lines[len(lines)-1])
+		}
+		i = j - 1
+	}
+	return results
+}
+
+// djangoTestID normalizes both the Django 5 "mod.Class.method" and older "mod.Class" forms
+func djangoTestID(method, path string) (id, module, class string) {
+	if !strings.HasSuffix(path, "."+method) {
+		path += "." + method
+	}
+	parts := strings.Split(strings.TrimSuffix(path, "."+method), ".")
+	if len(parts) > 1 {
+		module = strings.Join(parts[:len(parts)-1], ".")
+		class = parts[len(parts)-1]
+	} else {
+		module = parts[0]
+	}
+	return path, module, class
+}
+
+// SortTestResults orders results by module, class and name for tree display
+func SortTestResults(results []TestResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.Module != b.Module {
+			return a.Module < b.Module
+		}
+		if a.Class != b.Class {
+			return a.Class < b.Class
+		}
+		return a.Name < b.Name
+	})
+}
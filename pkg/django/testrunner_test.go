@@ -0,0 +1,118 @@
+package django
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseJUnitXML(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "blog", "tests"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "blog", "tests", "test_models.py"), []byte("\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	report := `<?xml version="1.0" encoding="utf-8"?>
+<testsuites><testsuite name="pytest" tests="3">
+  <testcase classname="blog.tests.test_models.TestPost" name="test_slug" time="0.012"/>
+  <testcase classname="blog.tests.test_models.TestPost" name="test_title" time="0.5">
+    <failure message="AssertionError: assert 'a' == 'b'">blog/tests/test_models.py:12: AssertionError</failure>
+  </testcase>
+  <testcase classname="blog.tests.test_models" name="test_feed" time="0">
+    <skipped type="pytest.skip" message="needs redis"/>
+  </testcase>
+</testsuite></testsuites>`
+	results, err := ParseJUnitXML([]byte(report), root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("expected three results, got %+v", results)
+	}
+	failed := results[1]
+	if failed.ID != "blog/tests/test_models.py::TestPost::test_title" || failed.Status != TestFailed || failed.Class != "TestPost" {
+		t.Fatalf("unexpected failed result %+v", failed)
+	}
+	if failed.Duration != 500*time.Millisecond || !strings.Contains(failed.Details, "AssertionError") {
+		t.Fatalf("unexpected failure details %+v", failed)
+	}
+	if results[2].ID != "blog/tests/test_models.py::test_feed" || results[2].Status != TestSkipped || results[2].Message != "needs redis" {
+		t.Fatalf("unexpected skipped result %+v", results[2])
+	}
+}
+
+func TestParsePytestSummary(t *testing.T) {
+	output := strings.Join([]string{
+		"=========================== short test summary info ============================",
+		"PASSED tests/test_api.py::test_list",
+		"FAILED tests/test_api.py::TestDetail::test_get - assert 404 == 200",
+		"SKIPPED [1] tests/test_api.py:30: needs network",
+		"ERROR tests/test_db.py::test_conn - django.db.utils.OperationalError",
+	}, "\n")
+	results := ParsePytestSummary(output)
+	if len(results) != 4 {
+		t.Fatalf("expected four results, got %+v", results)
+	}
+	if results[1].Class != "TestDetail" || results[1].Name != "test_get" || results[1].Message != "assert 404 == 200" {
+		t.Fatalf("unexpected failed result %+v", results[1])
+	}
+	report := &TestReport{Results: results}
+	if got := report.FailedIDs(); !slices.Equal(got, []string{"tests/test_api.py::TestDetail::test_get", "tests/test_db.py::test_conn"}) {
+		t.Fatalf("unexpected failed ids %v", got)
+	}
+}
+
+func TestParseDjangoTestOutput(t *testing.T) {
+	output := strings.Join([]string{
+		"test_slug (blog.tests.PostTests.test_slug) ... ok",
+		"test_title (blog.tests.PostTests) ... FAIL",
+		"test_feed (blog.tests.FeedTests.test_feed) ... skipped 'needs redis'",
+		"",
+		"======================================================================",
+		"FAIL: test_title (blog.tests.PostTests.test_title)",
+		"----------------------------------------------------------------------",
+		"Traceback (most recent call last):",
+		`  File "/app/blog/tests.py", line 12, in test_title`,
+		"    self.assertEqual(post.title, \"b\")",
+		"AssertionError: 'a' != 'b'",
+		"",
+		"----------------------------------------------------------------------",
+		"Ran 3 tests in 0.020s",
+	}, "\n")
+	results := ParseDjangoTestOutput(output)
+	if len(results) != 3 {
+		t.Fatalf("expected three results, got %+v", results)
+	}
+	failed := results[1]
+	if failed.ID != "blog.tests.PostTests.test_title" || failed.Module != "blog.tests" || failed.Class != "PostTests" || failed.Status != TestFailed {
+		t.Fatalf("unexpected failed result %+v", failed)
+	}
+	if failed.Message != "AssertionError: 'a' != 'b'" || !strings.Contains(failed.Details, `File "/app/blog/tests.py", line 12`) {
+		t.Fatalf("unexpected failure details %+v", failed)
+	}
+	if results[2].Status != TestSkipped || results[2].Message != "needs redis" {
+		t.Fatalf("unexpected skipped result %+v", results[2])
+	}
+}
+
+func TestTestCommand(t *testing.T) {
+	project := &Project{RootDir: t.TempDir(), ManagePyPath: "manage.py", HasPytest: true}
+	cmd := project.TestCommand(context.Background(), TestRunOptions{Failed: true, Keyword: "slug"})
+	args := strings.Join(cmd.Args, " ")
+	if !strings.Contains(args, "-m pytest -rA --junitxml=.lazy-django/test-results.xml --lf -k slug") {
+		t.Fatalf("unexpected pytest command %q", args)
+	}
+
+	project.HasPytest = false
+	cmd = project.TestCommand(context.Background(), TestRunOptions{Labels: []string{"blog.tests.PostTests.test_title"}})
+	if args := strings.Join(cmd.Args, " "); !strings.HasSuffix(args, "manage.py test -v 2 blog.tests.PostTests.test_title") {
+		t.Fatalf("unexpected manage.py command %q", args)
+	}
+}
@@ -0,0 +1,121 @@
+package django
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// TracebackFrame is one `File "...", line N, in func` line of a Python traceback
+type TracebackFrame struct {
+	Path     string // As printed, which may be a container or remote path
+	Line     int
+	Function string
+	Index    int // Line index within the searched text
+}
+
+var tracebackFramePattern = regexp.MustCompile(`File "([^"]+)", line (\d+)(?:, in (\S+))?`)
+
+// pytestLocationPattern matches pytest's failure locations: "tests/test_models.py:42: AssertionError"
+var pytestLocationPattern = regexp.MustCompile(`^(\S+\.py):(\d+): `)
+
+// ParseTracebackFrames finds traceback frames in output lines, in order
+func ParseTracebackFrames(lines []string) []TracebackFrame {
+	var frames []TracebackFrame
+	for i, line := range lines {
+		var m []string
+		if strings.Contains(line, `File "`) {
+			m = tracebackFramePattern.FindStringSubmatch(line)
+		} else if strings.Contains(line, ".py:") {
+			if loc := pytestLocationPattern.FindStringSubmatch(line); loc != nil {
+				m = append(loc, "")
+			}
+		}
+		if m == nil {
+			continue
+		}
+		lineNo, _ := strconv.Atoi(m[2])
+		frames = append(frames, TracebackFrame{Path: m[1], Line: lineNo, Function: m[3], Index: i})
+	}
+	return frames
+}
+
+// IsLibraryPath reports whether a frame comes from installed packages or the standard library
+func IsLibraryPath(path string) bool {
+	return strings.Contains(path, "/site-packages/") || strings.Contains(path, "/dist-packages/") ||
+		strings.Contains(path, "/lib/python") || strings.HasPrefix(path, "<")
+}
+
+// HostPath translates a path printed by manage.py into a path under RootDir. Container paths go
+// through the Django service's bind mounts and ssh paths through the configured remote dir.
+// ok is false when the file does not exist on this machine.
+func (p *Project) HostPath(path string) (string, bool) {
+	candidates := []string{path}
+	if !filepath.IsAbs(path) {
+		candidates = []string{filepath.Join(p.RootDir, path)}
+	}
+	if p.Compose != nil {
+		if service, found := p.Compose.Service(p.DockerService); found {
+			if mapped, ok := mountedHostPath(service.Mounts, path); ok {
+				candidates = append([]string{mapped}, candidates...)
+			}
+		}
+	}
+	if remote, ok := p.ActiveExecutor().(*sshExecutor); ok && remote.dir != "" {
+		if rel, ok := pathWithin(remote.dir, path); ok {
+			candidates = append([]string{filepath.Join(p.RootDir, rel)}, candidates...)
+		}
+	}
+	for _, candidate := range candidates {
+		if fileExists(candidate) {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+// mountedHostPath maps a container path through the longest matching bind mount target
+func mountedHostPath(mounts []ComposeMount, path string) (string, bool) {
+	best := -1
+	var mapped string
+	for _, mount := range mounts {
+		rel, ok := pathWithin(mount.Target, path)
+		if !ok || len(mount.Target) <= best {
+			continue
+		}
+		best = len(mount.Target)
+		mapped = filepath.Join(mount.Source, rel)
+	}
+	return mapped, best >= 0
+}
+
+// mountedContainerPath maps a host path through the longest matching bind mount source,
+// the reverse of mountedHostPath
+func mountedContainerPath(mounts []ComposeMount, hostPath string) (string, bool) {
+	best := -1
+	var mapped string
+	for _, mount := range mounts {
+		rel, ok := pathWithin(mount.Source, hostPath)
+		if !ok || len(mount.Source) <= best {
+			continue
+		}
+		best = len(mount.Source)
+		mapped = path.Join(mount.Target, rel)
+	}
+	return mapped, best >= 0
+}
+
+// pathWithin returns path relative to dir when path is dir or below it
+func pathWithin(dir, path string) (string, bool) {
+	dir = strings.TrimSuffix(filepath.ToSlash(dir), "/")
+	path = filepath.ToSlash(path)
+	if path == dir {
+		return ".", true
+	}
+	if dir == "" || !strings.HasPrefix(path, dir+"/") {
+		return "", false
+	}
+	return path[len(dir)+1:], true
+}
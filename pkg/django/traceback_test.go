@@ -0,0 +1,84 @@
+package django
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+)
+
+func TestParseTracebackFrames(t *testing.T) {
+	frames := ParseTracebackFrames([]string{
+		"Traceback (most recent call last):",
+		`  File "/usr/local/lib/python3.12/site-packages/django/core/handlers/exception.py", line 55, in inner`,
+		"    response = get_response(request)",
+		`  File "/app/blog/views.py", line 42, in detail`,
+		"    raise Http404",
+		"django.http.response.Http404",
+	})
+	if len(frames) != 2 {
+		t.Fatalf("expected two frames, got %+v", frames)
+	}
+	if frames[1] != (TracebackFrame{Path: "/app/blog/views.py", Line: 42, Function: "detail", Index: 3}) {
+		t.Fatalf("unexpected frame %+v", frames[1])
+	}
+	if !IsLibraryPath(frames[0].Path) || IsLibraryPath(frames[1].Path) {
+		t.Fatal("expected only the site-packages frame to be a library frame")
+	}
+}
+
+func TestHostPath(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "src", "blog"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "src", "blog", "views.py"), []byte("\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	project := &Project{
+		RootDir:       root,
+		DockerService: "web",
+		Compose: &ComposeProject{Services: []ComposeService{{
+			Name: "web",
+			Mounts: []ComposeMount{
+				{Source: root, Target: "/app"},
+				{Source: filepath.Join(root, "src"), Target: "/app/code"},
+			},
+		}}},
+	}
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorPython}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+	want := filepath.Join(root, "src", "blog", "views.py")
+	if path, ok := project.HostPath("/app/code/blog/views.py"); !ok || path != want {
+		t.Fatalf("expected the longest mount to win, got %q %v", path, ok)
+	}
+	if path, ok := project.HostPath("src/blog/views.py"); !ok || path != want {
+		t.Fatalf("expected relative paths under the root, got %q %v", path, ok)
+	}
+	if _, ok := project.HostPath("/app/missing.py"); ok {
+		t.Fatal("did not expect a missing file to resolve")
+	}
+
+	if err := project.ConfigureExecutor(config.ExecutorConfig{Backend: ExecutorSSH, Host: "dev@box", Dir: "/srv/site"}); err != nil {
+		t.Fatalf("ConfigureExecutor failed: %v", err)
+	}
+	if path, ok := project.HostPath("/srv/site/src/blog/views.py"); !ok || path != want {
+		t.Fatalf("expected the ssh dir to map to the root, got %q %v", path, ok)
+	}
+}
+
+func TestParseTracebackFramesPytestLocations(t *testing.T) {
+	frames := ParseTracebackFrames([]string{
+		"    def test_title(post):",
+		">       assert post.title == \"b\"",
+		"E       AssertionError",
+		"",
+		"blog/tests/test_models.py:12: AssertionError",
+	})
+	if len(frames) != 1 || frames[0] != (TracebackFrame{Path: "blog/tests/test_models.py", Line: 12, Index: 4}) {
+		t.Fatalf("unexpected frames %+v", frames)
+	}
+}
@@ -0,0 +1,250 @@
+package django
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// URLRoute is one endpoint of the URLconf, in resolution order
+type URLRoute struct {
+	Path       string   `json:"path"` // Full pattern including include() prefixes, with a leading "/"
+	Regex      bool     `json:"regex"`
+	Name       string   `json:"name"` // Reverse name including namespaces, e.g. "blog:detail"
+	View       string   `json:"view"` // Dotted path of the view function or class
+	Methods    []string `json:"methods"`
+	Decorators []string `json:"decorators"`
+	File       string   `json:"file"`
+	Line       int      `json:"line"`
+	Issues     []string `json:"-"` // Shadowed patterns and duplicate names, see DetectRouteConflicts
+}
+
+const urlRoutesScript = `
+import inspect, json
+from django.urls import get_resolver
+from django.urls.resolvers import URLPattern, URLResolver, RegexPattern
+
+# Python < 3.11 has no co_qualname, so Django's decorators are recognised by where their code lives.
+_OWNERS = [
+    ('contrib/auth/decorators.py', '<lambda>', 'login_required'),
+    ('contrib/auth/decorators.py', 'check_perms', 'permission_required'),
+    ('contrib/auth/decorators.py', '', 'user_passes_test'),
+    ('contrib/admin/views/decorators.py', '<lambda>', 'staff_member_required'),
+    ('views/decorators/http.py', 'inner', 'require_http_methods'),
+    ('utils/decorators.py', '', 'make_middleware_decorator'),
+]
+
+def _code_owner(fn):
+    code = getattr(fn, '__code__', None)
+    if code is None:
+        return ''
+    qualname = getattr(code, 'co_qualname', None)
+    if qualname:
+        return qualname.split('.')[0]
+    filename = code.co_filename.replace('\\', '/')
+    for suffix, name, owner in _OWNERS:
+        if filename.endswith(suffix) and name in ('', code.co_name):
+            return owner
+    return code.co_name
+
+def _closure(fn):
+    return [cell.cell_contents for cell in (getattr(fn, '__closure__', None) or ()) if _filled(cell)]
+
+def _filled(cell):
+    try:
+        cell.cell_contents
+        return True
+    except ValueError:
+        return False
+
+def _decorators(callback, view_class):
+    found, methods = [], []
+    if getattr(callback, 'csrf_exempt', False):
+        found.append('csrf_exempt')
+    layer = callback
+    while layer is not None and getattr(layer, '__wrapped__', None) is not None:
+        if view_class is not None and layer.__wrapped__ is view_class:
+            # Django < 4.0 as_view() wraps the class itself.
+            break
+        owner = _code_owner(layer)
+        values = _closure(layer)
+        if owner == 'user_passes_test':
+            tests = [_code_owner(v) for v in values if callable(v) and _code_owner(v)]
+            owner = next((t for t in tests if t != 'user_passes_test'), owner)
+        elif owner == 'require_http_methods':
+            methods = next((list(v) for v in values if isinstance(v, (list, tuple))), methods)
+        elif owner == 'make_middleware_decorator':
+            owner = next((type(v).__name__ for v in values if type(v).__name__.endswith('Middleware')), owner)
+        if owner and owner not in found:
+            found.append(owner)
+        layer = layer.__wrapped__
+    if view_class is not None:
+        for base in view_class.__mro__:
+            if base.__module__.startswith('django.contrib.auth') and base.__name__.endswith('Mixin'):
+                found.append(base.__name__)
+        for permission in getattr(view_class, 'permission_classes', ()) or ():
+            found.append(getattr(permission, '__name__', type(permission).__name__))
+    return found, methods
+
+def _methods(callback, view_class):
+    actions = getattr(callback, 'actions', None)
+    if actions:
+        return sorted(method.upper() for method in actions)
+    if view_class is not None:
+        names = getattr(view_class, 'http_method_names', ())
+        return [m.upper() for m in names if m not in ('options', 'head') and hasattr(view_class, m)]
+    return []
+
+def _describe(callback):
+    view_class = getattr(callback, 'view_class', None) or getattr(callback, 'cls', None)
+    target = view_class or inspect.unwrap(callback)
+    name = getattr(target, '__qualname__', None) or type(target).__name__
+    view = f"{getattr(target, '__module__', '') or ''}.{name}".strip('.')
+    try:
+        source = inspect.getsourcefile(target) or ''
+        line = inspect.getsourcelines(target)[1]
+    except (TypeError, OSError):
+        source, line = '', 0
+    decorators, methods = _decorators(callback, view_class)
+    return view, methods or _methods(callback, view_class), decorators, source, line
+
+def _walk(patterns, prefix, namespace, regex, routes):
+    for pattern in patterns:
+        part = str(pattern.pattern)
+        is_regex = regex or isinstance(pattern.pattern, RegexPattern)
+        if isinstance(pattern.pattern, RegexPattern):
+            part = part.lstrip('^')
+        if isinstance(pattern, URLResolver):
+            ns = namespace
+            if pattern.namespace:
+                ns = namespace + [pattern.namespace]
+            _walk(pattern.url_patterns, prefix + part.rstrip('$'), ns, is_regex, routes)
+        elif isinstance(pattern, URLPattern):
+            view, methods, decorators, source, line = _describe(pattern.callback)
+            name = ':'.join(namespace + [pattern.name]) if pattern.name else ''
+            routes.append({'path': '/' + prefix + part, 'regex': is_regex, 'name': name, 'view': view,
+                           'methods': methods, 'decorators': decorators, 'file': source, 'line': line})
+
+routes = []
+_walk(get_resolver().url_patterns, '', [], False, routes)
+print(json.dumps(routes))
+`
+
+// GetURLRoutes lists every URL pattern with its reverse name, view, methods and decorators
+func (p *Project) GetURLRoutes() ([]URLRoute, error) {
+	output, err := p.runDiscoveryCommand("shell", "-c", urlRoutesScript)
+	if err != nil {
+		if out := strings.TrimSpace(output); out != "" {
+			return nil, fmt.Errorf("%w: %s", err, lastNonEmptyLine(out))
+		}
+		return nil, err
+	}
+	payload, err := extractJSONPayload(output)
+	if err != nil {
+		return nil, err
+	}
+	var routes []URLRoute
+	if err := json.Unmarshal([]byte(payload), &routes); err != nil {
+		return nil, fmt.Errorf("failed to parse URL routes: %w", err)
+	}
+	DetectRouteConflicts(routes)
+	return routes, nil
+}
+
+// DetectRouteConflicts records duplicate reverse names and patterns that can never match because
+// an earlier pattern matches the same paths first
+func DetectRouteConflicts(routes []URLRoute) {
+	names := make(map[string][]int)
+	for i := range routes {
+		routes[i].Issues = nil
+		if routes[i].Name != "" {
+			names[routes[i].Name] = append(names[routes[i].Name], i)
+		}
+	}
+	for i := range routes {
+		for j := 0; j < i; j++ {
+			if routeShadows(routes[j], routes[i]) {
+				routes[i].Issues = append(routes[i].Issues, "shadowed by "+routes[j].Path)
+				break
+			}
+		}
+		if indexes := names[routes[i].Name]; len(indexes) > 1 {
+			var others []string
+			for _, other := range indexes {
+				if other != i {
+					others = append(others, routes[other].Path)
+				}
+			}
+			routes[i].Issues = append(routes[i].Issues, fmt.Sprintf("name %q also used by %s", routes[i].Name, strings.Join(others, ", ")))
+		}
+	}
+}
+
+var routeConverterPattern = regexp.MustCompile(`^<(?:(\w+):)?\w+>$`)
+
+// converterMatches reports whether a path() converter accepts a literal segment
+var converterMatches = map[string]*regexp.Regexp{
+	"str":  regexp.MustCompile(`^[^/]+$`),
+	"int":  regexp.MustCompile(`^[0-9]+$`),
+	"slug": regexp.MustCompile(`^[-a-zA-Z0-9_]+$`),
+	"uuid": regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`),
+}
+
+// routeShadows reports whether every path matched by later is matched by earlier. Regex
+// patterns are only compared for equality.
+func routeShadows(earlier, later URLRoute) bool {
+	if earlier.Regex || later.Regex {
+		return earlier.Path == later.Path
+	}
+	a := strings.Split(earlier.Path, "/")
+	b := strings.Split(later.Path, "/")
+	for i, segment := range a {
+		converter := routeConverter(segment)
+		if converter == "path" {
+			// path: matches the rest, including slashes, but needs at least one character.
+			return i < len(b) && strings.Join(b[i:], "/") != ""
+		}
+		if i >= len(b) {
+			return false
+		}
+		if !segmentShadows(segment, converter, b[i]) {
+			return false
+		}
+	}
+	return len(a) == len(b)
+}
+
+func segmentShadows(segment, converter, other string) bool {
+	if converter == "" {
+		return segment == other
+	}
+	if otherConverter := routeConverter(other); otherConverter != "" {
+		switch converter {
+		case otherConverter:
+			return true
+		case "str":
+			return otherConverter != "path"
+		case "slug":
+			return otherConverter == "int"
+		}
+		return false
+	}
+	if pattern, ok := converterMatches[converter]; ok {
+		return pattern.MatchString(other)
+	}
+	// Custom converters: only an identical converter is known to match.
+	return false
+}
+
+// routeConverter returns the converter of a "<type:name>" segment, "str" for "<name>", or ""
+func routeConverter(segment string) string {
+	m := routeConverterPattern.FindStringSubmatch(segment)
+	if m == nil {
+		return ""
+	}
+	if m[1] == "" {
+		return "str"
+	}
+	return m[1]
+}
@@ -0,0 +1,60 @@
+package django
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDetectRouteConflicts(t *testing.T) {
+	routes := []URLRoute{
+		{Path: "/posts/<slug:slug>/", Name: "blog:detail"},
+		{Path: "/posts/new/", Name: "blog:create"},
+		{Path: "/posts/<int:pk>/edit/", Name: "blog:edit"},
+		{Path: "/posts/2024/edit/", Name: "blog:edit"},
+		{Path: "/files/<path:rest>", Name: "files"},
+		{Path: "/files/readme.txt", Name: "readme"},
+		{Path: "/posts/new-post/comments/", Name: "comments"},
+		{Path: "^legacy/$", Regex: true, Name: "legacy"},
+		{Path: "^legacy/$", Regex: true, Name: "legacy-again"},
+	}
+	DetectRouteConflicts(routes)
+
+	want := map[int]string{
+		1: "shadowed by /posts/<slug:slug>/",
+		3: "shadowed by /posts/<int:pk>/edit/",
+		5: "shadowed by /files/<path:rest>",
+		8: "shadowed by ^legacy/$",
+	}
+	for i, route := range routes {
+		issues := strings.Join(route.Issues, "; ")
+		if expected, ok := want[i]; ok {
+			if !strings.Contains(issues, expected) {
+				t.Errorf("route %s: expected %q, got %q", route.Path, expected, issues)
+			}
+		} else if strings.Contains(issues, "shadowed") {
+			t.Errorf("route %s: unexpected %q", route.Path, issues)
+		}
+	}
+	if !strings.Contains(strings.Join(routes[2].Issues, ";"), `name "blog:edit" also used by /posts/2024/edit/`) {
+		t.Fatalf("expected a duplicate name issue, got %v", routes[2].Issues)
+	}
+}
+
+func TestRouteShadowsConverters(t *testing.T) {
+	cases := []struct {
+		earlier, later string
+		want           bool
+	}{
+		{"/<str:name>/", "/<slug:slug>/", true},
+		{"/<slug:slug>/", "/<str:name>/", false},
+		{"/<int:pk>/", "/abc/", false},
+		{"/<uuid:id>/", "/<uuid:other>/", true},
+		{"/<year:year>/", "/2024/", false},
+		{"/a/", "/a/b/", false},
+	}
+	for _, c := range cases {
+		if got := routeShadows(URLRoute{Path: c.earlier}, URLRoute{Path: c.later}); got != c.want {
+			t.Errorf("routeShadows(%q, %q) = %v, want %v", c.earlier, c.later, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package gui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	projectAnalyticsDirName = "analytics"
+	analyticsTitle          = "Command Analytics"
+
+	// A command whose outcome changes this many times in a row of runs is reported as flaky
+	flakyFlipThreshold = 2
+	// Trend compares the median duration of the older and newer half of runs; smaller changes are steady
+	trendMinRuns      = 4
+	trendSteadyChange = 0.2
+)
+
+// commandStats aggregates the recorded runs of one command over the history retention window
+type commandStats struct {
+	Command      string  `json:"command"`
+	Runs         int     `json:"runs"`
+	Successes    int     `json:"successes"`
+	Failures     int     `json:"failures"`
+	Cancelled    int     `json:"cancelled"`
+	SuccessRate  float64 `json:"success_rate"`
+	P50MS        int64   `json:"p50_ms"`
+	P95MS        int64   `json:"p95_ms"`
+	Trend        string  `json:"trend"`
+	TrendPercent int     `json:"trend_percent"`
+	Flips        int     `json:"flips"`
+	Flaky        bool    `json:"flaky"`
+	FirstRun     string  `json:"first_run"`
+	LastRun      string  `json:"last_run"`
+	LastStatus   string  `json:"last_status"`
+}
+
+// computeCommandStats groups command events (oldest first) by command line. Cancelled runs count
+// as runs but not towards the success rate, durations or flips; timeouts count as failures.
+// Flaky commands come first, then the most run.
+func computeCommandStats(events []historyEvent) []commandStats {
+	type runs struct {
+		stats     commandStats
+		durations []int64
+		lastOK    string
+	}
+	byCommand := make(map[string]*runs)
+	var order []string
+	for _, event := range events {
+		if event.Type != "command" || event.Command == "" {
+			continue
+		}
+		r, ok := byCommand[event.Command]
+		if !ok {
+			r = &runs{stats: commandStats{Command: event.Command, FirstRun: event.Time}}
+			byCommand[event.Command] = r
+			order = append(order, event.Command)
+		}
+		r.stats.Runs++
+		r.stats.LastRun = event.Time
+		r.stats.LastStatus = event.Status
+		outcome := "failure"
+		switch event.Status {
+		case "cancelled":
+			r.stats.Cancelled++
+			continue
+		case "success":
+			r.stats.Successes++
+			outcome = "success"
+		default:
+			r.stats.Failures++
+		}
+		if r.lastOK != "" && r.lastOK != outcome {
+			r.stats.Flips++
+		}
+		r.lastOK = outcome
+		r.durations = append(r.durations, event.DurationMS)
+	}
+
+	stats := make([]commandStats, 0, len(order))
+	for _, command := range order {
+		r := byCommand[command]
+		s := r.stats
+		if completed := s.Successes + s.Failures; completed > 0 {
+			s.SuccessRate = float64(s.Successes) / float64(completed)
+		}
+		s.Flaky = s.Flips >= flakyFlipThreshold
+		if len(r.durations) > 0 {
+			sorted := append([]int64(nil), r.durations...)
+			sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+			s.P50MS = percentile(sorted, 50)
+			s.P95MS = percentile(sorted, 95)
+		}
+		s.Trend, s.TrendPercent = durationTrend(r.durations)
+		stats = append(stats, s)
+	}
+	sort.SliceStable(stats, func(a, b int) bool {
+		if stats[a].Flaky != stats[b].Flaky {
+			return stats[a].Flaky
+		}
+		return stats[a].Runs > stats[b].Runs
+	})
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted values
+func percentile(sorted []int64, p int) int64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// durationTrend compares the median duration of the newer half of runs with the older half
+func durationTrend(durations []int64) (string, int) {
+	if len(durations) < trendMinRuns {
+		return "-", 0
+	}
+	half := len(durations) / 2
+	older := append([]int64(nil), durations[:half]...)
+	newer := append([]int64(nil), durations[len(durations)-half:]...)
+	sort.Slice(older, func(a, b int) bool { return older[a] < older[b] })
+	sort.Slice(newer, func(a, b int) bool { return newer[a] < newer[b] })
+	before, after := percentile(older, 50), percentile(newer, 50)
+	if before <= 0 {
+		return "-", 0
+	}
+	change := float64(after-before) / float64(before)
+	percent := int(change * 100)
+	switch {
+	case change > trendSteadyChange:
+		return "slower", percent
+	case change < -trendSteadyChange:
+		return "faster", percent
+	}
+	return "steady", percent
+}
+
+// formatCommandStats renders the analytics table with the flaky commands listed again below it
+func formatCommandStats(stats []commandStats, eventCount int) string {
+	var b strings.Builder
+	runs := 0
+	var flaky []commandStats
+	for _, s := range stats {
+		runs += s.Runs
+		if s.Flaky {
+			flaky = append(flaky, s)
+		}
+	}
+	fmt.Fprintf(&b, "%d commands, %d runs across the last %d history events\n\n", len(stats), runs, eventCount)
+	if len(stats) == 0 {
+		fmt.Fprintln(&b, "No command runs recorded yet.")
+		return b.String()
+	}
+
+	header := []string{"RUNS", "OK%", "P50", "P95", "TREND", "FLIPS", "LAST", "COMMAND"}
+	rows := [][]string{header}
+	for _, s := range stats {
+		trend := s.Trend
+		if trend == "slower" || trend == "faster" {
+			trend = fmt.Sprintf("%s %+d%%", trend, s.TrendPercent)
+		}
+		flips := strconv.Itoa(s.Flips)
+		if s.Flaky {
+			flips += " flaky"
+		}
+		rows = append(rows, []string{
+			strconv.Itoa(s.Runs),
+			successRateLabel(s),
+			formatTestDuration(time.Duration(s.P50MS) * time.Millisecond),
+			formatTestDuration(time.Duration(s.P95MS) * time.Millisecond),
+			trend,
+			flips,
+			dashIfEmpty(s.LastStatus),
+			s.Command,
+		})
+	}
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for c, cell := range row {
+			widths[c] = max(widths[c], len(cell))
+		}
+	}
+	for _, row := range rows {
+		for c, cell := range row[:len(row)-1] {
+			fmt.Fprintf(&b, "%-*s  ", widths[c], cell)
+		}
+		fmt.Fprintln(&b, row[len(row)-1])
+	}
+
+	if len(flaky) > 0 {
+		fmt.Fprintf(&b, "\nFlaky (outcome changed %d+ times):\n", flakyFlipThreshold)
+		for _, s := range flaky {
+			fmt.Fprintf(&b, "- %s: %d ok / %d failed, last %s\n", s.Command, s.Successes, s.Failures, dashIfEmpty(s.LastStatus))
+		}
+	}
+	return b.String()
+}
+
+func successRateLabel(s commandStats) string {
+	if s.Successes+s.Failures == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.0f", s.SuccessRate*100)
+}
+
+// encodeCommandStats renders stats as indented JSON or as CSV with a header row
+func encodeCommandStats(stats []commandStats, format string) ([]byte, error) {
+	if format == "json" {
+		if stats == nil {
+			stats = []commandStats{}
+		}
+		data, err := json.MarshalIndent(stats, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"command", "runs", "successes", "failures", "cancelled", "success_rate", "p50_ms", "p95_ms", "trend", "trend_percent", "flips", "flaky", "first_run", "last_run", "last_status"})
+	for _, s := range stats {
+		_ = w.Write([]string{
+			s.Command,
+			strconv.Itoa(s.Runs),
+			strconv.Itoa(s.Successes),
+			strconv.Itoa(s.Failures),
+			strconv.Itoa(s.Cancelled),
+			strconv.FormatFloat(s.SuccessRate, 'f', 3, 64),
+			strconv.FormatInt(s.P50MS, 10),
+			strconv.FormatInt(s.P95MS, 10),
+			s.Trend,
+			strconv.Itoa(s.TrendPercent),
+			strconv.Itoa(s.Flips),
+			strconv.FormatBool(s.Flaky),
+			s.FirstRun,
+			s.LastRun,
+			s.LastStatus,
+		})
+	}
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+func analyticsExportPath(format string) string {
+	return filepath.Join(projectStateDirName, projectAnalyticsDirName, "commands."+format)
+}
+
+func (gui *Gui) analyticsActions() []projectAction {
+	return []projectAction{
+		{label: "Show in Output tab", internal: "analyticsshow"},
+		{label: "JSON -> " + analyticsExportPath("json"), internal: "analyticssave", value: "json"},
+		{label: "CSV -> " + analyticsExportPath("csv"), internal: "analyticssave", value: "csv"},
+	}
+}
+
+// showCommandAnalytics aggregates the history log into the Command Analytics tab, saving a
+// JSON or CSV export first when format is set
+func (gui *Gui) showCommandAnalytics(format string) error {
+	var events []historyEvent
+	var err error
+	if gui.historyStore != nil {
+		events, err = gui.historyStore.tail(maxPersistedHistoryEvents)
+	}
+	if err != nil {
+		return gui.showMessage(analyticsTitle, fmt.Sprintf("History log unavailable: %v", err))
+	}
+	stats := computeCommandStats(events)
+
+	savedPath := ""
+	if format != "" && gui.project != nil {
+		var data []byte
+		data, err = encodeCommandStats(stats, format)
+		if err == nil {
+			savedPath = filepath.Join(gui.project.RootDir, analyticsExportPath(format))
+			if err = os.MkdirAll(filepath.Dir(savedPath), 0755); err == nil {
+				err = os.WriteFile(savedPath, data, 0644)
+			}
+		}
+	}
+
+	tabID := gui.startCommandOutputTab(analyticsTitle)
+	gui.resetOutput(tabID, analyticsTitle)
+	_ = gui.switchPanel(MainWindow)
+	if err != nil {
+		gui.appendOutput(tabID, fmt.Sprintf("Export failed: %v\n\n", err))
+		gui.rememberError("analytics", err.Error())
+	} else if savedPath != "" {
+		gui.appendOutput(tabID, fmt.Sprintf("Saved %s\n\n", savedPath))
+	}
+	gui.appendOutput(tabID, formatCommandStats(stats, len(events)))
+	gui.refreshOutputView()
+	return nil
+}
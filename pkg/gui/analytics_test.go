@@ -0,0 +1,95 @@
+package gui
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func commandEvent(command, status string, durationMS int64) historyEvent {
+	return historyEvent{Time: "2026-03-01T09:00:00Z", Type: "command", Status: status, Command: command, DurationMS: durationMS}
+}
+
+func TestComputeCommandStats(t *testing.T) {
+	events := []historyEvent{
+		commandEvent("make test", "success", 1000),
+		commandEvent("python manage.py migrate", "success", 400),
+		commandEvent("make test", "error", 1100),
+		{Type: "model", Status: "ok", App: "blog", Model: "Post"},
+		commandEvent("make test", "cancelled", 50),
+		commandEvent("make test", "success", 2000),
+		commandEvent("make test", "timeout", 3000),
+		commandEvent("python manage.py migrate", "success", 500),
+	}
+	stats := computeCommandStats(events)
+	if len(stats) != 2 || stats[0].Command != "make test" {
+		t.Fatalf("expected two commands with make test first, got %+v", stats)
+	}
+	test := stats[0]
+	if test.Runs != 5 || test.Successes != 2 || test.Failures != 2 || test.Cancelled != 1 || test.SuccessRate != 0.5 {
+		t.Fatalf("unexpected counts: %+v", test)
+	}
+	if test.P50MS != 1100 || test.P95MS != 3000 {
+		t.Fatalf("unexpected percentiles: p50=%d p95=%d", test.P50MS, test.P95MS)
+	}
+	if test.Flips != 3 || !test.Flaky || test.LastStatus != "timeout" {
+		t.Fatalf("expected make test to be flaky, got %+v", test)
+	}
+	if test.Trend != "slower" || test.TrendPercent != 100 {
+		t.Fatalf("expected a slower trend, got %s %d", test.Trend, test.TrendPercent)
+	}
+	migrate := stats[1]
+	if migrate.Flaky || migrate.SuccessRate != 1 || migrate.Trend != "-" {
+		t.Fatalf("unexpected migrate stats: %+v", migrate)
+	}
+}
+
+func TestDurationTrend(t *testing.T) {
+	tests := []struct {
+		durations []int64
+		trend     string
+	}{
+		{durations: []int64{100, 100, 100}, trend: "-"},
+		{durations: []int64{100, 110, 105, 95}, trend: "steady"},
+		{durations: []int64{400, 400, 200, 200}, trend: "faster"},
+	}
+	for _, tc := range tests {
+		if trend, _ := durationTrend(tc.durations); trend != tc.trend {
+			t.Fatalf("durationTrend(%v) = %s, want %s", tc.durations, trend, tc.trend)
+		}
+	}
+}
+
+func TestEncodeCommandStats(t *testing.T) {
+	stats := computeCommandStats([]historyEvent{commandEvent("echo \"a,b\"", "success", 10)})
+	data, err := encodeCommandStats(stats, "csv")
+	if err != nil {
+		t.Fatalf("encodeCommandStats returned error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "command,runs,") || !strings.HasPrefix(lines[1], `"echo ""a,b""",1,1,0,0,1.000,10,10,-,`) {
+		t.Fatalf("unexpected csv:\n%s", data)
+	}
+
+	data, err = encodeCommandStats(nil, "json")
+	if err != nil || strings.TrimSpace(string(data)) != "[]" {
+		t.Fatalf("expected an empty JSON array, got %q (%v)", data, err)
+	}
+	data, _ = encodeCommandStats(stats, "json")
+	var decoded []commandStats
+	if err := json.Unmarshal(data, &decoded); err != nil || len(decoded) != 1 || decoded[0].P95MS != 10 {
+		t.Fatalf("unexpected json round trip: %v %+v", err, decoded)
+	}
+}
+
+func TestFormatCommandStatsListsFlakyCommands(t *testing.T) {
+	events := []historyEvent{
+		commandEvent("make test", "success", 1000),
+		commandEvent("make test", "error", 1000),
+		commandEvent("make test", "success", 1000),
+	}
+	text := formatCommandStats(computeCommandStats(events), len(events))
+	if !strings.Contains(text, "2 flaky") || !strings.Contains(text, "- make test: 2 ok / 1 failed, last success") {
+		t.Fatalf("unexpected report:\n%s", text)
+	}
+}
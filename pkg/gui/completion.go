@@ -0,0 +1,527 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const maxCommandCompletions = 50
+
+// Django's own commands, completed until `manage.py help` has been loaded
+var coreManagementCommands = []string{
+	"changepassword", "check", "collectstatic", "compilemessages", "createcachetable", "createsuperuser",
+	"dbshell", "diffsettings", "dumpdata", "findstatic", "flush", "inspectdb", "loaddata", "makemessages",
+	"makemigrations", "migrate", "optimizemigration", "remove_stale_contenttypes", "runserver",
+	"sendtestemail", "shell", "showmigrations", "sqlflush", "sqlmigrate", "sqlsequencereset",
+	"squashmigrations", "startapp", "startproject", "test", "testserver",
+}
+
+// Options every management command inherits from BaseCommand
+var baseCommandOptions = []commandCompletion{
+	{text: "--settings", hint: "settings module, e.g. myproject.settings.dev"},
+	{text: "--pythonpath", hint: "directory to add to the Python path"},
+	{text: "--verbosity", hint: "0 minimal, 1 normal, 2 verbose, 3 very verbose"},
+	{text: "--traceback", hint: "raise on CommandError exceptions"},
+	{text: "--no-color", hint: "don't colorize the command output"},
+	{text: "--force-color", hint: "force colorization of the command output"},
+	{text: "--skip-checks", hint: "skip system checks"},
+}
+
+// commandCompletion is a candidate for the word under the cursor in the command bar
+type commandCompletion struct {
+	text string // Replaces the word being completed
+	hint string // Option help, make target description, task label...
+}
+
+// commandCompletionSource is what the command bar completes against
+type commandCompletionSource struct {
+	rootDir     string
+	subcommands []string
+	arguments   map[string][]django.CommandArgument // Introspected arguments by subcommand
+	makeTargets []makeTarget
+	tasks       []projectTaskEntry
+	apps        []django.App
+	migrations  []django.Migration
+}
+
+// commandBarState is the history position and Tab cycle of the command bar
+type commandBarState struct {
+	historyIndex int // Index into commandHistory while browsing, -1 otherwise
+	historyDraft string
+	cycleBase    string // Input before the first Tab of a cycle
+	cycleInput   string // Input after the last Tab; another Tab continues the cycle
+	cycleIndex   int
+}
+
+// completeCommand returns the byte offset of the word being completed and its candidates.
+// A task label completes to the whole task command, so its candidate starts at 0.
+func completeCommand(input string, src commandCompletionSource) (int, []commandCompletion) {
+	words := strings.Fields(input)
+	current := ""
+	if len(words) > 0 && !strings.HasSuffix(input, " ") {
+		current = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+	start := len(input) - len(current)
+
+	if len(words) == 0 {
+		return completeFirstWord(input, current, src)
+	}
+
+	if words[0] == "make" {
+		var candidates []commandCompletion
+		for _, target := range src.makeTargets {
+			candidates = append(candidates, commandCompletion{text: target.name, hint: target.description})
+		}
+		return start, filterCompletions(candidates, current)
+	}
+
+	manage := -1
+	for i, word := range words {
+		if strings.HasSuffix(word, "manage.py") || word == "django-admin" {
+			manage = i
+			break
+		}
+	}
+	if manage < 0 {
+		candidates := completePath(src.rootDir, current)
+		if len(words) == 1 && strings.HasPrefix(words[0], "python") && strings.HasPrefix("manage.py", current) {
+			candidates = append([]commandCompletion{{text: "manage.py", hint: "Django management command"}}, candidates...)
+		}
+		return start, candidates
+	}
+	if manage == len(words)-1 {
+		subcommands := src.subcommands
+		if len(subcommands) == 0 {
+			subcommands = coreManagementCommands
+		}
+		candidates := make([]commandCompletion, 0, len(subcommands))
+		for _, name := range subcommands {
+			candidates = append(candidates, commandCompletion{text: name})
+		}
+		return start, filterCompletions(candidates, current)
+	}
+	return start, completeManageArgument(words[manage+1], words[manage+2:], current, src)
+}
+
+func completeFirstWord(input, current string, src commandCompletionSource) (int, []commandCompletion) {
+	candidates := []commandCompletion{
+		{text: "python manage.py", hint: "Django management command"},
+		{text: "make", hint: "Makefile target"},
+	}
+	candidates = filterCompletions(candidates, strings.TrimLeft(input, " "))
+	needle := strings.ToLower(strings.TrimLeft(input, " "))
+	for _, task := range src.tasks {
+		if strings.HasPrefix(strings.ToLower(task.Label), needle) || strings.HasPrefix(task.Command, strings.TrimLeft(input, " ")) {
+			candidates = append(candidates, commandCompletion{text: task.Command, hint: "task: " + task.Label})
+		}
+	}
+	if len(candidates) > 0 {
+		return 0, limitCompletions(candidates)
+	}
+	return len(input) - len(current), completePath(src.rootDir, current)
+}
+
+// completeManageArgument completes options, option values and positional arguments of a subcommand
+func completeManageArgument(subcommand string, previous []string, current string, src commandCompletionSource) []commandCompletion {
+	arguments := src.arguments[subcommand]
+	if strings.HasPrefix(current, "-") {
+		var candidates []commandCompletion
+		seen := make(map[string]bool)
+		for _, arg := range arguments {
+			for _, flag := range arg.Flags {
+				if strings.HasPrefix(flag, "--") && !seen[flag] {
+					seen[flag] = true
+					candidates = append(candidates, commandCompletion{text: flag, hint: arg.Help})
+				}
+			}
+		}
+		for _, option := range baseCommandOptions {
+			if !seen[option.text] {
+				candidates = append(candidates, option)
+			}
+		}
+		return filterCompletions(candidates, current)
+	}
+
+	var positional []string
+	for i, word := range previous {
+		if strings.HasPrefix(word, "-") || (i > 0 && optionTakesValue(arguments, previous[i-1])) {
+			continue
+		}
+		positional = append(positional, word)
+	}
+	if len(previous) > 0 {
+		if option := previous[len(previous)-1]; optionTakesValue(arguments, option) {
+			for _, arg := range arguments {
+				if slices.Contains(arg.Flags, option) && len(arg.Choices) > 0 {
+					return filterCompletions(choiceCompletions(arg.Choices, arg.Help), current)
+				}
+			}
+			if option == "--verbosity" || option == "-v" {
+				return filterCompletions(choiceCompletions([]string{"0", "1", "2", "3"}, ""), current)
+			}
+			return completePath(src.rootDir, current)
+		}
+	}
+
+	switch subcommand {
+	case "dumpdata":
+		var candidates []commandCompletion
+		for _, app := range src.apps {
+			candidates = append(candidates, commandCompletion{text: app.Name, hint: "app"})
+			for _, model := range app.Models {
+				candidates = append(candidates, commandCompletion{text: app.Name + "." + model.Name, hint: "model"})
+			}
+		}
+		return filterCompletions(candidates, current)
+	case "makemigrations", "showmigrations", "test":
+		if subcommand != "test" || !strings.ContainsAny(current, "/.") {
+			return filterCompletions(appCompletions(src.apps), current)
+		}
+	case "migrate", "sqlmigrate", "squashmigrations", "optimizemigration":
+		switch len(positional) {
+		case 0:
+			return filterCompletions(appCompletions(src.apps), current)
+		case 1, 2:
+			if len(positional) == 2 && subcommand != "squashmigrations" {
+				break
+			}
+			var candidates []commandCompletion
+			if subcommand == "migrate" {
+				candidates = append(candidates, commandCompletion{text: "zero", hint: "unapply every migration"})
+			}
+			for _, migration := range src.migrations {
+				if migration.App != positional[0] {
+					continue
+				}
+				hint := "unapplied"
+				if migration.Applied {
+					hint = "applied"
+				}
+				candidates = append(candidates, commandCompletion{text: migration.Name, hint: hint})
+			}
+			return filterCompletions(candidates, current)
+		}
+	}
+	return completePath(src.rootDir, current)
+}
+
+// optionTakesValue reports whether word is an option followed by a separate value
+func optionTakesValue(arguments []django.CommandArgument, word string) bool {
+	if !strings.HasPrefix(word, "-") || strings.Contains(word, "=") {
+		return false
+	}
+	for _, arg := range arguments {
+		if slices.Contains(arg.Flags, word) {
+			return !arg.Boolean()
+		}
+	}
+	switch word {
+	case "--settings", "--pythonpath", "--verbosity", "-v":
+		return true
+	}
+	return false
+}
+
+func appCompletions(apps []django.App) []commandCompletion {
+	candidates := make([]commandCompletion, 0, len(apps))
+	for _, app := range apps {
+		candidates = append(candidates, commandCompletion{text: app.Name, hint: "app"})
+	}
+	return candidates
+}
+
+func choiceCompletions(choices []string, hint string) []commandCompletion {
+	candidates := make([]commandCompletion, 0, len(choices))
+	for _, choice := range choices {
+		candidates = append(candidates, commandCompletion{text: choice, hint: hint})
+	}
+	return candidates
+}
+
+// completePath completes files and directories relative to the project root. Hidden entries are
+// only offered once the word starts with a dot.
+func completePath(rootDir, current string) []commandCompletion {
+	if rootDir == "" {
+		return nil
+	}
+	dir, prefix := filepath.Split(current)
+	searchDir := dir
+	if !filepath.IsAbs(searchDir) {
+		searchDir = filepath.Join(rootDir, dir)
+	}
+	entries, err := os.ReadDir(searchDir)
+	if err != nil {
+		return nil
+	}
+	var candidates []commandCompletion
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		if entry.IsDir() {
+			name += "/"
+		}
+		candidates = append(candidates, commandCompletion{text: dir + name})
+	}
+	return limitCompletions(candidates)
+}
+
+func filterCompletions(candidates []commandCompletion, prefix string) []commandCompletion {
+	var matched []commandCompletion
+	seen := make(map[string]bool)
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate.text, prefix) && candidate.text != prefix && !seen[candidate.text] {
+			seen[candidate.text] = true
+			matched = append(matched, candidate)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool { return matched[i].text < matched[j].text })
+	return limitCompletions(matched)
+}
+
+func limitCompletions(candidates []commandCompletion) []commandCompletion {
+	if len(candidates) > maxCommandCompletions {
+		return candidates[:maxCommandCompletions]
+	}
+	return candidates
+}
+
+// applyCompletion replaces the word at start with the candidate. A lone candidate that is not a
+// directory gets a trailing space so the next word can be typed straight away.
+func applyCompletion(input string, start int, candidate commandCompletion, only bool) string {
+	completed := input[:start] + candidate.text
+	if only && !strings.HasSuffix(candidate.text, "/") {
+		completed += " "
+	}
+	return completed
+}
+
+// commandCompletionSource gathers what the project knows right now. Subcommands and their
+// arguments fill in as the background loads finish.
+func (gui *Gui) commandCompletionSource() commandCompletionSource {
+	src := commandCompletionSource{arguments: make(map[string][]django.CommandArgument)}
+	if gui.project != nil {
+		src.rootDir = gui.project.RootDir
+		src.apps = gui.project.Apps
+		src.migrations = gui.project.Migrations
+	}
+	for _, command := range gui.commands.commands {
+		src.subcommands = append(src.subcommands, command.Name)
+	}
+	for name, command := range gui.commands.described {
+		src.arguments[name] = command.Arguments
+	}
+	if gui.makeTargetsLoaded {
+		src.makeTargets = gui.makeTargets
+	}
+	if gui.projectTasksReady {
+		src.tasks = gui.projectTasks
+	}
+	return src
+}
+
+// loadCommandCompletions fetches the management commands in the background for completion
+func (gui *Gui) loadCommandCompletions() {
+	if gui.project == nil || len(gui.commands.commands) > 0 || gui.commands.loading {
+		return
+	}
+	gui.commands.loading = true
+	project := gui.project
+	go func() {
+		commands, err := project.ListManagementCommands()
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.commands.loading = false
+			if err == nil && len(gui.commands.commands) == 0 {
+				gui.commands.commands = commands
+			}
+			return nil
+		})
+	}()
+}
+
+// describeCommandForCompletion introspects the options of a subcommand in the background
+func (gui *Gui) describeCommandForCompletion(name string) {
+	if _, ok := gui.commands.described[name]; ok || gui.commands.describing[name] || gui.project == nil {
+		return
+	}
+	if gui.commands.describing == nil {
+		gui.commands.describing = make(map[string]bool)
+	}
+	gui.commands.describing[name] = true
+	project := gui.project
+	go func() {
+		described, err := project.DescribeManagementCommand(django.ManagementCommand{Name: name})
+		gui.g.Update(func(g *gocui.Gui) error {
+			delete(gui.commands.describing, name)
+			if err != nil {
+				return nil
+			}
+			if gui.commands.described == nil {
+				gui.commands.described = make(map[string]django.ManagementCommand)
+			}
+			gui.commands.described[name] = described
+			return nil
+		})
+	}()
+}
+
+// commandBarInput returns the single line of the command bar
+func commandBarInput(v *gocui.View) string {
+	return strings.TrimRight(v.Buffer(), "\r\n")
+}
+
+func setCommandBarInput(v *gocui.View, input string) {
+	v.Clear()
+	_ = v.SetOrigin(0, 0)
+	_, _ = v.Write([]byte(input))
+	width, _ := v.Size()
+	cursor := len([]rune(input))
+	if cursor >= width && width > 0 {
+		_ = v.SetOrigin(cursor-width+1, 0)
+		cursor = width - 1
+	}
+	_ = v.SetCursor(cursor, 0)
+}
+
+// completeCommandBar handles Tab: it inserts the first candidate, and repeated presses cycle
+// through the others
+func (gui *Gui) completeCommandBar(g *gocui.Gui, v *gocui.View) error {
+	if gui.inputMode != "command" || v == nil {
+		return nil
+	}
+	input := commandBarInput(v)
+	state := &gui.commandBar
+	if input != state.cycleInput || state.cycleInput == "" {
+		state.cycleBase = input
+		state.cycleIndex = 0
+	} else {
+		state.cycleIndex++
+	}
+
+	start, candidates := completeCommand(state.cycleBase, gui.commandCompletionSource())
+	gui.requestArgumentCompletions(state.cycleBase)
+	if len(candidates) == 0 {
+		state.cycleInput = ""
+		return nil
+	}
+	candidate := candidates[state.cycleIndex%len(candidates)]
+	completed := applyCompletion(state.cycleBase, start, candidate, len(candidates) == 1)
+	setCommandBarInput(v, completed)
+	state.cycleInput = completed
+	if len(candidates) == 1 {
+		state.cycleInput = ""
+	}
+	return nil
+}
+
+// requestArgumentCompletions starts loading the options of the subcommand being typed
+func (gui *Gui) requestArgumentCompletions(input string) {
+	words := strings.Fields(input)
+	for i, word := range words {
+		if (strings.HasSuffix(word, "manage.py") || word == "django-admin") && i+1 < len(words) {
+			if i+2 < len(words) || strings.HasSuffix(input, " ") {
+				gui.describeCommandForCompletion(words[i+1])
+			}
+			return
+		}
+	}
+}
+
+// browseCommandHistory handles Up (-1) and Down (+1) over commandHistory. Leaving the newest
+// entry restores what was typed before browsing.
+func (gui *Gui) browseCommandHistory(v *gocui.View, step int) {
+	history := gui.commandHistory
+	state := &gui.commandBar
+	if len(history) == 0 || v == nil {
+		return
+	}
+	index := state.historyIndex
+	if index < 0 {
+		if step > 0 {
+			return
+		}
+		state.historyDraft = commandBarInput(v)
+		index = len(history)
+	}
+	index += step
+	switch {
+	case index < 0:
+		index = 0
+	case index >= len(history):
+		state.historyIndex = -1
+		setCommandBarInput(v, state.historyDraft)
+		return
+	}
+	state.historyIndex = index
+	state.cycleInput = ""
+	setCommandBarInput(v, history[index])
+}
+
+// layoutCommandGhost shows the rest of the first candidate after the cursor in dim text, and its
+// hint in the input bar subtitle
+func (gui *Gui) layoutCommandGhost(g *gocui.Gui, v *gocui.View, x0, y0, x1 int) error {
+	input := commandBarInput(v)
+	cx, _ := v.Cursor()
+	ox, _ := v.Origin()
+	start, candidates := completeCommand(input, gui.commandCompletionSource())
+	v.Subtitle = ""
+	if input == "" || len(candidates) == 0 || cx+ox != len([]rune(input)) {
+		g.DeleteView(CommandGhostWindow)
+		return nil
+	}
+
+	candidate := candidates[0]
+	word := input[start:]
+	ghost := ""
+	if strings.HasPrefix(candidate.text, word) {
+		ghost = candidate.text[len(word):]
+	} else {
+		ghost = "  -> " + candidate.text
+	}
+	hint := candidate.hint
+	if len(candidates) > 1 {
+		hint = strings.TrimSpace(fmt.Sprintf("%s (Tab: 1 of %d)", hint, len(candidates)))
+	}
+	if hint != "" {
+		v.Subtitle = " " + truncateRunes(hint, max(0, (x1-x0)/2)) + " "
+	}
+
+	left := x0 + cx
+	room := x1 - left - 1
+	if room <= 0 || ghost == "" {
+		g.DeleteView(CommandGhostWindow)
+		return nil
+	}
+	ghost = truncateRunes(ghost, room)
+	gv, err := g.SetView(CommandGhostWindow, left, y0, left+len([]rune(ghost))+1, y0+2, 0)
+	if err != nil && err != gocui.ErrUnknownView {
+		return err
+	}
+	gv.Frame = false
+	gv.FgColor = gocui.ColorDefault | gocui.AttrDim
+	gv.Clear()
+	_, _ = gv.Write([]byte(ghost))
+	g.SetViewOnTop(CommandGhostWindow)
+	return nil
+}
+
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	if limit <= 3 {
+		return string(runes[:limit])
+	}
+	return string(runes[:limit-3]) + "..."
+}
@@ -0,0 +1,109 @@
+package gui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func completionTexts(candidates []commandCompletion) string {
+	texts := make([]string, 0, len(candidates))
+	for _, candidate := range candidates {
+		texts = append(texts, candidate.text)
+	}
+	return strings.Join(texts, " ")
+}
+
+func TestCompleteCommand(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "fixtures"), 0755); err != nil {
+		t.Fatalf("mkdir failed: %v", err)
+	}
+	for _, name := range []string{"fixtures/users.json", "fixtures/posts.json"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("x"), 0644); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	src := commandCompletionSource{
+		rootDir:     root,
+		subcommands: []string{"check", "dumpdata", "makemigrations", "migrate", "publish"},
+		arguments: map[string][]django.CommandArgument{
+			"migrate": {
+				{Dest: "app_label", Nargs: "?"},
+				{Dest: "database", Flags: []string{"--database"}, Action: "store", Help: "Nominates a database"},
+				{Dest: "plan", Flags: []string{"--plan"}, Action: "store_true", Help: "Shows a list of the migration actions"},
+			},
+		},
+		makeTargets: []makeTarget{{name: "test", description: "Run tests"}, {name: "lint"}},
+		tasks:       []projectTaskEntry{{Label: "Seed demo data", Command: "python manage.py loaddata fixtures/demo.json"}},
+		apps: []django.App{
+			{Name: "blog", Models: []django.Model{{Name: "Post"}, {Name: "Comment"}}},
+			{Name: "billing"},
+		},
+		migrations: []django.Migration{
+			{App: "blog", Name: "0001_initial", Applied: true},
+			{App: "blog", Name: "0002_post_slug"},
+			{App: "billing", Name: "0001_initial"},
+		},
+	}
+	tests := []struct {
+		input string
+		start int
+		want  string
+	}{
+		{input: "python manage.py m", start: 17, want: "makemigrations migrate"},
+		{input: "python man", start: 7, want: "manage.py"},
+		{input: "make t", start: 5, want: "test"},
+		{input: "python manage.py dumpdata blog.", start: 26, want: "blog.Comment blog.Post"},
+		{input: "python manage.py migrate bl", start: 25, want: "blog"},
+		{input: "python manage.py migrate blog 000", start: 30, want: "0001_initial 0002_post_slug"},
+		{input: "python manage.py migrate --database default blog ", start: 49, want: "0001_initial 0002_post_slug zero"},
+		{input: "python manage.py migrate --p", start: 25, want: "--plan --pythonpath"},
+		{input: "python manage.py loaddata fixtures/p", start: 26, want: "fixtures/posts.json"},
+		{input: "cat fix", start: 4, want: "fixtures/"},
+		{input: "seed", start: 0, want: "python manage.py loaddata fixtures/demo.json"},
+	}
+	for _, tc := range tests {
+		start, candidates := completeCommand(tc.input, src)
+		if start != tc.start || completionTexts(candidates) != tc.want {
+			t.Fatalf("completeCommand(%q) = %d %q, want %d %q", tc.input, start, completionTexts(candidates), tc.start, tc.want)
+		}
+	}
+}
+
+func TestCompleteCommandFallsBackToCoreCommands(t *testing.T) {
+	start, candidates := completeCommand("./manage.py showm", commandCompletionSource{})
+	if start != 12 || completionTexts(candidates) != "showmigrations" {
+		t.Fatalf("unexpected completion: %d %q", start, completionTexts(candidates))
+	}
+}
+
+func TestCompletePathHidesDotfiles(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"manage.py", ".env"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte("x"), 0644); err != nil {
+			t.Fatalf("write failed: %v", err)
+		}
+	}
+	if got := completionTexts(completePath(root, "")); strings.Contains(got, ".env") || !strings.Contains(got, "manage.py") {
+		t.Fatalf("expected hidden files to be skipped, got %q", got)
+	}
+	if got := completionTexts(completePath(root, ".e")); got != ".env" {
+		t.Fatalf("expected .env for a dot prefix, got %q", got)
+	}
+}
+
+func TestApplyCompletion(t *testing.T) {
+	if got := applyCompletion("make t", 5, commandCompletion{text: "test"}, true); got != "make test " {
+		t.Fatalf("unexpected completion: %q", got)
+	}
+	if got := applyCompletion("cat fix", 4, commandCompletion{text: "fixtures/"}, true); got != "cat fixtures/" {
+		t.Fatalf("expected no space after a directory, got %q", got)
+	}
+	if got := applyCompletion("python manage.py mi", 17, commandCompletion{text: "migrate"}, false); got != "python manage.py migrate" {
+		t.Fatalf("expected no space while cycling, got %q", got)
+	}
+}
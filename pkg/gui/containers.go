@@ -0,0 +1,295 @@
+package gui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+// containerInfo is one compose container from `docker compose ps`, `docker inspect` and `docker stats`
+type containerInfo struct {
+	Service  string `json:"Service"`
+	Name     string `json:"Name"`
+	State    string `json:"State"`
+	Status   string `json:"Status"`
+	Health   string `json:"Health"`
+	ExitCode int    `json:"ExitCode"`
+	Restarts int    `json:"-"`
+	CPU      string `json:"-"`
+	Memory   string `json:"-"`
+}
+
+// containerStats is one line of `docker stats --no-stream --format '{{json .}}'`
+type containerStats struct {
+	Name     string `json:"Name"`
+	CPUPerc  string `json:"CPUPerc"`
+	MemUsage string `json:"MemUsage"`
+	MemPerc  string `json:"MemPerc"`
+}
+
+// decodeDockerJSON reads docker's --format json output, which is either a JSON array or one
+// object per line depending on the docker version
+func decodeDockerJSON[T any](output string) []T {
+	trimmed := strings.TrimSpace(output)
+	if trimmed == "" {
+		return nil
+	}
+	var items []T
+	if strings.HasPrefix(trimmed, "[") {
+		if err := json.Unmarshal([]byte(trimmed), &items); err != nil {
+			return nil
+		}
+		return items
+	}
+	for _, line := range strings.Split(trimmed, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var item T
+		if err := json.Unmarshal([]byte(line), &item); err == nil {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func parseComposePSContainers(output string) []containerInfo {
+	containers := decodeDockerJSON[containerInfo](output)
+	parsed := make([]containerInfo, 0, len(containers))
+	for _, c := range containers {
+		if c.Service == "" {
+			continue
+		}
+		state := c.State
+		if state == "" {
+			state = c.Status
+		}
+		if state == "" {
+			state = "unknown"
+		}
+		c.State = strings.ToLower(state)
+		if c.Health == "" {
+			c.Health = healthFromStatus(c.Status)
+		}
+		parsed = append(parsed, c)
+	}
+	return parsed
+}
+
+// healthFromStatus reads the health from a status such as "Up 2 minutes (healthy)"
+func healthFromStatus(status string) string {
+	for _, health := range []string{"unhealthy", "healthy", "health: starting"} {
+		if strings.Contains(status, "("+health+")") {
+			return strings.TrimPrefix(health, "health: ")
+		}
+	}
+	return ""
+}
+
+// parseDockerInspectRestarts reads `docker inspect --format '{{.Name}} {{.RestartCount}}'` lines
+func parseDockerInspectRestarts(output string) map[string]int {
+	restarts := make(map[string]int)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		count, err := strconv.Atoi(fields[1])
+		if err != nil {
+			continue
+		}
+		restarts[strings.TrimPrefix(fields[0], "/")] = count
+	}
+	return restarts
+}
+
+func parseDockerStats(output string) map[string]containerStats {
+	stats := make(map[string]containerStats)
+	for _, s := range decodeDockerJSON[containerStats](output) {
+		if s.Name != "" {
+			stats[s.Name] = s
+		}
+	}
+	return stats
+}
+
+// loadContainerDetails collects state, health, restart counts and resource usage for every
+// compose container. It shells out several times, so call it off the UI goroutine.
+func loadContainerDetails(project *django.Project) ([]containerInfo, error) {
+	args := append([]string{"compose"}, project.ComposeFileArgs()...)
+	cmd := exec.Command("docker", append(args, "ps", "--all", "--format", "json")...)
+	cmd.Dir = project.RootDir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker compose ps: %w", err)
+	}
+	containers := parseComposePSContainers(string(output))
+	if len(containers) == 0 {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(containers))
+	running := make([]string, 0, len(containers))
+	for _, c := range containers {
+		if c.Name == "" {
+			continue
+		}
+		names = append(names, c.Name)
+		if c.State == "running" {
+			running = append(running, c.Name)
+		}
+	}
+	var restarts map[string]int
+	if len(names) > 0 {
+		inspect := exec.Command("docker", append([]string{"inspect", "--format", "{{.Name}} {{.RestartCount}}"}, names...)...)
+		if output, err := inspect.Output(); err == nil {
+			restarts = parseDockerInspectRestarts(string(output))
+		}
+	}
+	var stats map[string]containerStats
+	if len(running) > 0 {
+		statsCmd := exec.Command("docker", append([]string{"stats", "--no-stream", "--format", "{{json .}}"}, running...)...)
+		if output, err := statsCmd.Output(); err == nil {
+			stats = parseDockerStats(string(output))
+		}
+	}
+
+	for i := range containers {
+		containers[i].Restarts = restarts[containers[i].Name]
+		if s, ok := stats[containers[i].Name]; ok {
+			containers[i].CPU = s.CPUPerc
+			containers[i].Memory = s.MemUsage
+			if s.MemPerc != "" {
+				containers[i].Memory += " (" + s.MemPerc + ")"
+			}
+		}
+	}
+	return containers, nil
+}
+
+// formatContainerTable renders container details as aligned columns
+func formatContainerTable(containers []containerInfo) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SERVICE\tSTATE\tHEALTH\tRESTARTS\tCPU\tMEMORY")
+	for _, c := range containers {
+		state := c.State
+		if state == "exited" {
+			state = fmt.Sprintf("exited (%d)", c.ExitCode)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", c.Service, state, dashIfEmpty(c.Health), c.Restarts, dashIfEmpty(c.CPU), dashIfEmpty(c.Memory))
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+func dashIfEmpty(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
+
+// showContainerDetails loads container details in the background into a Command tab
+func (gui *Gui) showContainerDetails() error {
+	tabID := gui.startCommandOutputTab("Containers")
+	gui.appendOutput(tabID, "Loading container state, health and resource usage...\n")
+	gui.refreshOutputView()
+	_ = gui.switchPanel(MainWindow)
+
+	project := gui.project
+	go func() {
+		containers, err := loadContainerDetails(project)
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.resetOutput(tabID, "Containers")
+			switch {
+			case err != nil:
+				gui.appendOutput(tabID, fmt.Sprintf("Failed to read container status: %v\n", err))
+				gui.rememberError("containers", err.Error())
+			case len(containers) == 0:
+				gui.appendOutput(tabID, "No containers. Start services from Project -> Containers... first.\n")
+			default:
+				gui.appendOutput(tabID, formatContainerTable(containers))
+				gui.appendOutput(tabID, fmt.Sprintf("\nUpdated %s\n", time.Now().Format("15:04:05")))
+				gui.containerStatus = make(map[string]string, len(containers))
+				for _, c := range containers {
+					gui.containerStatus[c.Service] = c.State
+				}
+				gui.renderServerStatus()
+			}
+			gui.refreshOutputView()
+			return nil
+		})
+	}()
+	return nil
+}
+
+func (gui *Gui) projectServiceActions() []projectAction {
+	services, err := gui.listComposeServices()
+	if err != nil {
+		return nil
+	}
+	actions := make([]projectAction, 0, len(services))
+	for _, service := range services {
+		label := service
+		if status := gui.containerStatus[service]; status != "" {
+			label += " (" + status + ")"
+		}
+		actions = append(actions, projectAction{label: label, internal: "openservice", value: service})
+	}
+	return actions
+}
+
+func (gui *Gui) projectServiceDetailActions(service string) []projectAction {
+	return []projectAction{
+		{label: "Follow logs", internal: "servicelogs", value: service},
+		{label: "Restart", internal: "servicerestart", value: service},
+		{label: "Rebuild and start (up --build)", internal: "servicerebuild", value: service},
+		{label: "Open shell", internal: "serviceshell", value: service},
+	}
+}
+
+func (gui *Gui) composeCommand(args ...string) *exec.Cmd {
+	full := append([]string{"compose"}, gui.project.ComposeFileArgs()...)
+	cmd := exec.Command("docker", append(full, args...)...)
+	cmd.Dir = gui.project.RootDir
+	return cmd
+}
+
+// followServiceLogs streams `docker compose logs -f` for one service into a Logs tab
+func (gui *Gui) followServiceLogs(service string) error {
+	cmd := gui.composeCommand("logs", "-f", "--tail", "200", service)
+	return gui.runStreamingCommandToRoute(OutputTabLogs, "Logs: "+service, cmd, nil, "docker compose logs -f "+service, nil)
+}
+
+// runServiceAction streams a compose command for one service, then refreshes container status
+func (gui *Gui) runServiceAction(action, service string, args ...string) error {
+	cmd := gui.composeCommand(append(args, service)...)
+	startedAt := time.Now()
+	display := fmt.Sprintf("docker compose %s %s", strings.Join(args, " "), service)
+	return gui.runStreamingCommandToRoute(OutputTabCommand, "", cmd, nil, display, func(g *gocui.Gui, waitErr error) {
+		gui.refreshContainerStatus()
+		gui.renderServerStatus()
+		gui.recordContainerAction(action, []string{service}, startedAt, waitErr)
+	})
+}
+
+// openServiceShell hands the terminal to an interactive shell in the service container
+func (gui *Gui) openServiceShell(service string) error {
+	cmd := gui.composeCommand("exec", service, "sh", "-c", "command -v bash >/dev/null 2>&1 && exec bash || exec sh")
+	startedAt := time.Now()
+	err := runInCurrentTerminal(cmd)
+	gui.recordContainerAction("shell", []string{service}, startedAt, err)
+	if err != nil {
+		return gui.showMessage("Containers", fmt.Sprintf("Shell in %s failed: %v", service, err))
+	}
+	return nil
+}
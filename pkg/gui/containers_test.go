@@ -0,0 +1,43 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseComposePSContainersReadsHealth(t *testing.T) {
+	output := `{"Service":"web","Name":"app-web-1","State":"running","Status":"Up 2 minutes (healthy)","Health":"","ExitCode":0}
+{"Service":"db","Name":"app-db-1","State":"running","Status":"Up 2 minutes","Health":"starting","ExitCode":0}
+{"Service":"worker","Name":"app-worker-1","State":"exited","Status":"Exited (137) 1 minute ago","ExitCode":137}`
+
+	containers := parseComposePSContainers(output)
+	if len(containers) != 3 {
+		t.Fatalf("expected 3 containers, got %+v", containers)
+	}
+	if containers[0].Health != "healthy" || containers[1].Health != "starting" {
+		t.Fatalf("unexpected health values %q, %q", containers[0].Health, containers[1].Health)
+	}
+	if containers[2].State != "exited" || containers[2].ExitCode != 137 {
+		t.Fatalf("unexpected exited container %+v", containers[2])
+	}
+}
+
+func TestParseDockerInspectRestartsAndStats(t *testing.T) {
+	restarts := parseDockerInspectRestarts("/app-web-1 0\n/app-worker-1 4\n")
+	if restarts["app-worker-1"] != 4 || restarts["app-web-1"] != 0 {
+		t.Fatalf("unexpected restart counts %v", restarts)
+	}
+
+	stats := parseDockerStats(`{"Name":"app-web-1","CPUPerc":"1.25%","MemUsage":"120MiB / 1.9GiB","MemPerc":"6.1%"}`)
+	if stats["app-web-1"].CPUPerc != "1.25%" {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+
+	table := formatContainerTable([]containerInfo{
+		{Service: "web", State: "running", Health: "healthy", CPU: "1.25%", Memory: "120MiB / 1.9GiB"},
+		{Service: "worker", State: "exited", ExitCode: 137, Restarts: 4},
+	})
+	if !strings.Contains(table, "exited (137)") || !strings.Contains(table, "healthy") {
+		t.Fatalf("unexpected table:\n%s", table)
+	}
+}
@@ -0,0 +1,172 @@
+package gui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/config"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const coverageTitle = "Coverage"
+
+// Coverage table orders
+const (
+	coverageSortWorst = "worst"
+	coverageSortPath  = "path"
+)
+
+// exportCoverage runs `coverage json` after a covered test run and shows the report. It is a
+// tracked operation, so X cancels it and the command timeout applies.
+func (gui *Gui) exportCoverage() {
+	project := gui.project
+	ctx, op := gui.beginContextOperation("coverage json", "")
+	go func() {
+		runCtx, cancel := project.OperationContext(ctx, config.TimeoutCommand)
+		output, err := project.CoverageJSONCommand(runCtx).CombinedOutput()
+		cancel()
+		var report *django.CoverageReport
+		if err == nil {
+			report, err = project.ReadCoverageReport()
+		}
+		gui.g.Update(func(g *gocui.Gui) error {
+			if err = gui.endOperation(op, err); err != nil {
+				if errors.Is(err, context.Canceled) {
+					return gui.showMessage("Coverage", "Coverage export cancelled.")
+				}
+				message := strings.TrimSpace(string(output))
+				if message == "" {
+					message = err.Error()
+				}
+				gui.rememberError("coverage", message)
+				return gui.showMessage("Coverage", fmt.Sprintf("Could not read the coverage report: %s\nIs coverage installed in the execution backend?", message))
+			}
+			gui.tests.coverage = report
+			return gui.showCoverage()
+		})
+	}()
+}
+
+// showCoverage renders the last coverage report in the Coverage tab
+func (gui *Gui) showCoverage() error {
+	if gui.tests.coverage == nil {
+		return gui.showMessage("Coverage", "No coverage report yet. Run the tests with coverage first.")
+	}
+	if gui.tests.coverageSort == "" {
+		gui.tests.coverageSort = coverageSortWorst
+	}
+	text, lineFiles := formatCoverageReport(gui.tests.coverage, gui.tests.coverageSort)
+	tabID := gui.tests.coverageTabID
+	if _, ok := gui.outputTabs[tabID]; ok {
+		gui.resetOutput(tabID, coverageTitle)
+		gui.switchOutputTab(tabID)
+	} else {
+		tabID = gui.startCommandOutputTab(coverageTitle)
+	}
+	gui.tests.coverageTabID = tabID
+	gui.tests.coverageLines = lineFiles
+	gui.outputTabs[tabID].originY = 0
+	gui.appendOutput(tabID, text)
+	_ = gui.switchPanel(MainWindow)
+	gui.refreshOutputView()
+	return nil
+}
+
+// toggleCoverageSort switches between worst-first and path order
+func (gui *Gui) toggleCoverageSort() error {
+	if gui.tests.coverageSort == coverageSortWorst {
+		gui.tests.coverageSort = coverageSortPath
+	} else {
+		gui.tests.coverageSort = coverageSortWorst
+	}
+	return gui.showCoverage()
+}
+
+// coverageFileUnderCursor returns the file on the selected (or top visible) line of the Coverage tab
+func (gui *Gui) coverageFileUnderCursor() (django.CoverageFile, bool) {
+	tabID := gui.tests.coverageTabID
+	if tabID == "" || gui.tests.coverage == nil || gui.resolveOutputTabID("", false) != tabID {
+		return django.CoverageFile{}, false
+	}
+	line := gui.outputCursorLine(tabID)
+	if line < 0 || line >= len(gui.tests.coverageLines) || gui.tests.coverageLines[line] == "" {
+		return django.CoverageFile{}, false
+	}
+	for _, file := range gui.tests.coverage.Files {
+		if file.Path == gui.tests.coverageLines[line] {
+			return file, true
+		}
+	}
+	return django.CoverageFile{}, false
+}
+
+// openCoverageFile opens the file under the cursor at its first missed line
+func (gui *Gui) openCoverageFile() error {
+	file, ok := gui.coverageFileUnderCursor()
+	if !ok {
+		return nil
+	}
+	line := 1
+	if len(file.MissingLines) > 0 {
+		line = file.MissingLines[0]
+	}
+	path, ok := gui.project.HostPath(file.Path)
+	if !ok {
+		return gui.showMessage("Coverage", fmt.Sprintf("%s is not available on this machine.", file.Path))
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:   "editor",
+		Source: "coverage",
+		Status: "ok",
+		Action: fmt.Sprintf("%s:%d", path, line),
+	})
+	return gui.openFileInEditor("Coverage", path, line)
+}
+
+// formatCoverageReport renders per-app and per-file tables. lineFiles maps each output line to
+// the file it shows.
+func formatCoverageReport(report *django.CoverageReport, order string) (string, []string) {
+	apps := report.Apps()
+	files := append([]django.CoverageFile(nil), report.Files...)
+	if order == coverageSortWorst {
+		sort.SliceStable(apps, func(i, j int) bool { return apps[i].Percent < apps[j].Percent })
+		sort.SliceStable(files, func(i, j int) bool { return files[i].Percent < files[j].Percent })
+	}
+
+	var b strings.Builder
+	var lineFiles []string
+	orderLabel := "worst coverage first"
+	if order == coverageSortPath {
+		orderLabel = "by path"
+	}
+	fmt.Fprintf(&b, "Total coverage: %.1f%% (%s)\n", report.Percent, orderLabel)
+	fmt.Fprintln(&b, "Enter on a file opens it at its first missed line.")
+	fmt.Fprintln(&b)
+	lineFiles = append(lineFiles, "", "", "")
+
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "APP\tSTMTS\tMISS\tCOVER")
+	lineFiles = append(lineFiles, "")
+	for _, app := range apps {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\n", app.Name, app.Statements, app.Missed, app.Percent)
+		lineFiles = append(lineFiles, "")
+	}
+	_ = w.Flush()
+	fmt.Fprintln(&b)
+	lineFiles = append(lineFiles, "")
+
+	w = tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "FILE\tSTMTS\tMISS\tCOVER\tMISSING")
+	lineFiles = append(lineFiles, "")
+	for _, file := range files {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.1f%%\t%s\n", file.Path, file.Statements, file.Missed, file.Percent, django.LineRanges(file.MissingLines))
+		lineFiles = append(lineFiles, file.Path)
+	}
+	_ = w.Flush()
+	return b.String(), lineFiles
+}
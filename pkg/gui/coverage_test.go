@@ -0,0 +1,29 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestFormatCoverageReport(t *testing.T) {
+	report := &django.CoverageReport{Percent: 80, Files: []django.CoverageFile{
+		{Path: "blog/models.py", App: "blog", Statements: 10, Percent: 100},
+		{Path: "blog/views.py", App: "blog", Statements: 10, Missed: 4, Percent: 60, MissingLines: []int{3, 4, 5, 9}},
+	}}
+	text, lineFiles := formatCoverageReport(report, coverageSortWorst)
+	lines := outputLines(strings.TrimSuffix(text, "\n"))
+	if len(lines) != len(lineFiles) {
+		t.Fatalf("expected a file per line, got %d lines and %d files:\n%s", len(lines), len(lineFiles), text)
+	}
+	last := len(lines) - 1
+	if lineFiles[last-1] != "blog/views.py" || !strings.HasSuffix(lines[last-1], "3-5, 9") {
+		t.Fatalf("expected the worst file first, got %q -> %q", lines[last-1], lineFiles[last-1])
+	}
+
+	_, lineFiles = formatCoverageReport(report, coverageSortPath)
+	if lineFiles[last-1] != "blog/models.py" {
+		t.Fatalf("expected path order, got %v", lineFiles)
+	}
+}
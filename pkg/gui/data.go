@@ -10,29 +10,74 @@ import (
 	"github.com/williamblackie/lazydjango/pkg/django"
 )
 
-// loadAndDisplayRecords queries and displays records in a table format
+// loadAndDisplayRecords queries the current page in the background and displays it in a table
 func (gui *Gui) loadAndDisplayRecords() error {
+	return gui.loadRecords(nil)
+}
+
+// loadRecords queries the current page off the UI goroutine. onLoaded runs once the page is
+// displayed, unless a newer query or leaving the model superseded this one.
+func (gui *Gui) loadRecords(onLoaded func()) error {
 	mainView, err := gui.g.View(MainWindow)
 	if err != nil {
 		return err
 	}
 
+	app, model, page, pageSize := gui.currentApp, gui.currentModel, gui.currentPage, gui.pageSize
+	gui.setMainTitle(fmt.Sprintf("%s.%s (Page %d)", app, model, page))
 	mainView.Clear()
-	gui.setMainTitle(fmt.Sprintf("%s.%s (Page %d)", gui.currentApp, gui.currentModel, gui.currentPage))
+	fmt.Fprintf(mainView, "Loading %s.%s...\n", app, model)
+
+	if gui.recordsOp != nil {
+		gui.recordsOp.cancel()
+	}
+	ctx, op := gui.beginContextOperation(fmt.Sprintf("query %s.%s", app, model), "")
+	gui.recordsOp = op
+	go func() {
+		result, err := django.NewDataViewer(gui.project).WithContext(ctx).QueryModel(app, model, nil, page, pageSize)
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			if gui.recordsOp != op {
+				return nil
+			}
+			gui.recordsOp = nil
+			if gui.currentApp != app || gui.currentModel != model {
+				return nil
+			}
 
-	viewer := django.NewDataViewer(gui.project)
-	result, err := viewer.QueryModel(gui.currentApp, gui.currentModel, nil, gui.currentPage, gui.pageSize)
+			if err != nil {
+				if v, viewErr := g.View(MainWindow); viewErr == nil {
+					v.Clear()
+					fmt.Fprintf(v, "Error loading data: %v\n", err)
+				}
+				gui.rememberError("model-query", err.Error())
+				return nil
+			}
+
+			gui.currentRecords = result.Records
+			gui.totalRecords = result.Total
+			gui.recordsHasNext = result.HasNext
+			if err := gui.renderRecords(); err != nil {
+				return err
+			}
+			if onLoaded != nil {
+				onLoaded()
+			}
+			return nil
+		})
+	}()
+	return nil
+}
 
+// renderRecords displays the loaded page, e.g. after the selection moved
+func (gui *Gui) renderRecords() error {
+	mainView, err := gui.g.View(MainWindow)
 	if err != nil {
-		mainView.Clear()
-		fmt.Fprintf(mainView, "Error loading data: %v\n", err)
-		gui.rememberError("model-query", err.Error())
-		return nil
+		return err
 	}
 
-	gui.currentRecords = result.Records
-	gui.totalRecords = result.Total
-	gui.selectedRecordIdx = clampSelection(gui.selectedRecordIdx, len(result.Records))
+	gui.setMainTitle(fmt.Sprintf("%s.%s (Page %d)", gui.currentApp, gui.currentModel, gui.currentPage))
+	gui.selectedRecordIdx = clampSelection(gui.selectedRecordIdx, len(gui.currentRecords))
 	var selectedPK interface{}
 	if len(gui.currentRecords) > 0 && gui.selectedRecordIdx >= 0 && gui.selectedRecordIdx < len(gui.currentRecords) {
 		selectedPK = gui.currentRecords[gui.selectedRecordIdx].PK
@@ -40,7 +85,7 @@ func (gui *Gui) loadAndDisplayRecords() error {
 	gui.rememberModelAccess(gui.currentApp, gui.currentModel, gui.currentPage, gui.selectedRecordIdx, selectedPK)
 	mainView.Clear()
 
-	if len(result.Records) == 0 {
+	if len(gui.currentRecords) == 0 {
 		gui.modelOriginY = 0
 		keepSelectionVisible(mainView, -1, &gui.modelOriginY)
 		fmt.Fprintln(mainView, "No records found.")
@@ -50,19 +95,19 @@ func (gui *Gui) loadAndDisplayRecords() error {
 	}
 
 	// Header
-	totalPages := (result.Total + gui.pageSize - 1) / gui.pageSize
+	totalPages := (gui.totalRecords + gui.pageSize - 1) / gui.pageSize
 
 	fmt.Fprintf(mainView, "%s.%s - %d total records (Page %d/%d)\n\n",
-		gui.currentApp, gui.currentModel, result.Total, gui.currentPage, totalPages)
+		gui.currentApp, gui.currentModel, gui.totalRecords, gui.currentPage, totalPages)
 
 	// Build table
-	fieldNames, colWidths := gui.calculateTableLayout(result.Records)
+	fieldNames, colWidths := gui.calculateTableLayout(gui.currentRecords)
 	gui.printTableHeader(mainView, fieldNames, colWidths)
-	gui.printTableRows(mainView, result.Records, fieldNames, colWidths)
-	gui.printTableFooter(mainView, result.HasNext)
+	gui.printTableRows(mainView, gui.currentRecords, fieldNames, colWidths)
+	gui.printTableFooter(mainView, gui.recordsHasNext)
 
 	// Keep selected row in view while navigating records.
-	selectedLine := 4 + clampSelection(gui.selectedRecordIdx, len(result.Records))
+	selectedLine := 4 + gui.selectedRecordIdx
 	keepSelectionVisible(mainView, selectedLine, &gui.modelOriginY)
 
 	return nil
@@ -187,7 +232,7 @@ func (gui *Gui) nextRecord(g *gocui.Gui, v *gocui.View) error {
 
 	if gui.selectedRecordIdx < len(gui.currentRecords)-1 {
 		gui.selectedRecordIdx++
-		return gui.loadAndDisplayRecords()
+		return gui.renderRecords()
 	}
 	return nil
 }
@@ -199,12 +244,15 @@ func (gui *Gui) prevRecord(g *gocui.Gui, v *gocui.View) error {
 
 	if gui.selectedRecordIdx > 0 {
 		gui.selectedRecordIdx--
-		return gui.loadAndDisplayRecords()
+		return gui.renderRecords()
 	}
 	return nil
 }
 
 func (gui *Gui) nextPage(g *gocui.Gui, v *gocui.View) error {
+	if gui.currentWindow == MainWindow && gui.currentModel == "" && !gui.isModalOpen {
+		return gui.jumpOutputMatch(1)
+	}
 	if gui.currentWindow != MainWindow || gui.currentModel == "" {
 		return nil
 	}
@@ -237,21 +285,14 @@ func (gui *Gui) addRecord(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	viewer := django.NewDataViewer(gui.project)
-	fields, err := viewer.GetModelFields(gui.currentApp, gui.currentModel)
-	if err != nil {
-		gui.showMessage("Error", fmt.Sprintf("Failed to get model fields: %v", err))
-		return nil
-	}
-
-	editableFields := gui.filterEditableFields(fields)
-	if len(editableFields) == 0 {
-		gui.showMessage("Info", "No editable fields found. This model only has auto-generated fields.")
+	return gui.withModelFields(func(fields []map[string]interface{}) error {
+		editableFields := gui.filterEditableFields(fields)
+		if len(editableFields) == 0 {
+			return gui.showMessage("Info", "No editable fields found. This model only has auto-generated fields.")
+		}
+		gui.openFormModal("add", editableFields, nil)
 		return nil
-	}
-
-	gui.openFormModal("add", editableFields, nil)
-	return nil
+	})
 }
 
 func (gui *Gui) editRecord(g *gocui.Gui, v *gocui.View) error {
@@ -261,25 +302,40 @@ func (gui *Gui) editRecord(g *gocui.Gui, v *gocui.View) error {
 
 	selectedRecord := gui.currentRecords[gui.selectedRecordIdx]
 
-	viewer := django.NewDataViewer(gui.project)
-	fields, err := viewer.GetModelFields(gui.currentApp, gui.currentModel)
-	if err != nil {
-		gui.showMessage("Error", fmt.Sprintf("Failed to get model fields: %v", err))
-		return nil
-	}
+	return gui.withModelFields(func(fields []map[string]interface{}) error {
+		editableFields := gui.filterEditableFields(fields)
+		if len(editableFields) == 0 {
+			return gui.showMessage("Info", "No editable fields found.")
+		}
 
-	editableFields := gui.filterEditableFields(fields)
-	if len(editableFields) == 0 {
-		gui.showMessage("Info", "No editable fields found.")
-		return nil
-	}
+		currentValues := make(map[string]string)
+		for key, value := range selectedRecord.Fields {
+			currentValues[key] = fmt.Sprintf("%v", value)
+		}
 
-	currentValues := make(map[string]string)
-	for key, value := range selectedRecord.Fields {
-		currentValues[key] = fmt.Sprintf("%v", value)
-	}
+		gui.openFormModal("edit", editableFields, currentValues)
+		return nil
+	})
+}
 
-	gui.openFormModal("edit", editableFields, currentValues)
+// withModelFields loads the current model's fields in the background and hands them to then on
+// the UI goroutine, unless the user left the model or opened a modal meanwhile
+func (gui *Gui) withModelFields(then func(fields []map[string]interface{}) error) error {
+	app, model := gui.currentApp, gui.currentModel
+	ctx, op := gui.beginContextOperation(fmt.Sprintf("load %s.%s fields", app, model), "")
+	go func() {
+		fields, err := django.NewDataViewer(gui.project).WithContext(ctx).GetModelFields(app, model)
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			if gui.currentApp != app || gui.currentModel != model || gui.isModalOpen {
+				return nil
+			}
+			if err != nil {
+				return gui.showMessage("Error", fmt.Sprintf("Failed to get model fields: %v", err))
+			}
+			return then(fields)
+		})
+	}()
 	return nil
 }
 
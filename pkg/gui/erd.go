@@ -0,0 +1,131 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const projectDiagramsDirName = "diagrams"
+
+// openERDiagramModal starts the ER export flow from the Database panel.
+func (gui *Gui) openERDiagramModal(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentWindow != ListWindow {
+		return nil
+	}
+	return gui.openProjectActionsModal("ER Diagram: Scope", gui.erdScopeActions())
+}
+
+func (gui *Gui) erdScopeActions() []projectAction {
+	actions := make([]projectAction, 0, 5)
+	models := gui.sortedModels()
+	if len(models) > 0 {
+		model := models[clampSelection(gui.listSelection, len(models))]
+		label := model.App + "." + model.Name
+		actions = append(actions,
+			projectAction{label: fmt.Sprintf("Focus %s (depth 1)", label), internal: "erdscope", value: "focus:1:" + label},
+			projectAction{label: fmt.Sprintf("Focus %s (depth 2)", label), internal: "erdscope", value: "focus:2:" + label},
+			projectAction{label: fmt.Sprintf("App %s", model.App), internal: "erdscope", value: "app:" + model.App},
+		)
+	}
+	return append(actions,
+		projectAction{label: "All apps (hide Django contrib)", internal: "erdscope", value: "project"},
+		projectAction{label: "All apps", internal: "erdscope", value: "all"},
+	)
+}
+
+// parseERDScope converts a scope action value into a diagram filter.
+func parseERDScope(value string) django.ERFilter {
+	parts := strings.SplitN(value, ":", 3)
+	switch parts[0] {
+	case "focus":
+		if len(parts) == 3 {
+			depth, _ := strconv.Atoi(parts[1])
+			return django.ERFilter{Focus: parts[2], Depth: depth, HideContrib: true}
+		}
+	case "app":
+		if len(parts) >= 2 {
+			return django.ERFilter{Apps: []string{parts[1]}}
+		}
+	case "project":
+		return django.ERFilter{HideContrib: true}
+	}
+	return django.ERFilter{}
+}
+
+func (gui *Gui) erdFormatActions() []projectAction {
+	formats := []struct {
+		name  string
+		label string
+	}{
+		{django.ERFormatMermaid, "Mermaid"},
+		{django.ERFormatDOT, "Graphviz DOT"},
+		{django.ERFormatPlantUML, "PlantUML"},
+	}
+
+	actions := make([]projectAction, 0, len(formats)*2)
+	for _, format := range formats {
+		actions = append(actions, projectAction{label: format.label + " in Output tab", internal: "erdshow", value: format.name})
+	}
+	for _, format := range formats {
+		path := filepath.Join(projectStateDirName, projectDiagramsDirName, "erd"+django.ERFileExtension(format.name))
+		actions = append(actions, projectAction{label: fmt.Sprintf("%s -> %s", format.label, path), internal: "erdsave", value: format.name})
+	}
+	return actions
+}
+
+func (gui *Gui) selectERDScope(value string) error {
+	gui.erdFilter = parseERDScope(value)
+	return gui.openProjectActionsModal("ER Diagram: Format", gui.erdFormatActions())
+}
+
+// exportERDiagram renders the selected scope and either shows or saves it.
+func (gui *Gui) exportERDiagram(format string, save bool) error {
+	filter := gui.erdFilter
+	tabID := gui.startCommandOutputTab("ER Diagram")
+	gui.appendOutput(tabID, "Loading model graph...\n")
+	gui.refreshOutputView()
+	_ = gui.switchPanel(MainWindow)
+
+	ctx, op := gui.beginContextOperation("ER diagram", tabID)
+	go func() {
+		var diagram, savedPath string
+		graph, err := django.NewDataViewer(gui.project).WithContext(ctx).GetERGraph()
+		var filtered django.ERGraph
+		if err == nil {
+			filtered, err = graph.Filter(filter)
+		}
+		if err == nil {
+			diagram, err = django.RenderERDiagram(filtered, format)
+		}
+		if err == nil && save {
+			dir := filepath.Join(gui.project.RootDir, projectStateDirName, projectDiagramsDirName)
+			savedPath = filepath.Join(dir, "erd"+django.ERFileExtension(format))
+			if err = os.MkdirAll(dir, 0755); err == nil {
+				err = os.WriteFile(savedPath, []byte(diagram), 0644)
+			}
+		}
+
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			gui.resetOutput(tabID, "ER Diagram")
+			if err != nil {
+				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n", err))
+				gui.rememberError("erd", err.Error())
+			} else {
+				if savedPath != "" {
+					gui.appendOutput(tabID, fmt.Sprintf("Saved %s\n\n", savedPath))
+				}
+				gui.appendOutput(tabID, diagram)
+			}
+			gui.refreshOutputView()
+			return nil
+		})
+	}()
+	return nil
+}
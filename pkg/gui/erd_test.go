@@ -0,0 +1,44 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestParseERDScope(t *testing.T) {
+	filter := parseERDScope("focus:2:blog.Post")
+	if filter.Focus != "blog.Post" || filter.Depth != 2 || !filter.HideContrib {
+		t.Fatalf("unexpected focus filter: %+v", filter)
+	}
+	if filter := parseERDScope("app:shop"); len(filter.Apps) != 1 || filter.Apps[0] != "shop" {
+		t.Fatalf("unexpected app filter: %+v", filter)
+	}
+	if filter := parseERDScope("project"); !filter.HideContrib || filter.Focus != "" {
+		t.Fatalf("unexpected project filter: %+v", filter)
+	}
+	if filter := parseERDScope("all"); filter.HideContrib || len(filter.Apps) != 0 {
+		t.Fatalf("unexpected all filter: %+v", filter)
+	}
+}
+
+func TestERDScopeActionsUseSelectedModel(t *testing.T) {
+	gui := &Gui{project: &django.Project{
+		RootDir: t.TempDir(),
+		Models:  []django.Model{{App: "blog", Name: "Post"}},
+	}}
+
+	actions := gui.erdScopeActions()
+	if len(actions) != 5 {
+		t.Fatalf("expected 5 scope actions, got %d", len(actions))
+	}
+	if actions[0].value != "focus:1:blog.Post" || actions[2].value != "app:blog" {
+		t.Fatalf("unexpected scope actions: %#v", actions[:3])
+	}
+
+	formats := gui.erdFormatActions()
+	if len(formats) != 6 || !strings.HasSuffix(formats[3].label, "erd.mmd") {
+		t.Fatalf("unexpected format actions: %#v", formats)
+	}
+}
@@ -0,0 +1,81 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+var executorBackendLabels = map[string]string{
+	django.ExecutorPython:     "System python",
+	django.ExecutorVenv:       "Virtualenv (.venv, venv, VIRTUAL_ENV)",
+	django.ExecutorUV:         "uv run",
+	django.ExecutorPoetry:     "poetry run",
+	django.ExecutorDockerExec: "docker compose exec",
+	django.ExecutorDockerRun:  "docker compose run --rm",
+	django.ExecutorSSH:        "ssh to remote host",
+}
+
+func (gui *Gui) projectExecutorActions() []projectAction {
+	if gui.project == nil {
+		return nil
+	}
+	current := strings.TrimSpace(gui.project.ExecutorConfig.Backend)
+	active := gui.project.Executor
+	actions := make([]projectAction, 0, len(django.ExecutorBackends)+1)
+
+	label := "Auto-detect"
+	if current == "" || current == "auto" {
+		label += fmt.Sprintf(" (active: %s)", gui.project.ExecutorLabel())
+	}
+	actions = append(actions, projectAction{label: label, internal: "setexecutor", value: "auto"})
+
+	for _, backend := range django.ExecutorBackends {
+		label := executorBackendLabels[backend]
+		if current == backend && active != nil {
+			label += fmt.Sprintf(" (active: %s)", active.Label())
+		}
+		actions = append(actions, projectAction{label: label, internal: "setexecutor", value: backend})
+	}
+	return actions
+}
+
+// setProjectExecutor switches the execution backend and persists it to .lazy-django/config.json.
+func (gui *Gui) setProjectExecutor(backend string) error {
+	if gui.project == nil {
+		return nil
+	}
+
+	cfg, err := config.LoadProjectConfig(gui.project.RootDir)
+	if err != nil {
+		return gui.showMessage("Execution Backend", fmt.Sprintf("Failed to read %s: %v", config.ProjectConfigPath(gui.project.RootDir), err))
+	}
+	next := cfg.Executor
+	next.Backend = backend
+	if backend == "auto" {
+		next.Backend = ""
+	}
+
+	if err := gui.project.ConfigureExecutor(next); err != nil {
+		hint := ""
+		if backend == django.ExecutorSSH {
+			hint = fmt.Sprintf("\n\nSet \"host\" (and optionally \"dir\") under \"executor\" in %s.", config.ProjectConfigPath(gui.project.RootDir))
+		}
+		return gui.showMessage("Execution Backend", fmt.Sprintf("Cannot use %s: %v%s", backend, err, hint))
+	}
+
+	cfg.Executor = next
+	if err := config.SaveProjectConfig(gui.project.RootDir, cfg); err != nil {
+		gui.rememberError("executor", err.Error())
+		return gui.showMessage("Execution Backend", fmt.Sprintf("Switched to %s, but saving the config failed: %v", gui.project.ExecutorLabel(), err))
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:   "executor",
+		Source: "project",
+		Status: "ok",
+		Action: gui.project.Executor.Name(),
+	})
+	return gui.showMessage("Execution Backend", fmt.Sprintf("manage.py now runs via %s.", gui.project.ExecutorLabel()))
+}
@@ -0,0 +1,473 @@
+package gui
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+const (
+	projectExportsDirName = "exports"
+	exportTitle           = "Export Output"
+
+	exportScopeTab       = "tab"
+	exportScopeSelection = "selection"
+	exportScopeAll       = "all"
+)
+
+var exportFormats = []struct {
+	name  string
+	label string
+}{
+	{"md", "Markdown"},
+	{"html", "HTML (keeps colors)"},
+	{"txt", "Plain text"},
+}
+
+// exportTab is one output tab as written to an export, with the recorded outcome of the
+// commands it ran
+type exportTab struct {
+	title  string
+	route  string
+	text   string
+	events []historyEvent
+}
+
+// outputSection is a `$ command` header and the output printed after it. Text before the first
+// header has no command.
+type outputSection struct {
+	command string
+	body    string
+}
+
+var fileSlugPattern = regexp.MustCompile(`[^a-z0-9]+`)
+
+// openExportModal offers the current tab, the selected lines or every tab for export
+func (gui *Gui) openExportModal(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.inputMode != "" || gui.currentModel != "" {
+		return nil
+	}
+	return gui.openExportScopes()
+}
+
+func (gui *Gui) openExportScopes() error {
+	actions := gui.exportScopeActions()
+	if len(actions) == 0 {
+		return gui.showMessage(exportTitle, "No output to export yet.")
+	}
+	return gui.openProjectActionsModal(exportTitle, actions)
+}
+
+func (gui *Gui) exportScopeActions() []projectAction {
+	var actions []projectAction
+	tabID := gui.resolveOutputTabID("", false)
+	if tabID != "" {
+		if gui.outputSelectMode && gui.outputSelectTabID == tabID {
+			start, end := normalizeRange(gui.outputSelectAnchor, gui.outputSelectCursor)
+			actions = append(actions, projectAction{label: fmt.Sprintf("Selection (%d lines)", end-start+1), internal: "exportscope", value: exportScopeSelection})
+		}
+		actions = append(actions, projectAction{label: fmt.Sprintf("Current tab (%s)", gui.outputTitleForTab(tabID)), internal: "exportscope", value: exportScopeTab})
+	}
+	if count := len(gui.orderedOutputTabIDsForPicker()); count > 0 {
+		actions = append(actions, projectAction{label: fmt.Sprintf("All tabs (%d)", count), internal: "exportscope", value: exportScopeAll})
+	}
+	return actions
+}
+
+func (gui *Gui) exportFormatActions(scope string) []projectAction {
+	actions := make([]projectAction, 0, len(exportFormats))
+	for _, format := range exportFormats {
+		path := filepath.Join(projectStateDirName, projectExportsDirName, "*."+format.name)
+		actions = append(actions, projectAction{label: fmt.Sprintf("%s -> %s", format.label, path), internal: "exportsave", value: scope + ":" + format.name})
+	}
+	return actions
+}
+
+// exportOutput writes the chosen scope ("scope:format") under .lazy-django/exports/
+func (gui *Gui) exportOutput(value string) error {
+	scope, format, _ := strings.Cut(value, ":")
+	tabs := gui.exportTabs(scope)
+	if len(tabs) == 0 {
+		return gui.showMessage(exportTitle, "No output to export.")
+	}
+
+	now := time.Now()
+	name := "session"
+	if scope != exportScopeAll {
+		name = tabs[0].title
+	}
+	content := renderExport(tabs, format, filepath.Base(gui.project.RootDir), now)
+	path := filepath.Join(gui.project.RootDir, projectStateDirName, projectExportsDirName, exportFileName(name, format, now))
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err == nil {
+		err = os.WriteFile(path, []byte(content), 0644)
+	}
+	if err != nil {
+		gui.rememberError("export", err.Error())
+		return gui.showMessage(exportTitle, fmt.Sprintf("Failed to export: %v", err))
+	}
+	return gui.showMessage(exportTitle, fmt.Sprintf("Saved %s", path))
+}
+
+// exportTabs collects the tabs of a scope, oldest first, with their command events
+func (gui *Gui) exportTabs(scope string) []exportTab {
+	var ids []string
+	switch scope {
+	case exportScopeAll:
+		for _, id := range gui.outputOrder {
+			if _, ok := gui.outputTabs[id]; ok {
+				ids = append(ids, id)
+			}
+		}
+	default:
+		if id := gui.resolveOutputTabID("", false); id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	var events []historyEvent
+	if gui.historyStore != nil {
+		events, _ = gui.historyStore.tail(maxPersistedHistoryEvents)
+	}
+	tabs := make([]exportTab, 0, len(ids))
+	for _, id := range ids {
+		tab := gui.outputTabs[id]
+		text := tab.text
+		if scope == exportScopeSelection && gui.outputSelectMode && gui.outputSelectTabID == id {
+			start, end := normalizeRange(gui.outputSelectAnchor, gui.outputSelectCursor)
+			text = gui.outputRowsRawText(id, start, end)
+		}
+		tabEvents := tabCommandEvents(events, id, tab.created)
+		tabs = append(tabs, exportTab{title: gui.outputTitleForTab(id), route: tab.route, text: text, events: tabEvents})
+	}
+	return tabs
+}
+
+// tabCommandEvents returns the command events of tab id recorded since it was created, so
+// history from an earlier session that reused the same ID is left out
+func tabCommandEvents(events []historyEvent, id string, created time.Time) []historyEvent {
+	since := created.UTC().Truncate(time.Second)
+	var tabEvents []historyEvent
+	for _, event := range events {
+		if event.Type != "command" || event.OutputTab != id {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, event.Time)
+		if err != nil || at.Before(since) {
+			continue
+		}
+		tabEvents = append(tabEvents, event)
+	}
+	return tabEvents
+}
+
+func exportFileName(name, format string, now time.Time) string {
+	slug := strings.Trim(fileSlugPattern.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if slug == "" {
+		slug = "output"
+	}
+	return fmt.Sprintf("%s-%s.%s", now.Format("20060102-150405"), truncateRunes(slug, 40), format)
+}
+
+// renderExport renders tabs as plain text, Markdown or a standalone HTML page
+func renderExport(tabs []exportTab, format, projectName string, now time.Time) string {
+	heading := "LazyDjango output"
+	if projectName != "" {
+		heading += ": " + projectName
+	}
+	generated := "Exported " + now.Format("2006-01-02 15:04:05 MST")
+	var b strings.Builder
+	switch format {
+	case "md":
+		fmt.Fprintf(&b, "# %s\n\n%s\n", heading, generated)
+		for _, tab := range tabs {
+			fmt.Fprintf(&b, "\n## %s\n", tab.title)
+			for _, section := range splitOutputSections(cleanExportText(tab.text)) {
+				if section.command != "" {
+					fmt.Fprintf(&b, "\n%s\n", markdownCodeBlock("console", "$ "+section.command))
+					if event, ok := lastCommandEvent(tab.events, section.command); ok {
+						fmt.Fprintf(&b, "\n%s\n", commandStatusLabel(event))
+					}
+				}
+				if body := strings.Trim(stripANSI(section.body), "\n"); body != "" {
+					fmt.Fprintf(&b, "\n%s\n", markdownCodeBlock("text", body))
+				}
+			}
+		}
+	case "html":
+		fmt.Fprintf(&b, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>%s</title>\n", html.EscapeString(heading))
+		b.WriteString("<style>\n" +
+			"body { background: #1e1e1e; color: #d4d4d4; font-family: sans-serif; margin: 2em; }\n" +
+			"pre { background: #111; padding: 1em; overflow-x: auto; font-family: monospace; white-space: pre-wrap; }\n" +
+			".cmd { color: #fff; font-weight: bold; }\n" +
+			".status { font-family: monospace; }\n" +
+			".ok { color: #4ec94e; } .failed { color: #f14c4c; } .muted { color: #999; }\n" +
+			"</style>\n</head>\n<body>\n")
+		fmt.Fprintf(&b, "<h1>%s</h1>\n<p class=\"muted\">%s</p>\n", html.EscapeString(heading), html.EscapeString(generated))
+		for _, tab := range tabs {
+			fmt.Fprintf(&b, "<h2>%s</h2>\n", html.EscapeString(tab.title))
+			for _, section := range splitOutputSections(cleanExportText(tab.text)) {
+				if section.command != "" {
+					fmt.Fprintf(&b, "<pre class=\"cmd\">$ %s</pre>\n", html.EscapeString(section.command))
+					if event, ok := lastCommandEvent(tab.events, section.command); ok {
+						class := "ok"
+						if event.Status != "success" {
+							class = "failed"
+						}
+						fmt.Fprintf(&b, "<p class=\"status %s\">%s</p>\n", class, html.EscapeString(commandStatusLabel(event)))
+					}
+				}
+				if body := strings.Trim(section.body, "\n"); body != "" {
+					fmt.Fprintf(&b, "<pre>%s</pre>\n", ansiToHTML(body))
+				}
+			}
+		}
+		b.WriteString("</body>\n</html>\n")
+	default:
+		fmt.Fprintf(&b, "%s\n%s\n", heading, generated)
+		for _, tab := range tabs {
+			fmt.Fprintf(&b, "\n===== %s =====\n\n", tab.title)
+			for _, section := range splitOutputSections(cleanExportText(tab.text)) {
+				if section.command != "" {
+					fmt.Fprintf(&b, "$ %s\n", section.command)
+					if event, ok := lastCommandEvent(tab.events, section.command); ok {
+						fmt.Fprintf(&b, "[%s]\n", commandStatusLabel(event))
+					}
+				}
+				if body := strings.Trim(stripANSI(section.body), "\n"); body != "" {
+					fmt.Fprintf(&b, "%s\n\n", body)
+				}
+			}
+		}
+	}
+	return b.String()
+}
+
+// cleanExportText normalizes line endings and drops control characters other than color codes
+func cleanExportText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+	return strings.Map(func(r rune) rune {
+		if r == '\n' || r == '\t' || r == '\x1b' || unicode.IsPrint(r) {
+			return r
+		}
+		return -1
+	}, text)
+}
+
+// splitOutputSections splits output at the `$ command` lines written when a command starts
+func splitOutputSections(text string) []outputSection {
+	var sections []outputSection
+	current := outputSection{}
+	var body []string
+	flush := func() {
+		current.body = strings.Join(body, "\n")
+		if current.command != "" || strings.TrimSpace(current.body) != "" {
+			sections = append(sections, current)
+		}
+		body = nil
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(line, "$ ") {
+			flush()
+			current = outputSection{command: strings.TrimSpace(strings.TrimPrefix(line, "$ "))}
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+	return sections
+}
+
+// lastCommandEvent finds the last recorded run of command in a tab's events
+func lastCommandEvent(events []historyEvent, command string) (historyEvent, bool) {
+	command = sanitizeCommand(command)
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Command == command {
+			return events[i], true
+		}
+	}
+	return historyEvent{}, false
+}
+
+func commandStatusLabel(event historyEvent) string {
+	label := fmt.Sprintf("Exit status: %d (%s", event.ExitCode, dashIfEmpty(event.Status))
+	if event.DurationMS > 0 {
+		label += ", " + formatTestDuration(time.Duration(event.DurationMS)*time.Millisecond)
+	}
+	return label + ")"
+}
+
+// markdownCodeBlock fences text with more backticks than it contains in a row
+func markdownCodeBlock(lang, text string) string {
+	longest, run := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			run++
+			longest = max(longest, run)
+		} else {
+			run = 0
+		}
+	}
+	fence := strings.Repeat("`", max(3, longest+1))
+	return fence + lang + "\n" + text + "\n" + fence
+}
+
+// ansiPalette holds the 16 standard terminal colors, normal then bright
+var ansiPalette = [16]string{
+	"#000000", "#cd3131", "#0dbc79", "#e5e510", "#2472c8", "#bc3fbc", "#11a8cd", "#e5e5e5",
+	"#666666", "#f14c4c", "#23d18b", "#f5f543", "#3b8eea", "#d670d6", "#29b8db", "#ffffff",
+}
+
+type ansiStyle struct {
+	fg, bg                       string
+	bold, dim, italic, underline bool
+}
+
+func (s ansiStyle) css() string {
+	var parts []string
+	if s.fg != "" {
+		parts = append(parts, "color:"+s.fg)
+	}
+	if s.bg != "" {
+		parts = append(parts, "background:"+s.bg)
+	}
+	if s.bold {
+		parts = append(parts, "font-weight:bold")
+	}
+	if s.dim {
+		parts = append(parts, "opacity:0.7")
+	}
+	if s.italic {
+		parts = append(parts, "font-style:italic")
+	}
+	if s.underline {
+		parts = append(parts, "text-decoration:underline")
+	}
+	return strings.Join(parts, ";")
+}
+
+// ansiToHTML escapes text and turns SGR color codes (16, 256 and true color) into styled spans
+func ansiToHTML(text string) string {
+	var b strings.Builder
+	var style ansiStyle
+	open := false
+	last := 0
+	for _, loc := range ansiEscapePattern.FindAllStringIndex(text, -1) {
+		b.WriteString(html.EscapeString(text[last:loc[0]]))
+		last = loc[1]
+		style = applySGR(style, text[loc[0]+2:loc[1]-1])
+		if open {
+			b.WriteString("</span>")
+			open = false
+		}
+		if css := style.css(); css != "" {
+			fmt.Fprintf(&b, "<span style=\"%s\">", css)
+			open = true
+		}
+	}
+	b.WriteString(html.EscapeString(text[last:]))
+	if open {
+		b.WriteString("</span>")
+	}
+	return b.String()
+}
+
+func applySGR(style ansiStyle, params string) ansiStyle {
+	if params == "" {
+		return ansiStyle{}
+	}
+	codes := strings.Split(params, ";")
+	for i := 0; i < len(codes); i++ {
+		code, err := strconv.Atoi(codes[i])
+		if err != nil {
+			continue
+		}
+		switch {
+		case code == 0:
+			style = ansiStyle{}
+		case code == 1:
+			style.bold = true
+		case code == 2:
+			style.dim = true
+		case code == 3:
+			style.italic = true
+		case code == 4:
+			style.underline = true
+		case code == 22:
+			style.bold, style.dim = false, false
+		case code == 23:
+			style.italic = false
+		case code == 24:
+			style.underline = false
+		case code >= 30 && code <= 37:
+			style.fg = ansiPalette[code-30]
+		case code >= 90 && code <= 97:
+			style.fg = ansiPalette[code-90+8]
+		case code == 39:
+			style.fg = ""
+		case code >= 40 && code <= 47:
+			style.bg = ansiPalette[code-40]
+		case code >= 100 && code <= 107:
+			style.bg = ansiPalette[code-100+8]
+		case code == 49:
+			style.bg = ""
+		case code == 38 || code == 48:
+			color, used := extendedColor(codes[i+1:])
+			i += used
+			if code == 38 {
+				style.fg = color
+			} else {
+				style.bg = color
+			}
+		}
+	}
+	return style
+}
+
+// extendedColor reads the 5;n or 2;r;g;b arguments of a 38/48 code, returning how many it used
+func extendedColor(args []string) (string, int) {
+	if len(args) >= 2 && args[0] == "5" {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 0 || n > 255 {
+			return "", 2
+		}
+		return xterm256Color(n), 2
+	}
+	if len(args) >= 4 && args[0] == "2" {
+		var rgb [3]int
+		for i := range rgb {
+			rgb[i], _ = strconv.Atoi(args[i+1])
+			rgb[i] = min(max(rgb[i], 0), 255)
+		}
+		return fmt.Sprintf("#%02x%02x%02x", rgb[0], rgb[1], rgb[2]), 4
+	}
+	return "", len(args)
+}
+
+func xterm256Color(n int) string {
+	switch {
+	case n < 16:
+		return ansiPalette[n]
+	case n < 232:
+		n -= 16
+		level := func(v int) int {
+			if v == 0 {
+				return 0
+			}
+			return 55 + v*40
+		}
+		return fmt.Sprintf("#%02x%02x%02x", level(n/36), level(n/6%6), level(n%6))
+	default:
+		gray := 8 + (n-232)*10
+		return fmt.Sprintf("#%02x%02x%02x", gray, gray, gray)
+	}
+}
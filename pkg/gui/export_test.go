@@ -0,0 +1,137 @@
+package gui
+
+import (
+	"io"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestSplitOutputSections(t *testing.T) {
+	sections := splitOutputSections("Loading...\n$ make lint\nok\n$ make test\n")
+	if len(sections) != 3 || sections[0].command != "" || sections[1].command != "make lint" || sections[1].body != "ok" || sections[2].command != "make test" {
+		t.Fatalf("unexpected sections: %+v", sections)
+	}
+}
+
+func TestRenderExportFormats(t *testing.T) {
+	tabs := []exportTab{{
+		title: "migrate",
+		route: OutputTabCommand,
+		text:  "$ python manage.py migrate\n\n\x1b[32mApplying blog.0002... OK\x1b[0m\r\n$ make test\n\nran ```x```\n",
+		events: []historyEvent{
+			{Type: "command", Command: "python manage.py migrate", Status: "error", ExitCode: 1},
+			{Type: "command", Command: "python manage.py migrate", Status: "success", ExitCode: 0, DurationMS: 1500},
+		},
+	}}
+
+	markdown := renderExport(tabs, "md", "demo", time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC))
+	for _, want := range []string{
+		"# LazyDjango output: demo\n",
+		"## migrate\n",
+		"```console\n$ python manage.py migrate\n```\n\nExit status: 0 (success, 1.50s)\n",
+		"```text\nApplying blog.0002... OK\n```",
+		"```console\n$ make test\n```\n\n````text\nran ```x```\n````",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Fatalf("expected %q in markdown:\n%s", want, markdown)
+		}
+	}
+	if strings.Contains(markdown, "\x1b") {
+		t.Fatalf("expected colors to be stripped from markdown")
+	}
+
+	html := renderExport(tabs, "html", "demo", time.Now())
+	if !strings.Contains(html, `<span style="color:#0dbc79">Applying blog.0002... OK</span>`) {
+		t.Fatalf("expected colored span in html:\n%s", html)
+	}
+	if !strings.Contains(html, `<p class="status ok">Exit status: 0 (success, 1.50s)</p>`) || !strings.HasPrefix(html, "<!DOCTYPE html>") {
+		t.Fatalf("unexpected html:\n%s", html)
+	}
+
+	text := renderExport(tabs, "txt", "", time.Now())
+	if !strings.Contains(text, "===== migrate =====\n\n$ python manage.py migrate\n[Exit status: 0 (success, 1.50s)]\nApplying blog.0002... OK\n") {
+		t.Fatalf("unexpected text export:\n%s", text)
+	}
+}
+
+func TestAnsiToHTML(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{input: "a < b", want: "a &lt; b"},
+		{input: "\x1b[1;31merr\x1b[0m done", want: `<span style="color:#cd3131;font-weight:bold">err</span> done`},
+		{input: "\x1b[38;5;196mx\x1b[39m", want: `<span style="color:#ff0000">x</span>`},
+		{input: "\x1b[48;2;1;2;3my", want: `<span style="background:#010203">y</span>`},
+	}
+	for _, tc := range tests {
+		if got := ansiToHTML(tc.input); got != tc.want {
+			t.Fatalf("ansiToHTML(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestExportFileName(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC)
+	if got := exportFileName("Tests: blog/Post!", "md", now); got != "20260304-123000-tests-blog-post.md" {
+		t.Fatalf("unexpected file name: %q", got)
+	}
+}
+
+func TestTabCommandEventsSkipEarlierSessions(t *testing.T) {
+	created := time.Date(2026, 3, 4, 12, 30, 0, 500, time.UTC)
+	events := []historyEvent{
+		{Time: "2026-03-03T09:00:00Z", Type: "command", Command: "check", Status: "error", OutputTab: "command-001"},
+		{Time: "2026-03-04T12:30:00Z", Type: "command", Command: "check", Status: "success", OutputTab: "command-001"},
+		{Time: "2026-03-04T12:31:00Z", Type: "cancel", OutputTab: "command-001"},
+		{Time: "2026-03-04T12:32:00Z", Type: "command", Command: "migrate", OutputTab: "command-002"},
+	}
+	got := tabCommandEvents(events, "command-001", created)
+	if len(got) != 1 || got[0].Status != "success" {
+		t.Fatalf("expected only this session's check run, got %+v", got)
+	}
+}
+
+func TestStreamedColorsReachHTMLExport(t *testing.T) {
+	g, err := gocui.NewGui(gocui.OutputSimulator, true)
+	if err != nil {
+		t.Fatalf("failed to create simulated gui: %v", err)
+	}
+	screen := g.GetTestingScreen()
+	stop := screen.StartGui()
+	defer stop()
+
+	gui := &Gui{
+		g:                  g,
+		project:            &django.Project{RootDir: t.TempDir()},
+		outputTabs:         make(map[string]*outputTabState),
+		outputInputWriters: make(map[string]io.WriteCloser),
+	}
+	done := make(chan struct{})
+	g.Update(func(g *gocui.Gui) error {
+		cmd := exec.Command("sh", "-c", `printf '\033[31mFAILED\033[0m \033[?25lcheck\n'`)
+		return gui.runStreamingCommandToRoute(OutputTabCommand, "check", cmd, nil, "check", func(*gocui.Gui, error) {
+			close(done)
+		})
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the command to finish")
+	}
+
+	exported := make(chan string, 1)
+	g.Update(func(g *gocui.Gui) error {
+		exported <- renderExport(gui.exportTabs(exportScopeAll), "html", "demo", time.Now())
+		return nil
+	})
+	got := <-exported
+	if !strings.Contains(got, `<span style="color:#cd3131">FAILED</span> check`) {
+		t.Fatalf("expected streamed colors in html export:\n%s", got)
+	}
+}
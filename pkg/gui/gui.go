@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +14,7 @@ import (
 	"regexp"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -44,7 +44,17 @@ type Gui struct {
 	favoriteCommands        []string
 	recentModels            []persistedRecentModel
 	recentErrors            []persistedRecentError
-	serverCmd               *exec.Cmd
+	servers                 map[string]*devServer      // supervised servers by profile name
+	processes               map[string]*managedProcess // Procfile/configured processes by name
+	tests                   testRun
+	routes                  routesView
+	commands                commandBrowser
+	history                 historyView
+	frameTabID              string // output tab of the selected traceback frame
+	frameIndex              int
+	serverOrder             []string
+	operations              []*runningOperation // cancellable background work, newest last
+	nextOperationID         int
 	appVersion              string
 	updateChecked           bool
 	updateChecking          bool
@@ -77,17 +87,28 @@ type Gui struct {
 	modelOriginY  int
 
 	// Cached left-panel metadata
-	snapshotCache     []*django.Snapshot
-	snapshotErr       error
-	snapshotLoaded    bool
-	containerStatus   map[string]string
-	makeTargets       []makeTarget
-	makeTargetsErr    error
-	makeTargetsLoaded bool
-	projectTasks      []projectTaskEntry
-	projectTasksErr   error
-	projectTasksPath  string
-	projectTasksReady bool
+	snapshotCache         []*django.Snapshot
+	snapshotErr           error
+	snapshotLoaded        bool
+	containerStatus       map[string]string
+	makeTargets           []makeTarget
+	makeTargetsErr        error
+	makeTargetsLoaded     bool
+	projectTasks          []projectTaskEntry
+	projectTasksErr       error
+	projectTasksPath      string
+	projectTasksReady     bool
+	migrationHealth       *django.MigrationHealth
+	migrationChecking     bool
+	migrationCheckPending bool
+	squashApp             string
+	squashStart           string
+	squashPlan            *django.SquashPlan
+	squashGenerated       string // squashed migration awaiting keep/discard
+	requestSavedName      string // saved request the request composer was opened from
+	requestReplaceName    string // saved request the user confirmed replacing
+	pruneCandidates       []django.PrunableSquash
+	pruneSelected         *django.PrunableSquash
 
 	// Data viewer state
 	currentApp        string
@@ -96,16 +117,25 @@ type Gui struct {
 	currentPage       int
 	selectedRecordIdx int
 	totalRecords      int
+	recordsHasNext    bool
+	recordsOp         *runningOperation // in-flight page query, superseded by the next one
 	pageSize          int
+	schemaApp         string
+	schemaModel       string
+	schemaShowDDL     bool
+	schemaTabID       string // output tab reused by every schema view
+	erdFilter         django.ERFilter
 
 	// Modal state
 	isModalOpen         bool
-	modalType           string // "add", "edit", "delete", "restore", "containers", "projectActions", "outputTabs"
+	modalType           string // "add", "edit", "request", "command", "delete", "restore", "containers", "projectActions", "outputTabs"
 	modalReturnWindow   string
 	modalFields         []map[string]interface{}
 	modalFieldIdx       int
 	modalValues         map[string]string
 	modalMessage        string
+	modalBusy           string // background work the form is waiting on, e.g. saving the record
+	pickerSeq           int    // bumped per value picker so late "load more" results are dropped
 	modalTitle          string
 	restoreSnapshots    []*django.Snapshot
 	restoreIndex        int
@@ -124,6 +154,7 @@ type Gui struct {
 	inputMode         string // "", "command", "search"
 	inputReturnWindow string
 	inputTargetTabID  string
+	commandBar        commandBarState
 }
 
 // Window names.
@@ -136,6 +167,8 @@ const (
 	FooterWindow     = "footer"
 	ModalWindow      = "modal"
 	ModalInputWindow = "modalInput"
+	// CommandGhostWindow overlays the completion suggestion after the command bar cursor
+	CommandGhostWindow = "commandGhost"
 )
 
 const (
@@ -161,6 +194,7 @@ type projectAction struct {
 	internal     string
 	makeTarget   string
 	shellCommand string
+	value        string // payload for internal actions (e.g. selected app or migration)
 }
 
 type makeTarget struct {
@@ -177,6 +211,9 @@ type outputTabState struct {
 	autoscroll bool
 	originX    int
 	originY    int
+	created    time.Time     // when this session opened the tab; tab IDs restart every session
+	logView    *logViewState // Logs route parsing and filters; nil until first used
+	search     *outputSearch // active / search; nil when not searching
 }
 
 func clampSelection(selection, count int) int {
@@ -231,13 +268,18 @@ func (gui *Gui) stylePanelView(v *gocui.View, windowName string) {
 func (gui *Gui) projectActions() []projectAction {
 	actions := []projectAction{
 		{label: "Server...", internal: "openserver"},
+		{label: "Processes...", internal: "openprocesses"},
+		{label: "Tests...", internal: "opentests"},
 	}
 
 	if gui.project != nil && gui.project.HasDocker && gui.project.DockerComposeFile != "" {
 		actions = append(actions, projectAction{label: "Containers...", internal: "opencontainers"})
 	}
 
-	actions = append(actions, projectAction{label: "Project Tasks...", internal: "openprojecttasks"})
+	actions = append(actions,
+		projectAction{label: "Project Tasks...", internal: "openprojecttasks"},
+		projectAction{label: "Execution Backend...", internal: "openexecutor"},
+	)
 	if len(gui.projectFavoriteActions()) > 0 {
 		actions = append(actions, projectAction{label: "Favorites...", internal: "openfavorites"})
 	}
@@ -253,23 +295,18 @@ func (gui *Gui) projectActions() []projectAction {
 	return actions
 }
 
-func (gui *Gui) projectServerActions() []projectAction {
-	return []projectAction{
-		{label: "Start dev server", internal: "runserver"},
-		{label: "Stop dev server", internal: "stopserver"},
-	}
-}
-
 func (gui *Gui) projectContainerActions() []projectAction {
 	return []projectAction{
 		{label: "Start selected services...", internal: "startcontainers"},
 		{label: "Stop selected services...", internal: "stopcontainers"},
+		{label: "Services (logs, restart, rebuild, shell)...", internal: "openservices"},
+		{label: "Health, restarts and resource usage", internal: "containerdetails"},
 		{label: "Refresh container status", internal: "refresh"},
 	}
 }
 
 func (gui *Gui) projectMigrationActions() []projectAction {
-	actions := make([]projectAction, 0, 4)
+	actions := make([]projectAction, 0, 8)
 	for _, target := range []string{"showmigrations", "migrations", "migrate"} {
 		t, ok := gui.makeTargetByName(target)
 		if !ok {
@@ -280,24 +317,35 @@ func (gui *Gui) projectMigrationActions() []projectAction {
 			makeTarget: t.name,
 		})
 	}
-	if len(actions) > 0 {
-		return actions
-	}
-
-	applied, total := gui.migrationSummary()
-	return []projectAction{
-		{label: fmt.Sprintf("Show migrations (%d/%d applied)", applied, total), command: "showmigrations --list"},
-		{label: "Make migrations", command: "makemigrations"},
-		{label: "Apply migrations", command: "migrate"},
-	}
+	if len(actions) == 0 {
+		applied, total := gui.migrationSummary()
+		actions = append(actions,
+			projectAction{label: fmt.Sprintf("Show migrations (%d/%d applied)", applied, total), command: "showmigrations --list"},
+			projectAction{label: "Make migrations", command: "makemigrations"},
+			projectAction{label: "Apply migrations", command: "migrate"},
+		)
+	}
+
+	return append(actions,
+		projectAction{label: "Merge conflicting migrations", internal: "mergemigrations"},
+		projectAction{label: "Check migration health", internal: "checkmigrations"},
+		projectAction{label: "Preview SQL for unapplied migrations", internal: "sqlpreview"},
+		projectAction{label: "Squash migrations...", internal: "opensquash"},
+		projectAction{label: "Prune squashed migrations...", internal: "openprune"},
+	)
 }
 
 func (gui *Gui) projectToolActions() []projectAction {
 	return []projectAction{
 		projectAction{label: "Django check", command: "check"},
-		projectAction{label: "Show URL patterns", internal: "showurls"},
+		projectAction{label: "Show URL routes", internal: "showurls"},
+		projectAction{label: "HTTP requests...", internal: "openrequests"},
+		projectAction{label: "Management commands", internal: "showcommands"},
 		projectAction{label: "Dependency doctor", internal: "doctor"},
+		projectAction{label: "History browser", internal: "historyview"},
 		projectAction{label: "History report", internal: "historyreport"},
+		projectAction{label: "Command analytics...", internal: "openanalytics"},
+		projectAction{label: "Export output...", internal: "openexport"},
 		projectAction{label: "Refresh project data", internal: "refresh"},
 	}
 }
@@ -521,49 +569,9 @@ func (gui *Gui) moveSelection(delta int) {
 
 func parseComposePSOutput(output string) map[string]string {
 	status := make(map[string]string)
-	type containerStatus struct {
-		Service string `json:"Service"`
-		State   string `json:"State"`
-		Status  string `json:"Status"`
-	}
-
-	trimmed := strings.TrimSpace(output)
-	if trimmed == "" {
-		return status
+	for _, c := range parseComposePSContainers(output) {
+		status[c.Service] = c.State
 	}
-
-	var containers []containerStatus
-	if strings.HasPrefix(trimmed, "[") {
-		if err := json.Unmarshal([]byte(trimmed), &containers); err != nil {
-			return status
-		}
-	} else {
-		for _, line := range strings.Split(trimmed, "\n") {
-			line = strings.TrimSpace(line)
-			if line == "" {
-				continue
-			}
-			var c containerStatus
-			if err := json.Unmarshal([]byte(line), &c); err == nil {
-				containers = append(containers, c)
-			}
-		}
-	}
-
-	for _, c := range containers {
-		if c.Service == "" {
-			continue
-		}
-		state := c.State
-		if state == "" {
-			state = c.Status
-		}
-		if state == "" {
-			state = "unknown"
-		}
-		status[c.Service] = strings.ToLower(state)
-	}
-
 	return status
 }
 
@@ -573,20 +581,54 @@ func stripANSI(s string) string {
 	return ansiEscapePattern.ReplaceAllString(s, "")
 }
 
+// terminalSGR rewrites color codes to what the Output panel can draw in 8-color mode: bright
+// colors fall back to their normal ones and 256/true colors are dropped
+func terminalSGR(s string) string {
+	return ansiEscapePattern.ReplaceAllStringFunc(s, func(seq string) string {
+		codes := strings.Split(seq[2:len(seq)-1], ";")
+		kept := make([]string, 0, len(codes))
+		for i := 0; i < len(codes); i++ {
+			code, err := strconv.Atoi(codes[i])
+			switch {
+			case err != nil:
+				kept = append(kept, "0")
+			case code == 38 || code == 48:
+				_, used := extendedColor(codes[i+1:])
+				i += used
+			case (code >= 90 && code <= 97) || (code >= 100 && code <= 107):
+				kept = append(kept, strconv.Itoa(code-60))
+			default:
+				kept = append(kept, codes[i])
+			}
+		}
+		if len(kept) == 0 {
+			return ""
+		}
+		return "\x1b[" + strings.Join(kept, ";") + "m"
+	})
+}
+
 var ansiControlPattern = regexp.MustCompile(`\x1b(?:\[[0-?]*[ -/]*[@-~]|\][^\x07]*(?:\x07|\x1b\\)|[@-Z\\-_])`)
 
 var interactivePromptPattern = regexp.MustCompile(`(?i)^(email address|username|password|confirm password|new password|old password|enter .+):\s*$`)
 
+// sanitizeOutputForDisplay drops cursor movement, titles and other control sequences but keeps
+// color codes, which search, copy and plain exports strip on their own
 func sanitizeOutputForDisplay(text string) string {
 	if text == "" {
 		return ""
 	}
-	text = ansiControlPattern.ReplaceAllString(text, "")
+	text = ansiControlPattern.ReplaceAllStringFunc(text, func(seq string) string {
+		if ansiEscapePattern.MatchString(seq) {
+			return seq
+		}
+		return ""
+	})
 	text = strings.ReplaceAll(text, "\r\n", "\n")
 	text = strings.ReplaceAll(text, "\r", "\n")
 	text = strings.Map(func(r rune) rune {
 		switch {
-		case r == '\n' || r == '\t':
+		case r == '\n' || r == '\t' || r == '\x1b':
 			return r
 		case unicode.IsPrint(r):
 			return r
@@ -808,6 +850,13 @@ func NewGui(project *django.Project) (*Gui, error) {
 		currentPage:        1,
 	}
 
+	project.DockerServiceFound = func(service string) {
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.project.PromoteDockerService(service)
+			return nil
+		})
+	}
+
 	g.Highlight = false
 	g.Cursor = false
 	g.FgColor = gocui.ColorWhite
@@ -971,6 +1020,7 @@ func (gui *Gui) layout(g *gocui.Gui) error {
 		return gui.layoutInputPrompt(g, maxX, maxY)
 	}
 	g.DeleteView(ModalInputWindow)
+	g.DeleteView(CommandGhostWindow)
 
 	if _, err := g.SetCurrentView(gui.currentWindow); err != nil && err != gocui.ErrUnknownView {
 		return err
@@ -1182,6 +1232,7 @@ func (gui *Gui) startStartupHydration() {
 			}
 			gui.startupHydrationDone = true
 			gui.clampSelections()
+			gui.startMigrationHealthCheck()
 
 			if menuView, err := g.View(MenuWindow); err == nil {
 				gui.renderProjectList(menuView)
@@ -1382,6 +1433,7 @@ func (gui *Gui) startOutputTab(route, title string, autoscroll bool) string {
 		title:      title,
 		autoscroll: autoscroll,
 		originY:    originY,
+		created:    time.Now(),
 	}
 	gui.outputOrder = append(gui.outputOrder, id)
 	gui.outputRoutes[route] = id
@@ -1429,7 +1481,7 @@ func (gui *Gui) freezeOutputTabAtCurrentPosition(tabID string) {
 		return
 	}
 
-	lines := outputLines(gui.outputTextForTab(tabID))
+	lines := gui.outputViewLines(tabID)
 	if len(lines) == 0 {
 		tab.autoscroll = false
 		tab.originX = 0
@@ -1564,9 +1616,25 @@ func (gui *Gui) renderOutputView(v *gocui.View) {
 		if gui.project != nil {
 			fmt.Fprintf(v, "Project: %s\n", gui.project.RootDir)
 			fmt.Fprintf(v, "Apps: %d  Models: %d  Database: %s\n", len(gui.project.InstalledApps), len(gui.project.Models), gui.databaseLabel())
+			if source := gui.project.Database.Source; source != "" {
+				fmt.Fprintf(v, "Database config: %s\n", source)
+			}
+			if options := gui.project.Database.OptionsString(); options != "" {
+				fmt.Fprintf(v, "Database options: %s\n", options)
+			}
+			if source := gui.project.Database.PasswordSource; source != "" {
+				fmt.Fprintf(v, "Database password: from %s\n", source)
+			}
+			fmt.Fprintf(v, "Executor: %s\n", gui.project.ExecutorLabel())
+			if gui.project.ExecutorError != "" {
+				fmt.Fprintf(v, "Executor config error: %s\n", gui.project.ExecutorError)
+			}
 			if !gui.startupHydrationDone {
 				fmt.Fprintln(v, "Metadata: loading...")
 			}
+			if gui.project.ComposeError != "" {
+				fmt.Fprintf(v, "Compose config error: %s\n", gui.project.ComposeError)
+			}
 			if gui.project.HasDocker {
 				fmt.Fprintln(v, "Docker: configured")
 			} else {
@@ -1608,6 +1676,14 @@ func (gui *Gui) renderOutputView(v *gocui.View) {
 	totalTabs := len(gui.outputOrder)
 	tabIndex := gui.outputTabPosition(tabID) + 1
 	title := fmt.Sprintf("%s [%s %d/%d %s]", gui.outputTitleForTab(tabID), outputRouteLabel(tab.route), tabIndex, totalTabs, outputFollowLabel(following))
+	if label := tab.logView.label(); label != "" {
+		title += " {" + label + "}"
+	}
+	var matches []int
+	if tab.search != nil {
+		matches = gui.searchMatches(tabID)
+		title += " {" + tab.search.label(matches) + "}"
+	}
 	gui.mainTitle = title
 	v.Title = gui.panelTitle(MainWindow, title)
 
@@ -1621,9 +1697,37 @@ func (gui *Gui) renderOutputView(v *gocui.View) {
 		fmt.Fprintln(v, "Use [ and ] to switch tabs, f to toggle tail/hold, x to close, Ctrl+L to clear.")
 		return
 	}
-	lines := outputLines(text)
-	for _, line := range lines {
-		fmt.Fprintln(v, line)
+	rows := gui.outputRows(tabID)
+	lines := make([]string, len(rows))
+	_, height := v.Size()
+	firstVisible, lastVisible := visibleOutputRows(tab, height, len(rows), following)
+	currentStart := -1
+	if tab.search != nil && len(matches) > 0 {
+		if base := gui.baseOutputRows(tabID); tab.search.current >= 0 && tab.search.current < len(base) {
+			currentStart = base[tab.search.current].start
+		}
+	}
+	for i, row := range rows {
+		lines[i] = row.text
+		text := row.text
+		if tab.search != nil && i >= firstVisible && i < lastVisible && row.end >= row.start {
+			highlight := ansiSearchMatch
+			if row.start == currentStart {
+				highlight = ansiSearchCurrent
+			}
+			text = highlightMatches(text, tab.search.pattern, highlight, row.color)
+		}
+		text = terminalSGR(text)
+		if row.color != "" {
+			fmt.Fprintln(v, row.color+text+ansiReset)
+		} else {
+			fmt.Fprintln(v, text)
+		}
+	}
+	if len(rows) == 0 && tab.search != nil && tab.search.filter {
+		fmt.Fprintln(v, "No lines match the search. Press & to show all lines.")
+	} else if len(rows) == 0 {
+		fmt.Fprintln(v, "No lines match the current log filter. Press F to change it.")
 	}
 	if !tab.autoscroll {
 		v.Autoscroll = false
@@ -1659,7 +1763,7 @@ func (gui *Gui) currentOutputLineIndex(tabID string) int {
 	if !ok {
 		return 0
 	}
-	lines := outputLines(gui.outputTextForTab(tabID))
+	lines := gui.outputViewLines(tabID)
 	if len(lines) == 0 {
 		return 0
 	}
@@ -1669,6 +1773,55 @@ func (gui *Gui) currentOutputLineIndex(tabID string) int {
 	return clampSelection(tab.originY, len(lines))
 }
 
+// outputCursorLine is the selection cursor in select mode, otherwise the top visible line
+func (gui *Gui) outputCursorLine(tabID string) int {
+	if gui.outputSelectMode && gui.outputSelectTabID == tabID {
+		return gui.outputSelectCursor
+	}
+	return gui.currentOutputLineIndex(tabID)
+}
+
+// openOutputItem handles Enter in the Output panel for tabs whose lines stand for something:
+// tests rerun, coverage files and URL routes open in the editor, management commands open their form
+func (gui *Gui) openOutputItem() error {
+	switch gui.resolveOutputTabID("", false) {
+	case "":
+		return nil
+	case gui.tests.resultsTabID:
+		return gui.runTestUnderCursor()
+	case gui.tests.coverageTabID:
+		return gui.openCoverageFile()
+	case gui.routes.tabID:
+		return gui.openRouteView()
+	case gui.commands.tabID:
+		return gui.openCommandUnderCursor()
+	case gui.history.tabID:
+		return gui.openHistoryEvent()
+	default:
+		return nil
+	}
+}
+
+// filterOutputTab handles F for the current output tab: URL routes and management commands take
+// a text filter, history offers its filters and everything else falls through to the Logs view
+func (gui *Gui) filterOutputTab(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.inputMode != "" || gui.currentModel != "" {
+		return nil
+	}
+	switch gui.resolveOutputTabID("", false) {
+	case "":
+		return gui.openLogViewModal()
+	case gui.routes.tabID:
+		return gui.openRouteFilterInput()
+	case gui.commands.tabID:
+		return gui.openCommandFilterInput()
+	case gui.history.tabID:
+		return gui.openProjectActionsModal("History Filters", gui.historyViewActions())
+	default:
+		return gui.openLogViewModal()
+	}
+}
+
 func (gui *Gui) ensureOutputSelectionVisible(tabID string) {
 	tab, ok := gui.outputTabs[tabID]
 	if !ok {
@@ -1680,7 +1833,7 @@ func (gui *Gui) ensureOutputSelectionVisible(tabID string) {
 		return
 	}
 
-	lines := outputLines(gui.outputTextForTab(tabID))
+	lines := gui.outputViewLines(tabID)
 	if len(lines) == 0 {
 		return
 	}
@@ -1770,7 +1923,7 @@ func (gui *Gui) toggleOutputSelectionMode(g *gocui.Gui, v *gocui.View) error {
 	if tabID == "" {
 		return nil
 	}
-	lines := outputLines(gui.outputTextForTab(tabID))
+	lines := gui.outputViewLines(tabID)
 	if len(lines) == 0 {
 		return nil
 	}
@@ -1801,7 +1954,7 @@ func (gui *Gui) moveOutputSelection(delta int) error {
 		return nil
 	}
 
-	lines := outputLines(gui.outputTextForTab(tabID))
+	lines := gui.outputViewLines(tabID)
 	if len(lines) == 0 {
 		gui.outputSelectMode = false
 		gui.outputSelectTabID = ""
@@ -1828,7 +1981,7 @@ func (gui *Gui) copyOutputSelection(g *gocui.Gui, v *gocui.View) error {
 		return nil
 	}
 
-	lines := outputLines(gui.outputTextForTab(tabID))
+	lines := gui.outputViewLines(tabID)
 	if len(lines) == 0 {
 		return nil
 	}
@@ -1840,10 +1993,7 @@ func (gui *Gui) copyOutputSelection(g *gocui.Gui, v *gocui.View) error {
 	}
 	start = clampSelection(start, len(lines))
 	end = clampSelection(end, len(lines))
-	content := strings.Join(lines[start:end+1], "\n")
-	if strings.TrimSpace(content) == "" {
-		content = outputLineAt(lines, start)
-	}
+	content := gui.outputRowsRawText(tabID, start, end)
 	content = sanitizeOutputForClipboard(content)
 	if strings.TrimSpace(content) == "" {
 		return gui.showMessage("Copy", "No copyable text in the selected output lines.")
@@ -1878,13 +2028,11 @@ func (gui *Gui) renderProjectList(v *gocui.View) {
 	v.Clear()
 	gui.clampSelections()
 
-	serverStatus := "stopped"
-	if gui.serverCmd != nil && gui.serverCmd.Process != nil {
-		serverStatus = "running"
-	}
-
 	lines := make([]string, 0, 32)
-	lines = append(lines, fmt.Sprintf("server: %s", serverStatus))
+	lines = append(lines, gui.serverStatusLines()...)
+	if len(gui.processes) > 0 {
+		lines = append(lines, gui.processStatusLine())
+	}
 	lines = append(lines, fmt.Sprintf("database: %s", gui.databaseLabel()))
 	if gui.hasMakefile() {
 		lines = append(lines, "workflow: make")
@@ -1896,6 +2044,9 @@ func (gui *Gui) renderProjectList(v *gocui.View) {
 	if !gui.startupHydrationDone {
 		lines = append(lines, "metadata: loading...")
 	}
+	if line := gui.migrationHealthLine(); line != "" {
+		lines = append(lines, line)
+	}
 
 	if gui.project.HasDocker {
 		if len(gui.containerStatus) == 0 {
@@ -2051,10 +2202,14 @@ func (gui *Gui) updateOptionsView(v *gocui.View) {
 		return
 	}
 	if gui.inputMode == "command" {
-		fmt.Fprint(v, "Command modal | Type shell/manage/make command  Enter:run  Esc:cancel  :help for key reference")
+		fmt.Fprint(v, "Command modal | Type shell/manage/make command  Tab:complete  Up/Down:history  Enter:run  Esc:cancel  :help for key reference")
 		return
 	}
 	if gui.inputMode == "search" {
+		if gui.inputReturnWindow == MainWindow && gui.currentModel == "" {
+			fmt.Fprint(v, "Search output | -r:regex  -c:case-sensitive  -f:only matching lines  -C N:context  Enter:jump  then n/N:next/prev  &:filter view  Esc:cancel")
+			return
+		}
 		fmt.Fprint(v, "Search modal | Type query for current panel  Enter:jump  Esc:cancel")
 		return
 	}
@@ -2063,24 +2218,24 @@ func (gui *Gui) updateOptionsView(v *gocui.View) {
 		return
 	}
 
-	global := "Nav | 1/2/3/4:panel  Tab/h/l:switch  j/k:move  g/G:top/bottom  Ctrl+d/u:page  Enter:run  :command  /search  v:select  y:copy  t:tabs  r:refresh  q:quit"
+	global := "Nav | 1/2/3/4:panel  Tab/h/l:switch  j/k:move  g/G:top/bottom  Ctrl+d/u:page  Enter:run  :command  /search  v:select  y:copy  t:tabs  X:cancel  r:refresh  q:quit"
 	context := ""
 
 	switch gui.currentWindow {
 	case MenuWindow:
-		context = "Project | Enter opens action groups, e:edit selected task config, u/D:container selector, U:update details"
+		context = "Project | Enter opens action groups, e:edit selected task config, u/D:container selector, M:merge migrations, U:update details"
 	case ListWindow:
-		context = "Database | Enter opens selected model data"
+		context = "Database | Enter opens selected model data, s:schema, S:toggle DDL, E:ER diagram"
 	case DataWindow:
 		context = "Data | Enter action, c:create, L:list, R:restore"
 	case MainWindow:
 		if gui.currentModel != "" {
-			context = "Output(model) | j/k/J/K:record  n/p or Ctrl+d/u:page  g/G:first/last row  a/e/d:CRUD  Esc:close model"
+			context = "Output(model) | j/k/J/K:record  n/p or Ctrl+d/u:page  g/G:first/last row  a/e/d:CRUD  s:schema  Esc:close model"
 		} else {
 			if gui.outputSelectMode {
 				context = fmt.Sprintf("Output(%s) select | j/k:extend  g/G:top/bottom  y:copy selected lines  v/Esc:exit select  [ ]:tabs  x:close", gui.currentOutputTabLabel())
 			} else {
-				context = fmt.Sprintf("Output(%s) | t:picker  [ ]:tabs  o:other type  f:tail/hold  x:close  Ctrl+L:clear  j/k/Ctrl+d/u:scroll  g/G:top/bottom  v:select  y:copy line  i:send input", gui.currentOutputTabLabel())
+				context = fmt.Sprintf("Output(%s) | t:picker  [ ]:tabs  o:other type  f:tail/hold  x:close  Ctrl+L:clear  j/k/Ctrl+d/u:scroll  g/G:top/bottom  v:select  y:copy line  i:send input  F:filters  z:traceback  T/O:frames/open  / n/N:search  &:matches only  H:request  W:export  Enter:rerun test/open file", gui.currentOutputTabLabel())
 			}
 		}
 	default:
@@ -2092,6 +2247,9 @@ func (gui *Gui) updateOptionsView(v *gocui.View) {
 
 func (gui *Gui) updateFooterView(v *gocui.View) {
 	v.Clear()
+	if gui.project != nil {
+		fmt.Fprintf(v, "Exec: %s | ", gui.project.ExecutorLabel())
+	}
 	fmt.Fprint(v, "Beta: LazyDjango is in active beta. Report issues: https://github.com/William-Blackie/lazydjango/issues")
 }
 
@@ -2276,6 +2434,18 @@ func (gui *Gui) setKeybindings() error {
 	if err := gui.bindGlobalRuneKey('U', gui.showUpdateInfo); err != nil {
 		return err
 	}
+	if err := gui.bindGlobalRuneKey('M', gui.mergeMigrations); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('s', gui.showModelSchema); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('S', gui.toggleSchemaDDL); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('E', gui.openERDiagramModal); err != nil {
+		return err
+	}
 	if err := gui.bindGlobalRuneKey('o', gui.toggleOutputTab); err != nil {
 		return err
 	}
@@ -2303,6 +2473,33 @@ func (gui *Gui) setKeybindings() error {
 	if err := gui.bindGlobalRuneKey('x', gui.closeCurrentOutputTab); err != nil {
 		return err
 	}
+	if err := gui.bindGlobalRuneKey('X', gui.cancelRunningOperation); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('F', gui.filterOutputTab); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('z', gui.toggleTraceback); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('T', gui.nextTracebackFrame); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('O', gui.openTracebackFrame); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('H', gui.openRequests); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('W', gui.openExportModal); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('N', gui.prevOutputMatch); err != nil {
+		return err
+	}
+	if err := gui.bindGlobalRuneKey('&', gui.toggleSearchFilter); err != nil {
+		return err
+	}
 	if err := gui.bindGlobalKey(gocui.KeyCtrlL, gui.clearCurrentOutputTab); err != nil {
 		return err
 	}
@@ -2395,7 +2592,7 @@ func (gui *Gui) jumpToTop(g *gocui.Gui, v *gocui.View) error {
 				return nil
 			}
 			gui.selectedRecordIdx = 0
-			return gui.loadAndDisplayRecords()
+			return gui.renderRecords()
 		}
 
 		tabID := gui.resolveOutputTabID("", false)
@@ -2403,7 +2600,7 @@ func (gui *Gui) jumpToTop(g *gocui.Gui, v *gocui.View) error {
 		if !ok {
 			return nil
 		}
-		lines := outputLines(gui.outputTextForTab(tabID))
+		lines := gui.outputViewLines(tabID)
 		if len(lines) == 0 {
 			return nil
 		}
@@ -2445,7 +2642,7 @@ func (gui *Gui) jumpToBottom(g *gocui.Gui, v *gocui.View) error {
 				return nil
 			}
 			gui.selectedRecordIdx = last
-			return gui.loadAndDisplayRecords()
+			return gui.renderRecords()
 		}
 
 		tabID := gui.resolveOutputTabID("", false)
@@ -2453,7 +2650,7 @@ func (gui *Gui) jumpToBottom(g *gocui.Gui, v *gocui.View) error {
 		if !ok {
 			return nil
 		}
-		lines := outputLines(gui.outputTextForTab(tabID))
+		lines := gui.outputViewLines(tabID)
 		if len(lines) == 0 {
 			return nil
 		}
@@ -2538,7 +2735,20 @@ func (gui *Gui) inputBarTitle() string {
 	case "command":
 		return " : Command "
 	case "search":
+		if gui.inputReturnWindow == MainWindow && gui.currentModel == "" {
+			return " / Search output (-r regex, -c case, -f filter, -C N context; empty clears) "
+		}
 		return " / Search "
+	case "log-path":
+		return " Log filter: path contains "
+	case "test-keyword":
+		return " Run tests matching (-k) "
+	case "route-filter":
+		return " Filter routes (path, name, view; ! for conflicts) "
+	case "command-filter":
+		return " Filter management commands (name or app) "
+	case "history-filter":
+		return " Filter history (type: status: since: until: text) "
 	case "output-input":
 		tabID := gui.inputTargetTabID
 		if tabID == "" {
@@ -2606,20 +2816,47 @@ func (gui *Gui) layoutInputPrompt(g *gocui.Gui, maxX, maxY int) error {
 	if err := g.SetKeybinding(ModalInputWindow, gocui.KeyCtrlC, gocui.ModNone, gui.cancelInputBar); err != nil {
 		return err
 	}
+	if gui.inputMode == "command" {
+		if err := gui.setCommandBarKeybindings(g); err != nil {
+			return err
+		}
+	}
 
 	g.SetViewOnTop(ModalInputWindow)
 	if _, err := g.SetCurrentView(ModalInputWindow); err != nil {
 		return err
 	}
+	if gui.inputMode == "command" {
+		return gui.layoutCommandGhost(g, v, x0, y0, x1)
+	}
+	g.DeleteView(CommandGhostWindow)
 	return nil
 }
 
+func (gui *Gui) setCommandBarKeybindings(g *gocui.Gui) error {
+	if err := g.SetKeybinding(ModalInputWindow, gocui.KeyTab, gocui.ModNone, gui.completeCommandBar); err != nil {
+		return err
+	}
+	if err := g.SetKeybinding(ModalInputWindow, gocui.KeyArrowUp, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		gui.browseCommandHistory(v, -1)
+		return nil
+	}); err != nil {
+		return err
+	}
+	return g.SetKeybinding(ModalInputWindow, gocui.KeyArrowDown, gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
+		gui.browseCommandHistory(v, 1)
+		return nil
+	})
+}
+
 func (gui *Gui) openCommandBar(g *gocui.Gui, v *gocui.View) error {
 	if gui.isModalOpen || gui.inputMode != "" {
 		return nil
 	}
 	gui.inputMode = "command"
 	gui.inputReturnWindow = gui.currentWindow
+	gui.commandBar = commandBarState{historyIndex: -1}
+	gui.loadCommandCompletions()
 	maxX, maxY := gui.g.Size()
 	return gui.layoutInputPrompt(gui.g, maxX, maxY)
 }
@@ -2673,6 +2910,7 @@ func (gui *Gui) closeInputBar() error {
 	gui.inputTargetTabID = ""
 	gui.g.DeleteKeybindings(ModalInputWindow)
 	gui.g.DeleteView(ModalInputWindow)
+	gui.g.DeleteView(CommandGhostWindow)
 	if _, err := gui.g.SetCurrentView(returnWindow); err != nil && err != gocui.ErrUnknownView {
 		return err
 	}
@@ -2718,11 +2956,29 @@ func (gui *Gui) submitInputBar(g *gocui.Gui, v *gocui.View) error {
 	}
 	if mode == "search" {
 		input := strings.TrimSpace(raw)
+		if gui.inputReturnWindow == MainWindow && gui.currentModel == "" {
+			return gui.searchCurrentOutput(input)
+		}
 		if input == "" {
 			return nil
 		}
 		return gui.searchCurrentWindow(input)
 	}
+	if mode == "log-path" {
+		return gui.setLogPathFilter(raw)
+	}
+	if mode == "test-keyword" {
+		return gui.runTestsMatching(raw)
+	}
+	if mode == "route-filter" {
+		return gui.setRouteFilter(raw)
+	}
+	if mode == "command-filter" {
+		return gui.setCommandFilter(raw)
+	}
+	if mode == "history-filter" {
+		return gui.setHistoryFilter(raw)
+	}
 	return nil
 }
 
@@ -2867,7 +3123,11 @@ func (gui *Gui) helpContent() string {
 		"Command/Search",
 		"  :             Open command bar",
 		"  :help         Open this help modal",
+		"  Tab           Complete commands, options, apps, models, migrations, paths",
+		"  Up / Down     Previous/next command from history (command bar)",
 		"  /             Search current panel/output and jump to closest match",
+		"  n / N         Next / previous output search match",
+		"  &             Show only lines matching the output search (toggle)",
 		"  Esc           Close command/search bar",
 		"",
 		"Output Tabs",
@@ -2877,11 +3137,24 @@ func (gui *Gui) helpContent() string {
 		"  f             Toggle tail-follow/hold in current tab",
 		"  x             Close current tab",
 		"  Ctrl+L        Clear current tab",
+		"  z             Expand/collapse traceback (Logs tabs)",
+		"  F             Log filters and request summary (Logs tabs)",
+		"  T / O         Cycle traceback frames / open frame in $EDITOR",
+		"  H             Compose an HTTP request (route under cursor in URL Routes)",
+		"  W             Export the selection, current tab or all tabs (Markdown/HTML/text)",
 		"",
 		"Project/Data",
 		"  Server...     Start/Stop dev server from Project panel",
+		"  Processes...  Start/Stop Procfile workers and watchers",
+		"  Tests...      Run tests, rerun failures, run tests matching a keyword",
+		"  Enter         Rerun the test under the cursor (Test Results tab)",
+		"  Enter         Open a file at its first missed line (Coverage tab)",
+		"  Enter / F     Open the view source / filter routes (URL Routes tab)",
+		"  Enter / F     Open the argument form / filter commands (Management Commands tab)",
+		"  Enter / F     Rerun, reopen or restore the event / filter and sort (History tab)",
 		"  u / D         Open start/stop container selector",
 		"  c / L / R     Create/List/Restore snapshots",
+		"  M             Merge conflicting migrations (makemigrations --merge)",
 		"",
 		"Model Data View",
 		"  j/k or J/K    Select previous/next record",
@@ -2889,10 +3162,13 @@ func (gui *Gui) helpContent() string {
 		"  Ctrl+d / Ctrl+u  Next/previous page (vim-style)",
 		"  g / G         Jump to first/last record on page",
 		"  a / e / d     Add/Edit/Delete record",
+		"  s / S         Show model schema / toggle introspected DDL",
+		"  E             Export ER diagram (Database panel)",
 		"  Esc           Close model view",
 		"",
 		"General",
 		"  r             Refresh project metadata",
+		"  X             Cancel the most recent running command/snapshot/schema load",
 		"  U             Show update information",
 		"  q or Ctrl+C   Quit",
 		"",
@@ -2990,7 +3266,7 @@ func (gui *Gui) searchCurrentWindow(query string) error {
 		}
 		if idx := nextMatchIndex(labels, query, gui.selectedRecordIdx); idx >= 0 {
 			gui.selectedRecordIdx = idx
-			return gui.loadAndDisplayRecords()
+			return gui.renderRecords()
 		}
 		return gui.showMessage("Search", "No matching record in current model page.")
 	default:
@@ -2998,43 +3274,6 @@ func (gui *Gui) searchCurrentWindow(query string) error {
 	}
 }
 
-func (gui *Gui) searchCurrentOutput(query string) error {
-	tabID := gui.resolveOutputTabID("", false)
-	if tabID == "" {
-		return gui.showMessage("Search", "No output tab is currently available.")
-	}
-
-	tab, ok := gui.outputTabs[tabID]
-	if !ok {
-		return gui.showMessage("Search", "Current output tab is not available.")
-	}
-
-	lines := strings.Split(gui.outputTextForTab(tabID), "\n")
-	if len(lines) == 0 {
-		return gui.showMessage("Search", "No output to search in this tab.")
-	}
-
-	current := tab.originY
-	if current == outputOriginTail || current < 0 {
-		current = len(lines) - 1
-	}
-	if current >= len(lines) {
-		current = len(lines) - 1
-	}
-
-	idx := nextMatchIndex(lines, query, current)
-	if idx < 0 {
-		return gui.showMessage("Search", "No match in current output tab.")
-	}
-
-	tab.autoscroll = false
-	tab.originX = 0
-	tab.originY = idx
-	gui.markStateDirty()
-	gui.refreshOutputView()
-	return nil
-}
-
 func (gui *Gui) openOutputTabsModal(g *gocui.Gui, v *gocui.View) error {
 	if gui.isModalOpen || gui.currentModel != "" {
 		return nil
@@ -3362,7 +3601,7 @@ func (gui *Gui) executeCommand(g *gocui.Gui, v *gocui.View) error {
 	case DataWindow:
 		return gui.executeDataSelection()
 	case MainWindow:
-		return nil
+		return gui.openOutputItem()
 	default:
 		return nil
 	}
@@ -3450,12 +3689,78 @@ func (gui *Gui) runProjectAction(action projectAction) error {
 	switch action.internal {
 	case "openserver":
 		return gui.openProjectActionsModal("Server Actions", gui.projectServerActions())
+	case "logfilter", "logpath", "logsummary", "logexpand", "lograw":
+		return gui.runLogViewAction(action)
+	case "openprocesses":
+		return gui.openProcesses()
+	case "opentests":
+		return gui.openProjectActionsModal("Tests", gui.projectTestActions())
+	case "runtests":
+		return gui.runTests(django.TestRunOptions{})
+	case "runtestscoverage":
+		return gui.runTests(django.TestRunOptions{Coverage: true})
+	case "showcoverage":
+		return gui.showCoverage()
+	case "coveragesort":
+		return gui.toggleCoverageSort()
+	case "runtestskeyword":
+		return gui.openTestKeywordInput()
+	case "rerunfailedtests":
+		return gui.rerunFailedTests()
+	case "runtest":
+		return gui.runTests(django.TestRunOptions{Labels: []string{action.value}})
+	case "showtestresults":
+		return gui.showTestResults()
+	case "openprocess":
+		return gui.openProjectActionsModal("Process: "+action.value, gui.projectProcessDetailActions(action.value))
+	case "startprocess":
+		return gui.startProcess(action.value)
+	case "stopprocess":
+		return gui.stopProcess(action.value)
+	case "restartprocess":
+		return gui.restartProcess(action.value)
+	case "showprocesslogs":
+		return gui.showProcessLogs(action.value)
+	case "startallprocesses":
+		return gui.startAllProcesses()
+	case "stopallprocesses":
+		return gui.stopAllProcesses()
+	case "restartallprocesses":
+		return gui.restartAllProcesses()
+	case "openexecutor":
+		return gui.openProjectActionsModal("Execution Backend", gui.projectExecutorActions())
+	case "setexecutor":
+		return gui.setProjectExecutor(action.value)
 	case "runserver":
 		return gui.startServer(gui.g, nil)
+	case "restartserver":
+		return gui.restartServer(gui.g, nil)
 	case "stopserver":
 		return gui.stopServer(gui.g, nil)
+	case "runserverport":
+		return gui.startServerOnPort(action.value)
+	case "startprofile":
+		return gui.startServerProfile(action.value, 0)
+	case "stopprofile":
+		return gui.stopServerProfile(action.value)
+	case "restartprofile":
+		return gui.restartServerProfile(action.value)
+	case "opendefaultserver":
+		return gui.openProjectActionsModal("Default Server Profile", gui.projectDefaultServerActions())
+	case "setdefaultserver":
+		return gui.setDefaultServerProfile(action.value)
+	case "killportowner":
+		return gui.stopPortOwnerAndStart(action.value)
 	case "showurls":
-		return gui.showURLPatterns()
+		return gui.showURLRoutes()
+	case "showcommands":
+		return gui.showManagementCommands()
+	case "openrequests":
+		return gui.openProjectActionsModal("HTTP Requests", gui.requestActions())
+	case "newrequest":
+		return gui.openRequestComposer(httpRequestSpec{}, "")
+	case "openrequest":
+		return gui.openSavedRequest(action.value)
 	case "doctor":
 		return gui.showDependencyDoctor()
 	case "refresh":
@@ -3464,6 +3769,24 @@ func (gui *Gui) runProjectAction(action projectAction) error {
 		return gui.startContainers(gui.g, nil)
 	case "stopcontainers":
 		return gui.stopContainers(gui.g, nil)
+	case "openservices":
+		actions := gui.projectServiceActions()
+		if len(actions) == 0 {
+			return gui.showMessage("Containers", "No services found in docker compose.")
+		}
+		return gui.openProjectActionsModal("Services", actions)
+	case "openservice":
+		return gui.openProjectActionsModal("Service: "+action.value, gui.projectServiceDetailActions(action.value))
+	case "containerdetails":
+		return gui.showContainerDetails()
+	case "servicelogs":
+		return gui.followServiceLogs(action.value)
+	case "servicerestart":
+		return gui.runServiceAction("restart", action.value, "restart")
+	case "servicerebuild":
+		return gui.runServiceAction("rebuild", action.value, "up", "-d", "--build")
+	case "serviceshell":
+		return gui.openServiceShell(action.value)
 	case "opencontainers":
 		return gui.openProjectActionsModal("Container Actions", gui.projectContainerActions())
 	case "openprojecttasks":
@@ -3485,6 +3808,61 @@ func (gui *Gui) runProjectAction(action projectAction) error {
 		return gui.openProjectActionsModal("Tool Actions", gui.projectToolActions())
 	case "historyreport":
 		return gui.showHistoryReport()
+	case "historyview":
+		return gui.showHistoryView()
+	case "openanalytics":
+		return gui.openProjectActionsModal(analyticsTitle, gui.analyticsActions())
+	case "analyticsshow":
+		return gui.showCommandAnalytics("")
+	case "analyticssave":
+		return gui.showCommandAnalytics(action.value)
+	case "openexport":
+		return gui.openExportScopes()
+	case "exportscope":
+		return gui.openProjectActionsModal(exportTitle, gui.exportFormatActions(action.value))
+	case "exportsave":
+		return gui.exportOutput(action.value)
+	case "historyfilterinput":
+		return gui.openHistoryFilterInput()
+	case "historyfilter":
+		return gui.setHistoryFilter(action.value)
+	case "historysort":
+		return gui.setHistorySort(action.value)
+	case "historyrerun", "historymodel", "historyrestore":
+		return gui.runHistoryAction(action)
+	case "mergemigrations":
+		return gui.mergeConflictingMigrations()
+	case "checkmigrations":
+		gui.startMigrationHealthCheck()
+		return nil
+	case "sqlpreview":
+		return gui.showMigrationSQLReport()
+	case "erdscope":
+		return gui.selectERDScope(action.value)
+	case "erdshow":
+		return gui.exportERDiagram(action.value, false)
+	case "erdsave":
+		return gui.exportERDiagram(action.value, true)
+	case "opensquash":
+		return gui.openProjectActionsModal("Squash: Select App", gui.projectSquashAppActions())
+	case "squashapp":
+		return gui.openProjectActionsModal(fmt.Sprintf("Squash %s: Start From", action.value), gui.projectSquashStartActions(action.value))
+	case "squashstart":
+		return gui.openProjectActionsModal(fmt.Sprintf("Squash %s: Up To", gui.squashApp), gui.projectSquashEndActions(action.value))
+	case "squashend":
+		return gui.previewSquashPlan(action.value)
+	case "squashrun":
+		return gui.runSquashPlan()
+	case "squashkeep":
+		return gui.finishSquash(true)
+	case "squashdiscard":
+		return gui.finishSquash(false)
+	case "openprune":
+		return gui.openProjectActionsModal("Prune Squashed Migrations", gui.projectPruneActions())
+	case "prunesquash":
+		return gui.previewPruneSquash(action.value)
+	case "pruneconfirm":
+		return gui.runPruneSquash()
 	default:
 		return nil
 	}
@@ -3513,7 +3891,7 @@ func (gui *Gui) runShellCommand(command string) error {
 	}
 
 	cmd := newTTYCompatShellCommand(command, gui.project.RootDir)
-	return gui.runStreamingCommandToRoute(OutputTabCommand, tabTitleFromCommand(command), cmd, false, command, nil)
+	return gui.runStreamingCommandToRoute(OutputTabCommand, tabTitleFromCommand(command), cmd, nil, command, nil)
 }
 
 func (gui *Gui) showHistoryReport() error {
@@ -3602,18 +3980,23 @@ func (gui *Gui) runManageCommand(title string, args ...string) error {
 	_ = gui.switchPanel(MainWindow)
 
 	startedAt := time.Now()
+	ctx, op := gui.beginContextOperation(command, tabID)
 	go func() {
-		output, runErr := gui.project.RunCommand(args...)
+		runCtx, cancel := gui.project.OperationContext(ctx, config.TimeoutCommand)
+		output, runErr := gui.project.RunCommandContext(runCtx, args...)
+		cancel()
 		gui.g.Update(func(g *gocui.Gui) error {
+			runErr = gui.endOperation(op, runErr)
 			if runErr != nil {
 				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n\n", runErr))
 			}
 			gui.appendOutput(tabID, output)
 			gui.refreshOutputView()
 
-			if len(args) > 0 && (args[0] == "makemigrations" || args[0] == "migrate") {
+			if len(args) > 0 && (args[0] == "makemigrations" || args[0] == "migrate" || args[0] == "squashmigrations") {
 				gui.project.Migrations = nil
 				gui.project.DiscoverMigrations()
+				gui.startMigrationHealthCheck()
 			}
 
 			gui.recordCommandExecution(command, tabID, startedAt, runErr)
@@ -3633,11 +4016,18 @@ func isLongRunningMakeTarget(target string) bool {
 	}
 }
 
+// streamSupervisor lets the server and process managers follow a streamed process.
+// started returns a channel closed once the process has been waited on.
+type streamSupervisor struct {
+	started func(cmd *exec.Cmd, tabID string) chan struct{}
+	output  func(chunk string)
+}
+
 func (gui *Gui) runStreamingCommandToRoute(
 	route string,
 	title string,
 	cmd *exec.Cmd,
-	trackAsServer bool,
+	supervisor *streamSupervisor,
 	displayCommand string,
 	onExit func(*gocui.Gui, error),
 ) error {
@@ -3668,6 +4058,7 @@ func (gui *Gui) runStreamingCommandToRoute(
 	_ = gui.switchPanel(MainWindow)
 	startedAt := time.Now()
 	prepareCommandForExecution(cmd, false)
+	django.SetProcessGroup(cmd)
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
@@ -3712,9 +4103,12 @@ func (gui *Gui) runStreamingCommandToRoute(
 		}
 	}
 
-	if trackAsServer {
-		gui.serverCmd = cmd
+	var supervisedDone chan struct{}
+	if supervisor != nil {
+		supervisedDone = supervisor.started(cmd, tabID)
 	}
+	// Killing a container command takes a docker call, so it runs off the UI goroutine.
+	op := gui.beginOperation(command, tabID, func() { go func() { _ = django.KillCommand(cmd) }() })
 
 	chunkCh := make(chan string, 256)
 	var wg sync.WaitGroup
@@ -3754,11 +4148,15 @@ func (gui *Gui) runStreamingCommandToRoute(
 			if chunk == "" {
 				continue
 			}
-			captured.WriteString(chunk)
+			plain := stripANSI(chunk)
+			captured.WriteString(plain)
 			chunkCopy := chunk
 			gui.g.Update(func(g *gocui.Gui) error {
 				gui.appendOutput(tabID, chunkCopy)
-				if hasInteractivePrompt(chunkCopy) {
+				if supervisor != nil && supervisor.output != nil {
+					supervisor.output(plain)
+				}
+				if hasInteractivePrompt(plain) {
 					_ = gui.maybeAutoFocusOutputInput(tabID)
 				}
 				gui.refreshOutputView()
@@ -3767,7 +4165,11 @@ func (gui *Gui) runStreamingCommandToRoute(
 		}
 
 		waitErr := cmd.Wait()
+		if supervisedDone != nil {
+			close(supervisedDone)
+		}
 		gui.g.Update(func(g *gocui.Gui) error {
+			waitErr = gui.endOperation(op, waitErr)
 			if writer, ok := gui.outputInputWriters[tabID]; ok {
 				_ = writer.Close()
 				delete(gui.outputInputWriters, tabID)
@@ -3781,12 +4183,6 @@ func (gui *Gui) runStreamingCommandToRoute(
 				gui.appendOutput(tabID, "Hint: start the required services first (Project -> Containers..., or press 'u').\n")
 			}
 
-			if trackAsServer {
-				gui.serverCmd = nil
-				if menuView, err := g.View(MenuWindow); err == nil {
-					gui.renderProjectList(menuView)
-				}
-			}
 			if onExit != nil {
 				onExit(g, waitErr)
 			}
@@ -3799,8 +4195,8 @@ func (gui *Gui) runStreamingCommandToRoute(
 	return nil
 }
 
-func (gui *Gui) runStreamingCommandToLogs(title string, cmd *exec.Cmd, trackAsServer bool, displayCommand string) error {
-	return gui.runStreamingCommandToRoute(OutputTabLogs, title, cmd, trackAsServer, displayCommand, nil)
+func (gui *Gui) runStreamingCommandToLogs(title string, cmd *exec.Cmd, displayCommand string) error {
+	return gui.runStreamingCommandToRoute(OutputTabLogs, title, cmd, nil, displayCommand, nil)
 }
 
 func (gui *Gui) runMakeTarget(_ string, target string) error {
@@ -3815,7 +4211,7 @@ func (gui *Gui) runMakeTarget(_ string, target string) error {
 		route = OutputTabLogs
 	}
 
-	return gui.runStreamingCommandToRoute(route, tabTitleFromCommand(command), cmd, false, command, func(g *gocui.Gui, _ error) {
+	return gui.runStreamingCommandToRoute(route, tabTitleFromCommand(command), cmd, nil, command, func(g *gocui.Gui, _ error) {
 		switch target {
 		case "migrations", "migrate", "migrate-site", "showmigrations":
 			gui.project.Migrations = nil
@@ -3857,15 +4253,13 @@ func (gui *Gui) openSelectedModel() error {
 		return err
 	}
 
-	if err := gui.loadAndDisplayRecords(); err != nil {
-		return err
-	}
-	var selectedPK interface{}
-	if len(gui.currentRecords) > 0 && gui.selectedRecordIdx >= 0 && gui.selectedRecordIdx < len(gui.currentRecords) {
-		selectedPK = gui.currentRecords[gui.selectedRecordIdx].PK
-	}
-	gui.recordModelOpen(gui.currentApp, gui.currentModel, gui.currentPage, selectedPK)
-	return nil
+	return gui.loadRecords(func() {
+		var selectedPK interface{}
+		if len(gui.currentRecords) > 0 && gui.selectedRecordIdx >= 0 && gui.selectedRecordIdx < len(gui.currentRecords) {
+			selectedPK = gui.currentRecords[gui.selectedRecordIdx].PK
+		}
+		gui.recordModelOpen(gui.currentApp, gui.currentModel, gui.currentPage, selectedPK)
+	})
 }
 
 func (gui *Gui) executeDataSelection() error {
@@ -3935,7 +4329,7 @@ func (gui *Gui) handleAddKey(g *gocui.Gui, v *gocui.View) error {
 func (gui *Gui) handleEditKey(g *gocui.Gui, v *gocui.View) error {
 	if gui.isModalOpen {
 		switch gui.modalType {
-		case "add", "edit":
+		case "add", "edit", "request", "command":
 			return gui.editModalField()
 		case "projectActions":
 			return gui.editSelectedProjectModalAction()
@@ -3957,36 +4351,6 @@ func (gui *Gui) handleDeleteKey(g *gocui.Gui, v *gocui.View) error {
 	return gui.deleteRecord(g, v)
 }
 
-func (gui *Gui) showURLPatterns() error {
-	tabID := gui.startCommandOutputTab("URL Patterns")
-	gui.appendOutput(tabID, "Loading URL patterns...\n")
-	_ = gui.switchPanel(MainWindow)
-
-	go func() {
-		patterns, runErr := gui.project.GetURLPatterns()
-		gui.g.Update(func(g *gocui.Gui) error {
-			gui.resetOutput(tabID, "URL Patterns")
-			if runErr != nil {
-				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n", runErr))
-				gui.rememberError("urls", runErr.Error())
-				gui.refreshOutputView()
-				return nil
-			}
-			for _, pattern := range patterns {
-				pattern = strings.TrimSpace(pattern)
-				if pattern == "" {
-					continue
-				}
-				gui.appendOutput(tabID, pattern+"\n")
-			}
-			gui.refreshOutputView()
-			return nil
-		})
-	}()
-
-	return nil
-}
-
 func (gui *Gui) showDependencyDoctor() error {
 	report := django.BuildDependencyReport(gui.project)
 	tabID := gui.startCommandOutputTab("Dependency Doctor")
@@ -4002,10 +4366,12 @@ func (gui *Gui) createSnapshot(g *gocui.Gui, v *gocui.View) error {
 	gui.refreshOutputView()
 	_ = gui.switchPanel(MainWindow)
 
+	ctx, op := gui.beginContextOperation("create snapshot", tabID)
 	go func() {
-		sm := django.NewSnapshotManager(gui.project)
+		sm := django.NewSnapshotManager(gui.project).WithContext(ctx)
 		snapshot, createErr := sm.CreateSnapshot("")
 		gui.g.Update(func(g *gocui.Gui) error {
+			createErr = gui.endOperation(op, createErr)
 			gui.resetOutput(tabID, "Create Snapshot")
 			if createErr != nil {
 				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n", createErr))
@@ -4098,49 +4464,15 @@ func (gui *Gui) showRestoreMenu() error {
 }
 
 func parseComposeServicesFromYAML(content string) []string {
-	inServices := false
-	services := make([]string, 0)
-	seen := make(map[string]struct{})
-
-	for _, rawLine := range strings.Split(content, "\n") {
-		line := rawLine
-		if idx := strings.Index(line, "#"); idx >= 0 {
-			line = line[:idx]
-		}
-		trimmed := strings.TrimSpace(line)
-		if trimmed == "" {
-			continue
-		}
-
-		if !inServices {
-			if trimmed == "services:" {
-				inServices = true
-			}
-			continue
-		}
-
-		indent := len(line) - len(strings.TrimLeft(line, " "))
-		// Leaving the services block.
-		if indent == 0 {
-			break
-		}
-
-		// Top-level service names are typically indented by two spaces.
-		if indent == 2 && strings.HasSuffix(trimmed, ":") {
-			name := strings.TrimSuffix(trimmed, ":")
-			if name == "" {
-				continue
-			}
-			if _, exists := seen[name]; exists {
-				continue
-			}
-			seen[name] = struct{}{}
-			services = append(services, name)
-		}
+	services, err := django.ParseComposeServices([]byte(content))
+	if err != nil {
+		return nil
 	}
-
-	sort.Strings(services)
-	return services
+	names := make([]string, 0, len(services))
+	for _, service := range services {
+		names = append(names, service.Name)
+	}
+	return names
 }
 
 func (gui *Gui) listComposeServices() ([]string, error) {
@@ -4148,7 +4480,8 @@ func (gui *Gui) listComposeServices() ([]string, error) {
 		return nil, fmt.Errorf("docker compose file is not configured")
 	}
 
-	composeArgs := []string{"compose", "-f", gui.project.DockerComposeFile, "config", "--services"}
+	composeArgs := append([]string{"compose"}, gui.project.ComposeFileArgs()...)
+	composeArgs = append(composeArgs, "config", "--services")
 	cmd := exec.Command("docker", composeArgs...)
 	cmd.Dir = gui.project.RootDir
 	output, err := cmd.Output()
@@ -4172,6 +4505,10 @@ func (gui *Gui) listComposeServices() ([]string, error) {
 		}
 	}
 
+	if services := gui.project.Compose.ServiceNames(); len(services) > 0 {
+		return services, nil
+	}
+
 	content, readErr := os.ReadFile(gui.project.DockerComposeFile)
 	if readErr == nil {
 		services := parseComposeServicesFromYAML(string(content))
@@ -4309,7 +4646,7 @@ func (gui *Gui) runContainerSelectionAction() error {
 	_ = gui.switchPanel(MainWindow)
 	startedAt := time.Now()
 
-	baseArgs := []string{"compose", "-f", gui.project.DockerComposeFile}
+	baseArgs := append([]string{"compose"}, gui.project.ComposeFileArgs()...)
 	args := append([]string{}, baseArgs...)
 	if action == "stop" {
 		args = append(args, "stop")
@@ -4421,62 +4758,6 @@ func parseContainerNameConflicts(output string) []string {
 	return names
 }
 
-func resolvePythonBinary() string {
-	if pythonPath, err := exec.LookPath("python"); err == nil {
-		return pythonPath
-	}
-	if python3Path, err := exec.LookPath("python3"); err == nil {
-		return python3Path
-	}
-	return "python"
-}
-
-func (gui *Gui) startServer(g *gocui.Gui, v *gocui.View) error {
-	if gui.serverCmd != nil && gui.serverCmd.Process != nil {
-		tabID := gui.startLogsOutputTab("Dev Server")
-		gui.appendOutput(tabID, "Server is already running.\n")
-		gui.refreshOutputView()
-		_ = gui.switchPanel(MainWindow)
-		return nil
-	}
-
-	var cmd *exec.Cmd
-	if gui.makeTargetExists("runserver") {
-		cmd = exec.Command("make", "runserver")
-	} else if gui.project.HasDocker && gui.project.DockerService != "" {
-		args := []string{"compose"}
-		if gui.project.DockerComposeFile != "" {
-			args = append(args, "-f", gui.project.DockerComposeFile)
-		}
-		args = append(args, "exec", "-T", gui.project.DockerService, "python", "manage.py", "runserver", "0.0.0.0:8000")
-		cmd = exec.Command("docker", args...)
-	} else {
-		cmd = exec.Command(resolvePythonBinary(), gui.project.ManagePyPath, "runserver")
-	}
-	cmd.Dir = gui.project.RootDir
-	return gui.runStreamingCommandToLogs("Dev Server", cmd, true, strings.Join(cmd.Args, " "))
-}
-
-func (gui *Gui) stopServer(g *gocui.Gui, v *gocui.View) error {
-	tabID := gui.startLogsOutputTab("Dev Server")
-
-	if gui.serverCmd == nil || gui.serverCmd.Process == nil {
-		gui.appendOutput(tabID, "\nNo running server process.\n")
-		gui.refreshOutputView()
-		_ = gui.switchPanel(MainWindow)
-		return nil
-	}
-
-	if err := gui.serverCmd.Process.Kill(); err != nil {
-		gui.appendOutput(tabID, fmt.Sprintf("\nFailed to stop server: %v\n", err))
-	} else {
-		gui.appendOutput(tabID, "\nServer stop signal sent.\n")
-	}
-	gui.refreshOutputView()
-	_ = gui.switchPanel(MainWindow)
-	return nil
-}
-
 func (gui *Gui) refresh(g *gocui.Gui, v *gocui.View) error {
 	tabID := gui.startCommandOutputTab("Refresh")
 	gui.appendOutput(tabID, "Refreshing project metadata...\n")
@@ -4496,6 +4777,7 @@ func (gui *Gui) refresh(g *gocui.Gui, v *gocui.View) error {
 	gui.refreshContainerStatus()
 	gui.invalidateSnapshotCache()
 	gui.clampSelections()
+	gui.startMigrationHealthCheck()
 
 	if gui.currentModel != "" {
 		found := false
@@ -4527,7 +4809,8 @@ func getContainerStatusForProject(project *django.Project) map[string]string {
 		return map[string]string{}
 	}
 
-	cmd := exec.Command("docker", "compose", "-f", project.DockerComposeFile, "ps", "--format", "json")
+	args := append([]string{"compose"}, project.ComposeFileArgs()...)
+	cmd := exec.Command("docker", append(args, "ps", "--format", "json")...)
 	cmd.Dir = project.RootDir
 	output, err := cmd.Output()
 	if err != nil {
@@ -4585,6 +4868,7 @@ func (gui *Gui) migrationSummary() (int, int) {
 
 // quit exits the application.
 func (gui *Gui) quit(g *gocui.Gui, v *gocui.View) error {
+	gui.stopAllOnQuit()
 	for tabID, writer := range gui.outputInputWriters {
 		if writer != nil {
 			_ = writer.Close()
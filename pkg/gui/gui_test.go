@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -601,6 +602,28 @@ func TestValidateConstrainedFieldsForeignKeyWithoutProject(t *testing.T) {
 	}
 }
 
+func TestForeignKeyReferences(t *testing.T) {
+	gui := &Gui{
+		currentApp: "blog",
+		modalFields: []map[string]interface{}{
+			{"name": "author", "type": "ForeignKey", "related_model": "User", "related_app": "auth"},
+			{"name": "category", "type": "ForeignKey", "related_model": "Category"},
+			{"name": "editor", "type": "ForeignKey", "related_model": "User", "related_app": "auth"},
+			{"name": "title", "type": "CharField"},
+		},
+		modalValues: map[string]string{"author": " 7 ", "category": "3", "title": "Hello"},
+	}
+
+	refs := gui.foreignKeyReferences()
+	want := []foreignKeyReference{
+		{field: "author", app: "auth", model: "User", value: "7"},
+		{field: "category", app: "blog", model: "Category", value: "3"},
+	}
+	if !reflect.DeepEqual(refs, want) {
+		t.Fatalf("expected %+v, got %+v", want, refs)
+	}
+}
+
 func TestSubmitModalValidationKeepsModalOpen(t *testing.T) {
 	requiredField := map[string]interface{}{
 		"name":  "title",
@@ -869,14 +892,23 @@ func TestSanitizeOutputForClipboard(t *testing.T) {
 }
 
 func TestSanitizeOutputForDisplay(t *testing.T) {
-	input := "\x1b[?25h\x1b[1;A\x1b[0;G\x1b[?25l[+] Running 1/1\r\n"
+	input := "\x1b[?25h\x1b[1;A\x1b[0;G\x1b[?25l[+] Running 1/1\r\n\x1b]0;title\x07\x1b[1;32mOK\x1b[0m\n"
 	got := sanitizeOutputForDisplay(input)
-	want := "[+] Running 1/1\n"
+	want := "[+] Running 1/1\n\x1b[1;32mOK\x1b[0m\n"
 	if got != want {
 		t.Fatalf("expected sanitized display output %q, got %q", want, got)
 	}
 }
 
+func TestTerminalSGR(t *testing.T) {
+	input := "\x1b[91mbright\x1b[m \x1b[1;38;5;208mextended\x1b[0m \x1b[38;2;1;2;3mtrue\x1b[0m"
+	got := terminalSGR(input)
+	want := "\x1b[31mbright\x1b[0m \x1b[1mextended\x1b[0m true\x1b[0m"
+	if got != want {
+		t.Fatalf("expected 8-color output %q, got %q", want, got)
+	}
+}
+
 func TestIsProjectTasksModalTitle(t *testing.T) {
 	if !isProjectTasksModalTitle(" Project Tasks ") {
 		t.Fatal("expected Project Tasks title to match")
@@ -1011,7 +1043,7 @@ func TestProjectToolActionsContainExpectedCommands(t *testing.T) {
 		if action.label == "Django check" {
 			foundCheck = true
 		}
-		if action.label == "Show URL patterns" {
+		if action.label == "Show URL routes" {
 			foundURLs = true
 		}
 	}
@@ -1020,7 +1052,7 @@ func TestProjectToolActionsContainExpectedCommands(t *testing.T) {
 		t.Fatal("expected tool actions to include Django check")
 	}
 	if !foundURLs {
-		t.Fatal("expected tool actions to include Show URL patterns")
+		t.Fatal("expected tool actions to include Show URL routes")
 	}
 }
 
@@ -0,0 +1,423 @@
+package gui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const historyViewTitle = "History"
+
+// History view orders
+const (
+	historySortTime     = "time"
+	historySortDuration = "duration"
+)
+
+// historyView is the History tab: the loaded events, the active filter and sort, and which event
+// each line shows
+type historyView struct {
+	tabID      string
+	events     []historyEvent // As stored, oldest first
+	filter     string
+	sort       string
+	lineEvents []int // Index into events for each line, -1 for other lines
+}
+
+// historyFilter is a parsed filter expression such as "type:command status:error since:7d migrate"
+type historyFilter struct {
+	types    []string
+	statuses []string
+	since    time.Time
+	until    time.Time
+	text     []string
+}
+
+// parseHistoryFilter reads type:, status:, since: and until: terms; everything else is text that
+// every match must contain. Dates are YYYY-MM-DD or a relative age such as 90m, 24h or 7d. until:
+// with a date includes that whole day.
+func parseHistoryFilter(expr string, now time.Time) (historyFilter, error) {
+	var filter historyFilter
+	for _, term := range strings.Fields(expr) {
+		key, value, ok := strings.Cut(term, ":")
+		value = strings.ToLower(value)
+		switch {
+		case ok && key == "type":
+			filter.types = append(filter.types, strings.Split(value, ",")...)
+		case ok && key == "status":
+			filter.statuses = append(filter.statuses, strings.Split(value, ",")...)
+		case ok && (key == "since" || key == "until"):
+			at, day, err := parseHistoryTime(value, now)
+			if err != nil {
+				return historyFilter{}, fmt.Errorf("%s: %w", key, err)
+			}
+			if key == "since" {
+				filter.since = at
+			} else {
+				if day {
+					at = at.AddDate(0, 0, 1)
+				}
+				filter.until = at
+			}
+		default:
+			filter.text = append(filter.text, strings.ToLower(term))
+		}
+	}
+	return filter, nil
+}
+
+func parseHistoryTime(value string, now time.Time) (time.Time, bool, error) {
+	if at, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return at, true, nil
+	}
+	if strings.HasSuffix(value, "d") {
+		if days, err := strconv.Atoi(strings.TrimSuffix(value, "d")); err == nil {
+			return now.AddDate(0, 0, -days), false, nil
+		}
+	}
+	if age, err := time.ParseDuration(value); err == nil {
+		return now.Add(-age), false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%q is not a date (YYYY-MM-DD) or an age (24h, 7d)", value)
+}
+
+func (f historyFilter) matches(event historyEvent) bool {
+	if len(f.types) > 0 && !containsFold(f.types, event.Type) {
+		return false
+	}
+	if len(f.statuses) > 0 && !containsFold(f.statuses, event.Status) {
+		return false
+	}
+	if !f.since.IsZero() || !f.until.IsZero() {
+		at, err := time.Parse(time.RFC3339, event.Time)
+		if err != nil || (!f.since.IsZero() && at.Before(f.since)) || (!f.until.IsZero() && !at.Before(f.until)) {
+			return false
+		}
+	}
+	if len(f.text) > 0 {
+		haystack := strings.ToLower(strings.Join([]string{
+			event.Type, event.Source, event.Status, event.Command, event.Action, event.App, event.Model,
+			event.RecordPK, event.Snapshot, strings.Join(event.Services, " "), event.Error,
+		}, " "))
+		for _, word := range f.text {
+			if !strings.Contains(haystack, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// showHistoryView loads history.ndjson into the History tab
+func (gui *Gui) showHistoryView() error {
+	if gui.historyStore == nil && gui.project != nil {
+		gui.historyStore = newProjectHistoryStore(gui.project.RootDir)
+	}
+	events, err := gui.historyStore.tail(maxPersistedHistoryEvents)
+	if err != nil {
+		return gui.showMessage(historyViewTitle, fmt.Sprintf("History log unavailable: %v", err))
+	}
+	gui.history.events = events
+	if gui.history.sort == "" {
+		gui.history.sort = historySortTime
+	}
+	tabID := gui.history.tabID
+	if _, ok := gui.outputTabs[tabID]; ok {
+		gui.switchOutputTab(tabID)
+	} else {
+		tabID = gui.startCommandOutputTab(historyViewTitle)
+	}
+	gui.history.tabID = tabID
+	_ = gui.switchPanel(MainWindow)
+	gui.renderHistoryView()
+	return nil
+}
+
+// renderHistoryView redraws the History tab with the current filter and sort
+func (gui *Gui) renderHistoryView() {
+	tabID := gui.history.tabID
+	if _, ok := gui.outputTabs[tabID]; !ok {
+		return
+	}
+	filter, err := parseHistoryFilter(gui.history.filter, time.Now())
+	gui.resetOutput(tabID, historyViewTitle)
+	gui.outputTabs[tabID].originY = 0
+	if err != nil {
+		gui.history.lineEvents = nil
+		gui.appendOutput(tabID, fmt.Sprintf("Invalid filter: %v\n", err))
+		gui.refreshOutputView()
+		return
+	}
+	text, lineEvents := formatHistoryEvents(gui.history.events, filter, gui.history.filter, gui.history.sort)
+	gui.history.lineEvents = lineEvents
+	gui.appendOutput(tabID, text)
+	gui.refreshOutputView()
+}
+
+// historyViewActions lists the filters and sort orders offered by F in the History tab
+func (gui *Gui) historyViewActions() []projectAction {
+	filterLabel := "Filter (type: status: since: until: text)..."
+	if gui.history.filter != "" {
+		filterLabel = fmt.Sprintf("Filter... (%s)", gui.history.filter)
+	}
+	sortLabel := "Sort by duration (slowest first)"
+	sortValue := historySortDuration
+	if gui.history.sort == historySortDuration {
+		sortLabel = "Sort by time (newest first)"
+		sortValue = historySortTime
+	}
+	actions := []projectAction{
+		{label: filterLabel, internal: "historyfilterinput"},
+		{label: "Errors only", internal: "historyfilter", value: "status:error"},
+		{label: "Last 24 hours", internal: "historyfilter", value: "since:24h"},
+		{label: sortLabel, internal: "historysort", value: sortValue},
+	}
+	seen := make(map[string]bool)
+	var types []string
+	for _, event := range gui.history.events {
+		if event.Type != "" && !seen[event.Type] {
+			seen[event.Type] = true
+			types = append(types, event.Type)
+		}
+	}
+	sort.Strings(types)
+	for _, eventType := range types {
+		actions = append(actions, projectAction{label: "Type: " + eventType, internal: "historyfilter", value: "type:" + eventType})
+	}
+	return append(actions, projectAction{label: "Clear filter", internal: "historyfilter", value: ""})
+}
+
+// openHistoryFilterInput asks for a history filter expression
+func (gui *Gui) openHistoryFilterInput() error {
+	gui.inputMode = "history-filter"
+	gui.inputReturnWindow = MainWindow
+	maxX, maxY := gui.g.Size()
+	return gui.layoutInputPrompt(gui.g, maxX, maxY)
+}
+
+func (gui *Gui) setHistoryFilter(filter string) error {
+	gui.history.filter = strings.TrimSpace(filter)
+	if gui.outputSelectMode && gui.outputSelectTabID == gui.history.tabID {
+		gui.outputSelectMode = false
+		gui.outputSelectTabID = ""
+	}
+	gui.renderHistoryView()
+	return nil
+}
+
+func (gui *Gui) setHistorySort(order string) error {
+	gui.history.sort = order
+	gui.renderHistoryView()
+	return nil
+}
+
+// historyEventUnderCursor returns the event on the selected (or top visible) line of the History tab
+func (gui *Gui) historyEventUnderCursor() (int, bool) {
+	line := gui.outputCursorLine(gui.history.tabID)
+	if line < 0 || line >= len(gui.history.lineEvents) || gui.history.lineEvents[line] < 0 {
+		return 0, false
+	}
+	return gui.history.lineEvents[line], true
+}
+
+// openHistoryEvent offers what can be done again with the event under the cursor
+func (gui *Gui) openHistoryEvent() error {
+	index, ok := gui.historyEventUnderCursor()
+	if !ok {
+		return nil
+	}
+	actions := historyEventActions(gui.history.events[index], index)
+	if len(actions) == 0 {
+		return gui.showMessage(historyViewTitle, "Nothing to rerun for this event.")
+	}
+	return gui.openProjectActionsModal("History Event", actions)
+}
+
+func historyEventActions(event historyEvent, index int) []projectAction {
+	value := strconv.Itoa(index)
+	var actions []projectAction
+	if event.Type == "command" && event.Command != "" && !strings.Contains(event.Command, "[REDACTED]") {
+		actions = append(actions, projectAction{label: "Rerun: " + event.Command, internal: "historyrerun", value: value})
+	}
+	if event.App != "" && event.Model != "" {
+		label := fmt.Sprintf("Reopen %s.%s at page %d", event.App, event.Model, max(event.Page, 1))
+		if event.RecordPK != "" {
+			label += " (pk " + event.RecordPK + ")"
+		}
+		actions = append(actions, projectAction{label: label, internal: "historymodel", value: value})
+	}
+	if event.SnapshotID != "" && event.Action != "delete" {
+		name := event.Snapshot
+		if name == "" {
+			name = event.SnapshotID
+		}
+		actions = append(actions, projectAction{label: "Restore snapshot " + name + "...", internal: "historyrestore", value: value})
+	}
+	return actions
+}
+
+// runHistoryAction performs an action picked from the History Event modal
+func (gui *Gui) runHistoryAction(action projectAction) error {
+	index, err := strconv.Atoi(action.value)
+	if err != nil || index < 0 || index >= len(gui.history.events) {
+		return nil
+	}
+	event := gui.history.events[index]
+	switch action.internal {
+	case "historyrerun":
+		return gui.runFavoriteCommand(event.Command)
+	case "historymodel":
+		return gui.reopenModel(event.App, event.Model, event.Page, event.RecordPK)
+	case "historyrestore":
+		return gui.restoreSnapshotByID(event.SnapshotID)
+	}
+	return nil
+}
+
+// reopenModel opens a model's data at a page and selects the record with the given primary key
+// when it is still on that page
+func (gui *Gui) reopenModel(app, model string, page int, recordPK string) error {
+	gui.currentApp = app
+	gui.currentModel = model
+	gui.currentPage = max(page, 1)
+	gui.selectedRecordIdx = 0
+	gui.currentWindow = MainWindow
+	gui.markStateDirty()
+	if _, err := gui.g.SetCurrentView(MainWindow); err != nil {
+		return err
+	}
+	return gui.loadRecords(func() {
+		if recordPK == "" {
+			return
+		}
+		for i, record := range gui.currentRecords {
+			if stringifyPK(record.PK) == recordPK {
+				if i != gui.selectedRecordIdx {
+					gui.selectedRecordIdx = i
+					_ = gui.renderRecords()
+				}
+				break
+			}
+		}
+	})
+}
+
+// restoreSnapshotByID opens the restore picker on a snapshot
+func (gui *Gui) restoreSnapshotByID(id string) error {
+	if err := gui.showRestoreMenu(); err != nil || gui.modalType != "restore" {
+		return err
+	}
+	for i, snapshot := range gui.restoreSnapshots {
+		if snapshot.ID == id {
+			gui.restoreIndex = i
+			return nil
+		}
+	}
+	_ = gui.closeModal()
+	return gui.showMessage("Restore Snapshot", fmt.Sprintf("Snapshot %s no longer exists.", id))
+}
+
+// formatHistoryEvents renders matching events as a table, errors on the line below. lineEvents
+// maps each output line to its event index.
+func formatHistoryEvents(events []historyEvent, filter historyFilter, expr, order string) (string, []int) {
+	var shown []int
+	for i := len(events) - 1; i >= 0; i-- {
+		if filter.matches(events[i]) {
+			shown = append(shown, i)
+		}
+	}
+	if order == historySortDuration {
+		sort.SliceStable(shown, func(a, b int) bool { return events[shown[a]].DurationMS > events[shown[b]].DurationMS })
+	}
+
+	var b strings.Builder
+	var lineEvents []int
+	orderLabel := "newest first"
+	if order == historySortDuration {
+		orderLabel = "slowest first"
+	}
+	fmt.Fprintf(&b, "%d of %d events (%s)", len(shown), len(events), orderLabel)
+	if expr != "" {
+		fmt.Fprintf(&b, " | filter: %s", expr)
+	}
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Enter reruns the command, reopens the model or restores the snapshot. F filters and sorts.")
+	fmt.Fprintln(&b)
+	lineEvents = append(lineEvents, -1, -1, -1)
+
+	header := []string{"TIME", "TYPE", "STATUS", "DURATION", "DETAIL"}
+	rows := [][]string{header}
+	for _, i := range shown {
+		event := events[i]
+		duration := "-"
+		if event.DurationMS > 0 {
+			duration = formatTestDuration(time.Duration(event.DurationMS) * time.Millisecond)
+		}
+		rows = append(rows, []string{historyEventTime(event), dashIfEmpty(event.Type), dashIfEmpty(event.Status), duration, historyEventDetail(event)})
+	}
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for c, cell := range row {
+			widths[c] = max(widths[c], len(cell))
+		}
+	}
+	writeRow := func(row []string) {
+		for c, cell := range row[:len(row)-1] {
+			fmt.Fprintf(&b, "%-*s  ", widths[c], cell)
+		}
+		fmt.Fprintln(&b, row[len(row)-1])
+	}
+	writeRow(header)
+	lineEvents = append(lineEvents, -1)
+	for n, i := range shown {
+		writeRow(rows[n+1])
+		lineEvents = append(lineEvents, i)
+		if events[i].Error != "" {
+			fmt.Fprintf(&b, "  ! %s\n", firstLine(events[i].Error))
+			lineEvents = append(lineEvents, i)
+		}
+	}
+	if len(shown) == 0 {
+		fmt.Fprintln(&b, "No events match the filter.")
+		lineEvents = append(lineEvents, -1)
+	}
+	return b.String(), lineEvents
+}
+
+func historyEventTime(event historyEvent) string {
+	at, err := time.Parse(time.RFC3339, event.Time)
+	if err != nil {
+		return dashIfEmpty(event.Time)
+	}
+	return at.Local().Format("2006-01-02 15:04:05")
+}
+
+// historyEventDetail summarizes what an event did
+func historyEventDetail(event historyEvent) string {
+	switch {
+	case event.Command != "":
+		return event.Command
+	case event.App != "" && event.Model != "":
+		detail := fmt.Sprintf("%s.%s page %d", event.App, event.Model, max(event.Page, 1))
+		if event.RecordPK != "" {
+			detail += " pk=" + event.RecordPK
+		}
+		return detail
+	case event.Snapshot != "" || event.SnapshotID != "":
+		return strings.TrimSpace(event.Action + " " + dashIfEmpty(event.Snapshot))
+	case len(event.Services) > 0:
+		return strings.TrimSpace(event.Action + " " + strings.Join(event.Services, ", "))
+	}
+	return dashIfEmpty(strings.TrimSpace(event.Action + " " + event.Source))
+}
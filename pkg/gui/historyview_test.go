@@ -0,0 +1,123 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseHistoryFilter(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	filter, err := parseHistoryFilter("type:command,model status:Error since:2d until:2026-03-03 Migrate", now)
+	if err != nil {
+		t.Fatalf("parseHistoryFilter returned error: %v", err)
+	}
+	if strings.Join(filter.types, " ") != "command model" || strings.Join(filter.statuses, " ") != "error" {
+		t.Fatalf("unexpected types/statuses: %v %v", filter.types, filter.statuses)
+	}
+	if !filter.since.Equal(now.AddDate(0, 0, -2)) || !filter.until.Equal(time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected range: %v - %v", filter.since, filter.until)
+	}
+	if strings.Join(filter.text, " ") != "migrate" {
+		t.Fatalf("unexpected text terms: %v", filter.text)
+	}
+	if _, err := parseHistoryFilter("since:yesterday", now); err == nil {
+		t.Fatalf("expected an error for an unparseable date")
+	}
+}
+
+func TestHistoryFilterMatches(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	events := []historyEvent{
+		{Time: "2026-03-01T09:00:00Z", Type: "command", Status: "success", Command: "python manage.py migrate", DurationMS: 1200},
+		{Time: "2026-03-02T09:00:00Z", Type: "model", Status: "ok", App: "blog", Model: "Post", Page: 3, RecordPK: "42"},
+		{Time: "2026-03-03T09:00:00Z", Type: "command", Status: "error", Command: "make test", DurationMS: 9000, Error: "exit status 2\nmore"},
+		{Time: "2026-03-04T09:00:00Z", Type: "snapshot", Status: "success", Action: "create", SnapshotID: "s1", Snapshot: "before-upgrade", DurationMS: 300},
+	}
+	tests := []struct {
+		expr string
+		want []int
+	}{
+		{expr: "", want: []int{0, 1, 2, 3}},
+		{expr: "type:command", want: []int{0, 2}},
+		{expr: "status:error", want: []int{2}},
+		{expr: "since:2026-03-02 until:2026-03-03", want: []int{1, 2}},
+		{expr: "blog.post", want: nil},
+		{expr: "blog post", want: []int{1}},
+		{expr: "upgrade", want: []int{3}},
+		{expr: "exit status", want: []int{2}},
+	}
+	for _, tc := range tests {
+		filter, err := parseHistoryFilter(tc.expr, now)
+		if err != nil {
+			t.Fatalf("parseHistoryFilter(%q) returned error: %v", tc.expr, err)
+		}
+		var got []int
+		for i, event := range events {
+			if filter.matches(event) {
+				got = append(got, i)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tc.want) {
+			t.Fatalf("filter %q matched %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestFormatHistoryEvents(t *testing.T) {
+	events := []historyEvent{
+		{Time: "2026-03-01T09:00:00Z", Type: "command", Status: "success", Command: "python manage.py migrate", DurationMS: 1200},
+		{Time: "2026-03-02T09:00:00Z", Type: "model", Status: "ok", App: "blog", Model: "Post", Page: 3, RecordPK: "42"},
+		{Time: "2026-03-03T09:00:00Z", Type: "command", Status: "error", Command: "make test", DurationMS: 9000, Error: "exit status 2\nmore"},
+		{Time: "2026-03-04T09:00:00Z", Type: "snapshot", Status: "success", Action: "create", SnapshotID: "s1", Snapshot: "before-upgrade", DurationMS: 300},
+	}
+	text, lineEvents := formatHistoryEvents(events, historyFilter{}, "", historySortTime)
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) != len(lineEvents) {
+		t.Fatalf("expected one mapping per line, got %d lines and %d mappings", len(lines), len(lineEvents))
+	}
+	var order []int
+	for i, line := range lines {
+		if lineEvents[i] >= 0 && !strings.HasPrefix(line, "  ! ") {
+			order = append(order, lineEvents[i])
+		}
+		if strings.HasPrefix(line, "  ! ") && (lineEvents[i] != 2 || line != "  ! exit status 2") {
+			t.Fatalf("unexpected error line %q mapped to %d", line, lineEvents[i])
+		}
+	}
+	if len(order) != 4 || order[0] != 3 || order[3] != 0 {
+		t.Fatalf("expected newest first, got %v", order)
+	}
+	if !strings.Contains(text, "blog.Post page 3 pk=42") || !strings.Contains(text, "create before-upgrade") {
+		t.Fatalf("unexpected details:\n%s", text)
+	}
+
+	_, lineEvents = formatHistoryEvents(events, historyFilter{}, "", historySortDuration)
+	order = order[:0]
+	for i, index := range lineEvents {
+		if index >= 0 && (i == 0 || lineEvents[i-1] != index) {
+			order = append(order, index)
+		}
+	}
+	if len(order) != 4 || order[0] != 2 || order[1] != 0 || order[2] != 3 {
+		t.Fatalf("expected slowest first, got %v", order)
+	}
+}
+
+func TestHistoryEventActions(t *testing.T) {
+	rerun := historyEvent{Type: "command", Status: "success", Command: "python manage.py migrate"}
+	if actions := historyEventActions(rerun, 0); len(actions) != 1 || actions[0].internal != "historyrerun" || actions[0].value != "0" {
+		t.Fatalf("expected a rerun action, got %v", actions)
+	}
+	if actions := historyEventActions(historyEvent{Type: "model", App: "blog", Model: "Post", Page: 3, RecordPK: "42"}, 1); len(actions) != 1 || actions[0].label != "Reopen blog.Post at page 3 (pk 42)" {
+		t.Fatalf("expected a reopen action, got %v", actions)
+	}
+	if actions := historyEventActions(historyEvent{Type: "snapshot", Action: "create", SnapshotID: "s1"}, 3); len(actions) != 1 || actions[0].internal != "historyrestore" {
+		t.Fatalf("expected a restore action, got %v", actions)
+	}
+	redacted := historyEvent{Type: "command", Command: "psql --password=[REDACTED]"}
+	if actions := historyEventActions(redacted, 0); len(actions) != 0 {
+		t.Fatalf("expected redacted commands not to be rerun, got %v", actions)
+	}
+}
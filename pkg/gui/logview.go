@@ -0,0 +1,521 @@
+package gui
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Logs tab filters
+const (
+	logFilterAll    = ""
+	logFilterErrors = "errors"
+	logFilterStatus = "status>=400"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// logViewState is how a Logs tab presents its text. The raw text is never changed.
+type logViewState struct {
+	raw      bool         // show the unparsed text
+	filter   string       // logFilterAll, logFilterErrors or logFilterStatus
+	path     string       // only requests whose path contains this
+	expanded map[int]bool // tracebacks shown in full, keyed by their first raw line
+}
+
+// label describes active filters for the output title
+func (s *logViewState) label() string {
+	if s == nil {
+		return ""
+	}
+	if s.raw {
+		return "raw"
+	}
+	parts := make([]string, 0, 2)
+	if s.filter != logFilterAll {
+		parts = append(parts, s.filter)
+	}
+	if s.path != "" {
+		parts = append(parts, "path~"+s.path)
+	}
+	return strings.Join(parts, " ")
+}
+
+// requestLog is one HTTP request line from runserver, runserver_plus, gunicorn or uvicorn
+type requestLog struct {
+	Method   string
+	Path     string
+	Status   int
+	Size     int // -1 when not logged
+	Duration time.Duration
+}
+
+// requestLinePattern matches `"GET /path HTTP/1.1" 200 1234` with an optional trailing timing
+var requestLinePattern = regexp.MustCompile(`"([A-Z]+) (\S+) HTTP/[0-9.]+" (\d{3})(?: (\d+|-))?(?:.*?\b(\d+(?:\.\d+)?) ?(ms|s)\b)?`)
+
+var errorLinePattern = regexp.MustCompile(`(?i)\b(error|critical|exception)\b`)
+
+func parseRequestLog(line string) (requestLog, bool) {
+	if !strings.Contains(line, " HTTP/") {
+		return requestLog{}, false
+	}
+	m := requestLinePattern.FindStringSubmatch(line)
+	if m == nil {
+		return requestLog{}, false
+	}
+	status, _ := strconv.Atoi(m[3])
+	req := requestLog{Method: m[1], Path: m[2], Status: status, Size: -1}
+	if size, err := strconv.Atoi(m[4]); err == nil {
+		req.Size = size
+	}
+	if m[5] != "" {
+		value, _ := strconv.ParseFloat(m[5], 64)
+		unit := time.Second
+		if m[6] == "ms" {
+			unit = time.Millisecond
+		}
+		req.Duration = time.Duration(value * float64(unit))
+	}
+	return req, true
+}
+
+type logEntryKind int
+
+const (
+	logEntryLine logEntryKind = iota
+	logEntryRequest
+	logEntryTraceback
+)
+
+// logEntry is a run of raw lines: a single line, a request, or a whole traceback
+type logEntry struct {
+	kind    logEntryKind
+	start   int // first raw line
+	end     int // last raw line, inclusive
+	request requestLog
+	summary string // exception line for tracebacks
+}
+
+// parseLogEntries groups raw log lines, folding each traceback through its exception line
+func parseLogEntries(lines []string) []logEntry {
+	entries := make([]logEntry, 0, len(lines))
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(strings.TrimSpace(line), "Traceback (most recent call last)") {
+			end := i
+			for end+1 < len(lines) {
+				next := lines[end+1]
+				if next == "" || strings.HasPrefix(next, " ") || strings.HasPrefix(next, "\t") ||
+					strings.HasPrefix(next, "During handling of the above exception") ||
+					strings.HasPrefix(next, "The above exception was the direct cause") ||
+					strings.HasPrefix(next, "Traceback (most recent call last)") {
+					end++
+					continue
+				}
+				// The exception line closes the block unless a chained traceback follows.
+				end++
+				j := end + 1
+				for j < len(lines) && lines[j] == "" {
+					j++
+				}
+				if j < len(lines) && (strings.HasPrefix(lines[j], "During handling of the above exception") ||
+					strings.HasPrefix(lines[j], "The above exception was the direct cause")) {
+					end = j
+					continue
+				}
+				break
+			}
+			summary := ""
+			for k := end; k >= i && summary == ""; k-- {
+				summary = strings.TrimSpace(lines[k])
+			}
+			entries = append(entries, logEntry{kind: logEntryTraceback, start: i, end: end, summary: summary})
+			i = end
+			continue
+		}
+		if req, ok := parseRequestLog(line); ok {
+			entries = append(entries, logEntry{kind: logEntryRequest, start: i, end: i, request: req})
+			continue
+		}
+		entries = append(entries, logEntry{kind: logEntryLine, start: i, end: i})
+	}
+	return entries
+}
+
+func (s *logViewState) matches(entry logEntry, lines []string) bool {
+	if s.path != "" && (entry.kind != logEntryRequest || !strings.Contains(entry.request.Path, s.path)) {
+		return false
+	}
+	switch s.filter {
+	case logFilterErrors:
+		switch entry.kind {
+		case logEntryTraceback:
+			return true
+		case logEntryRequest:
+			return entry.request.Status >= 500
+		default:
+			return errorLinePattern.MatchString(lines[entry.start])
+		}
+	case logFilterStatus:
+		return entry.kind == logEntryTraceback || (entry.kind == logEntryRequest && entry.request.Status >= 400)
+	}
+	return true
+}
+
+func statusColor(status int) string {
+	switch {
+	case status >= 500:
+		return ansiRed
+	case status >= 400:
+		return ansiYellow
+	case status >= 300:
+		return ansiCyan
+	default:
+		return ansiGreen
+	}
+}
+
+// outputRow is one displayed line of an output tab, mapped back to the raw lines it covers
+type outputRow struct {
+	text      string
+	color     string
+	start     int
+	end       int
+	traceback int // first raw line of the traceback this row belongs to, or -1
+}
+
+func rawOutputRows(lines []string) []outputRow {
+	rows := make([]outputRow, len(lines))
+	for i, line := range lines {
+		rows[i] = outputRow{text: line, start: i, end: i, traceback: -1}
+	}
+	return rows
+}
+
+// buildLogRows renders parsed log entries: filtered, colorized, with collapsed tracebacks
+func buildLogRows(lines []string, state *logViewState) []outputRow {
+	rows := make([]outputRow, 0, len(lines))
+	for _, entry := range parseLogEntries(lines) {
+		if !state.matches(entry, lines) {
+			continue
+		}
+		switch entry.kind {
+		case logEntryTraceback:
+			if state.expanded[entry.start] {
+				for i := entry.start; i <= entry.end; i++ {
+					text := "  " + lines[i]
+					if i == entry.start {
+						text = "▼ " + lines[i]
+					}
+					rows = append(rows, outputRow{text: text, color: ansiRed, start: i, end: i, traceback: entry.start})
+				}
+				continue
+			}
+			text := fmt.Sprintf("▶ %s (%d lines, z to expand)", entry.summary, entry.end-entry.start+1)
+			rows = append(rows, outputRow{text: text, color: ansiRed, start: entry.start, end: entry.end, traceback: entry.start})
+		case logEntryRequest:
+			rows = append(rows, outputRow{text: lines[entry.start], color: statusColor(entry.request.Status), start: entry.start, end: entry.end, traceback: -1})
+		default:
+			rows = append(rows, outputRow{text: lines[entry.start], start: entry.start, end: entry.end, traceback: -1})
+		}
+	}
+	return rows
+}
+
+// logState returns the tab's log view, creating the default structured view
+func (tab *outputTabState) logState() *logViewState {
+	if tab.logView == nil {
+		tab.logView = &logViewState{expanded: make(map[int]bool)}
+	}
+	return tab.logView
+}
+
+// outputRows returns the displayed rows of a tab, narrowed to search matches in the filter view
+func (gui *Gui) outputRows(tabID string) []outputRow {
+	rows := gui.baseOutputRows(tabID)
+	if tab, ok := gui.outputTabs[tabID]; ok {
+		return tab.searchRows(rows)
+	}
+	return rows
+}
+
+// baseOutputRows returns the rows of a tab before searching; Logs tabs are parsed, without their
+// own colors, unless switched to raw
+func (gui *Gui) baseOutputRows(tabID string) []outputRow {
+	text := gui.outputTextForTab(tabID)
+	tab, ok := gui.outputTabs[tabID]
+	if !ok || tab.route != OutputTabLogs || (tab.logView != nil && tab.logView.raw) {
+		return rawOutputRows(outputLines(text))
+	}
+	return buildLogRows(outputLines(stripANSI(text)), tab.logState())
+}
+
+// outputViewLines returns the displayed lines of a tab as plain text
+func (gui *Gui) outputViewLines(tabID string) []string {
+	rows := gui.outputRows(tabID)
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = row.text
+	}
+	return lines
+}
+
+// outputRowsRawText returns the raw text behind displayed rows start..end, so copying a
+// collapsed traceback copies the whole traceback
+func (gui *Gui) outputRowsRawText(tabID string, start, end int) string {
+	rows := gui.outputRows(tabID)
+	if len(rows) == 0 {
+		return ""
+	}
+	start = clampSelection(start, len(rows))
+	end = clampSelection(end, len(rows))
+	lines := outputLines(gui.outputTextForTab(tabID))
+	var parts []string
+	for _, row := range rows[start : end+1] {
+		parts = append(parts, lines[row.start:row.end+1]...)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func (gui *Gui) currentLogTab() (*outputTabState, bool) {
+	tabID := gui.resolveOutputTabID("", false)
+	tab, ok := gui.outputTabs[tabID]
+	if !ok || tab.route != OutputTabLogs {
+		return nil, false
+	}
+	return tab, true
+}
+
+func (gui *Gui) logViewActions() []projectAction {
+	tab, _ := gui.currentLogTab()
+	state := tab.logState()
+	mark := func(label string, on bool) string {
+		if on {
+			return label + " (current)"
+		}
+		return label
+	}
+	pathLabel := "Path contains..."
+	if state.path != "" {
+		pathLabel = fmt.Sprintf("Path contains... (%s)", state.path)
+	}
+	rawLabel := "Show raw text"
+	if state.raw {
+		rawLabel = "Show parsed view"
+	}
+	return []projectAction{
+		{label: mark("All lines", !state.raw && state.filter == logFilterAll && state.path == ""), internal: "logfilter", value: logFilterAll},
+		{label: mark("Errors only", !state.raw && state.filter == logFilterErrors), internal: "logfilter", value: logFilterErrors},
+		{label: mark("Status >= 400", !state.raw && state.filter == logFilterStatus), internal: "logfilter", value: logFilterStatus},
+		{label: pathLabel, internal: "logpath"},
+		{label: "Request summary by path", internal: "logsummary"},
+		{label: "Expand all tracebacks", internal: "logexpand", value: "all"},
+		{label: "Collapse all tracebacks", internal: "logexpand", value: "none"},
+		{label: rawLabel, internal: "lograw"},
+	}
+}
+
+// openLogViewModal offers filters and views for the current Logs tab
+func (gui *Gui) openLogViewModal() error {
+	if _, ok := gui.currentLogTab(); !ok {
+		return gui.showMessage("Log View", "Filters apply to Logs tabs. Press o to switch to the Logs route.")
+	}
+	return gui.openProjectActionsModal("Log View", gui.logViewActions())
+}
+
+func (gui *Gui) runLogViewAction(action projectAction) error {
+	tab, ok := gui.currentLogTab()
+	if !ok {
+		return nil
+	}
+	state := tab.logState()
+	switch action.internal {
+	case "logfilter":
+		state.raw = false
+		state.filter = action.value
+		if action.value == logFilterAll {
+			state.path = ""
+		}
+	case "logpath":
+		state.raw = false
+		gui.inputMode = "log-path"
+		gui.inputReturnWindow = MainWindow
+		maxX, maxY := gui.g.Size()
+		return gui.layoutInputPrompt(gui.g, maxX, maxY)
+	case "logsummary":
+		return gui.showRequestSummary(tab)
+	case "logexpand":
+		state.raw = false
+		state.expanded = make(map[int]bool)
+		if action.value == "all" {
+			for _, entry := range parseLogEntries(outputLines(stripANSI(tab.text))) {
+				if entry.kind == logEntryTraceback {
+					state.expanded[entry.start] = true
+				}
+			}
+		}
+	case "lograw":
+		state.raw = !state.raw
+	}
+	gui.resetLogViewPosition(tab)
+	return nil
+}
+
+// setLogPathFilter applies the "path contains" filter typed into the input bar
+func (gui *Gui) setLogPathFilter(path string) error {
+	tab, ok := gui.currentLogTab()
+	if !ok {
+		return nil
+	}
+	tab.logState().path = strings.TrimSpace(path)
+	gui.resetLogViewPosition(tab)
+	return nil
+}
+
+// resetLogViewPosition follows the tail again, since row positions change with the view
+func (gui *Gui) resetLogViewPosition(tab *outputTabState) {
+	if gui.outputSelectTabID == tab.id {
+		gui.outputSelectMode = false
+		gui.outputSelectTabID = ""
+	}
+	tab.autoscroll = true
+	tab.originX = 0
+	tab.originY = outputOriginTail
+	gui.refreshOutputView()
+}
+
+// toggleTraceback expands or collapses the traceback under the cursor, or the nearest one above it
+func (gui *Gui) toggleTraceback(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentWindow != MainWindow || gui.currentModel != "" {
+		return nil
+	}
+	tab, ok := gui.currentLogTab()
+	if !ok || (tab.logView != nil && tab.logView.raw) {
+		return nil
+	}
+	rows := gui.outputRows(tab.id)
+	if len(rows) == 0 {
+		return nil
+	}
+	idx := gui.currentOutputLineIndex(tab.id)
+	if gui.outputSelectMode && gui.outputSelectTabID == tab.id {
+		idx = clampSelection(gui.outputSelectCursor, len(rows))
+	}
+	for i := idx; i >= 0; i-- {
+		if rows[i].traceback < 0 {
+			continue
+		}
+		state := tab.logState()
+		start := rows[i].traceback
+		state.expanded[start] = !state.expanded[start]
+		// Keep the traceback header where the cursor was.
+		for j, row := range gui.outputRows(tab.id) {
+			if row.traceback == start {
+				tab.autoscroll = false
+				tab.originX = 0
+				tab.originY = j
+				if gui.outputSelectMode && gui.outputSelectTabID == tab.id {
+					gui.outputSelectAnchor, gui.outputSelectCursor = j, j
+				}
+				break
+			}
+		}
+		gui.refreshOutputView()
+		return nil
+	}
+	return gui.showMessage("Log View", "No traceback at or above the cursor.")
+}
+
+// requestStats aggregates requests to one path
+type requestStats struct {
+	path     string
+	count    int
+	byClass  [6]int // index status/100
+	bytes    int
+	sized    int
+	duration time.Duration
+	timed    int
+}
+
+// summarizeRequests counts requests per path (query strings dropped), busiest first
+func summarizeRequests(lines []string) []requestStats {
+	byPath := make(map[string]*requestStats)
+	for _, entry := range parseLogEntries(lines) {
+		if entry.kind != logEntryRequest {
+			continue
+		}
+		req := entry.request
+		path, _, _ := strings.Cut(req.Path, "?")
+		stats, ok := byPath[path]
+		if !ok {
+			stats = &requestStats{path: path}
+			byPath[path] = stats
+		}
+		stats.count++
+		if class := req.Status / 100; class >= 1 && class <= 5 {
+			stats.byClass[class]++
+		}
+		if req.Size >= 0 {
+			stats.bytes += req.Size
+			stats.sized++
+		}
+		if req.Duration > 0 {
+			stats.duration += req.Duration
+			stats.timed++
+		}
+	}
+	summary := make([]requestStats, 0, len(byPath))
+	for _, stats := range byPath {
+		summary = append(summary, *stats)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].count != summary[j].count {
+			return summary[i].count > summary[j].count
+		}
+		return summary[i].path < summary[j].path
+	})
+	return summary
+}
+
+func formatRequestSummary(summary []requestStats) string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COUNT\t2xx\t3xx\t4xx\t5xx\tAVG SIZE\tAVG TIME\tPATH")
+	for _, s := range summary {
+		avgSize, avgTime := "-", "-"
+		if s.sized > 0 {
+			avgSize = strconv.Itoa(s.bytes / s.sized)
+		}
+		if s.timed > 0 {
+			avgTime = (s.duration / time.Duration(s.timed)).Round(time.Millisecond).String()
+		}
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n", s.count, s.byClass[2], s.byClass[3], s.byClass[4], s.byClass[5], avgSize, avgTime, s.path)
+	}
+	_ = w.Flush()
+	return b.String()
+}
+
+func (gui *Gui) showRequestSummary(tab *outputTabState) error {
+	summary := summarizeRequests(outputLines(stripANSI(tab.text)))
+	title := "Requests: " + gui.outputTitleForTab(tab.id)
+	tabID := gui.startCommandOutputTab(title)
+	if len(summary) == 0 {
+		gui.appendOutput(tabID, "No request log lines in this tab yet.\n")
+	} else {
+		gui.appendOutput(tabID, formatRequestSummary(summary))
+	}
+	gui.refreshOutputView()
+	return gui.switchPanel(MainWindow)
+}
@@ -0,0 +1,109 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const sampleServerLog = `Starting development server at http://127.0.0.1:8000/
+[18/Oct/2026 10:00:01] "GET /api/items/?page=2 HTTP/1.1" 200 5120
+[18/Oct/2026 10:00:02] "POST /api/items/ HTTP/1.1" 400 87
+Internal Server Error: /api/orders/
+Traceback (most recent call last):
+  File "/app/orders/views.py", line 12, in list
+    raise ValueError("boom")
+ValueError: boom
+
+During handling of the above exception, another exception occurred:
+
+Traceback (most recent call last):
+  File "/app/core/middleware.py", line 8, in __call__
+KeyError: 'user'
+[18/Oct/2026 10:00:03] "GET /api/orders/ HTTP/1.1" 500 145
+INFO:     127.0.0.1:51234 - "GET /api/items/ HTTP/1.1" 200 OK
+127.0.0.1 - - [18/Oct/2026:10:00:04 +0000] "GET /health HTTP/1.1" 200 2 in 12ms`
+
+func TestParseRequestLog(t *testing.T) {
+	req, ok := parseRequestLog(`[18/Oct/2026 10:00:01] "GET /api/items/?page=2 HTTP/1.1" 200 5120`)
+	if !ok || req.Method != "GET" || req.Path != "/api/items/?page=2" || req.Status != 200 || req.Size != 5120 {
+		t.Fatalf("unexpected runserver request %+v", req)
+	}
+	req, ok = parseRequestLog(`INFO:     127.0.0.1:51234 - "GET /api/items/ HTTP/1.1" 200 OK`)
+	if !ok || req.Status != 200 || req.Size != -1 {
+		t.Fatalf("unexpected uvicorn request %+v", req)
+	}
+	req, ok = parseRequestLog(`127.0.0.1 - - [18/Oct/2026:10:00:04 +0000] "GET /health HTTP/1.1" 200 2 in 12ms`)
+	if !ok || req.Duration != 12*time.Millisecond {
+		t.Fatalf("expected request timing, got %+v", req)
+	}
+	if _, ok := parseRequestLog("Watching for file changes with StatReloader"); ok {
+		t.Fatal("did not expect a request")
+	}
+}
+
+func TestBuildLogRowsFoldsTracebacksAndFilters(t *testing.T) {
+	lines := outputLines(sampleServerLog)
+	state := &logViewState{expanded: make(map[int]bool)}
+
+	rows := buildLogRows(lines, state)
+	var folded []outputRow
+	for _, row := range rows {
+		if row.traceback >= 0 {
+			folded = append(folded, row)
+		}
+	}
+	if len(folded) != 1 || !strings.Contains(folded[0].text, "KeyError: 'user'") || folded[0].end-folded[0].start != 9 {
+		t.Fatalf("expected one chained traceback folded to its last exception, got %+v", folded)
+	}
+
+	state.expanded[folded[0].start] = true
+	if expanded := buildLogRows(lines, state); len(expanded) != len(rows)+9 {
+		t.Fatalf("expected the expanded traceback to add 9 rows, got %d -> %d", len(rows), len(expanded))
+	}
+
+	state.filter = logFilterStatus
+	var texts []string
+	for _, row := range buildLogRows(lines, state) {
+		if row.traceback < 0 {
+			texts = append(texts, row.text)
+		}
+	}
+	if len(texts) != 2 || !strings.Contains(texts[0], " 400 ") || !strings.Contains(texts[1], " 500 ") {
+		t.Fatalf("expected only 4xx/5xx requests, got %v", texts)
+	}
+
+	state.filter = logFilterAll
+	state.path = "/health"
+	if rows := buildLogRows(lines, state); len(rows) != 1 || rows[0].color != ansiGreen {
+		t.Fatalf("expected the single green /health request, got %+v", rows)
+	}
+}
+
+func TestOutputRowsRawTextCopiesWholeTraceback(t *testing.T) {
+	gui := &Gui{outputTabs: map[string]*outputTabState{
+		"logs-1": {id: "logs-1", route: OutputTabLogs, text: sampleServerLog},
+	}}
+	rows := gui.outputRows("logs-1")
+	for i, row := range rows {
+		if row.traceback < 0 {
+			continue
+		}
+		text := gui.outputRowsRawText("logs-1", i, i)
+		if !strings.HasPrefix(text, "Traceback (most recent call last):") || !strings.HasSuffix(text, "KeyError: 'user'") {
+			t.Fatalf("expected the raw traceback, got %q", text)
+		}
+		return
+	}
+	t.Fatal("expected a traceback row")
+}
+
+func TestSummarizeRequests(t *testing.T) {
+	summary := summarizeRequests(outputLines(sampleServerLog))
+	if len(summary) != 3 || summary[0].path != "/api/items/" || summary[0].count != 3 || summary[0].byClass[4] != 1 {
+		t.Fatalf("unexpected summary %+v", summary)
+	}
+	if table := formatRequestSummary(summary); !strings.Contains(table, "12ms") {
+		t.Fatalf("expected the average time for /health, got:\n%s", table)
+	}
+}
@@ -0,0 +1,233 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const manageCommandsTitle = "Management Commands"
+
+// manageCommandsTaskField is the form field that saves the composed command as a project task
+const manageCommandsTaskField = "save as task"
+
+// commandBrowser is the Management Commands tab and the argument form of the selected command
+type commandBrowser struct {
+	tabID        string
+	commands     []django.ManagementCommand
+	filter       string
+	lineCommands []int // Index into commands for each line, -1 for other lines
+	described    map[string]django.ManagementCommand
+	form         django.ManagementCommand // Command whose argument form is open
+	loading      bool                     // Command list is loading for completion
+	describing   map[string]bool          // Commands being introspected for completion
+}
+
+// showManagementCommands loads `manage.py help` in the background into the Management Commands tab
+func (gui *Gui) showManagementCommands() error {
+	tabID := gui.commands.tabID
+	if _, ok := gui.outputTabs[tabID]; ok {
+		gui.resetOutput(tabID, manageCommandsTitle)
+		gui.switchOutputTab(tabID)
+	} else {
+		tabID = gui.startCommandOutputTab(manageCommandsTitle)
+	}
+	gui.commands.tabID = tabID
+	gui.commands.lineCommands = nil
+	gui.appendOutput(tabID, "Loading management commands...\n")
+	_ = gui.switchPanel(MainWindow)
+	gui.refreshOutputView()
+
+	project := gui.project
+	go func() {
+		commands, err := project.ListManagementCommands()
+		gui.g.Update(func(g *gocui.Gui) error {
+			if err != nil {
+				gui.resetOutput(tabID, manageCommandsTitle)
+				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n", err))
+				gui.rememberError("commands", err.Error())
+				gui.refreshOutputView()
+				return nil
+			}
+			gui.commands.commands = commands
+			gui.commands.described = nil
+			gui.renderManagementCommands()
+			return nil
+		})
+	}()
+	return nil
+}
+
+// renderManagementCommands redraws the Management Commands tab with the current filter
+func (gui *Gui) renderManagementCommands() {
+	tabID := gui.commands.tabID
+	if _, ok := gui.outputTabs[tabID]; !ok {
+		return
+	}
+	text, lineCommands := formatManagementCommands(gui.commands.commands, gui.commands.filter)
+	gui.resetOutput(tabID, manageCommandsTitle)
+	gui.outputTabs[tabID].originY = 0
+	gui.commands.lineCommands = lineCommands
+	gui.appendOutput(tabID, text)
+	gui.refreshOutputView()
+}
+
+// openCommandFilterInput asks for a management commands filter
+func (gui *Gui) openCommandFilterInput() error {
+	gui.inputMode = "command-filter"
+	gui.inputReturnWindow = MainWindow
+	maxX, maxY := gui.g.Size()
+	return gui.layoutInputPrompt(gui.g, maxX, maxY)
+}
+
+func (gui *Gui) setCommandFilter(filter string) error {
+	gui.commands.filter = strings.TrimSpace(filter)
+	if gui.outputSelectMode && gui.outputSelectTabID == gui.commands.tabID {
+		gui.outputSelectMode = false
+		gui.outputSelectTabID = ""
+	}
+	gui.renderManagementCommands()
+	return nil
+}
+
+// openCommandUnderCursor introspects the command under the cursor and opens its argument form
+func (gui *Gui) openCommandUnderCursor() error {
+	line := gui.outputCursorLine(gui.commands.tabID)
+	if line < 0 || line >= len(gui.commands.lineCommands) || gui.commands.lineCommands[line] < 0 {
+		return nil
+	}
+	command := gui.commands.commands[gui.commands.lineCommands[line]]
+	if described, ok := gui.commands.described[command.Name]; ok {
+		return gui.openCommandForm(described)
+	}
+
+	ctx, op := gui.beginContextOperation("describe "+command.Name, gui.commands.tabID)
+	project := gui.project
+	go func() {
+		described, err := project.DescribeManagementCommand(command)
+		if err == nil {
+			err = ctx.Err()
+		}
+		gui.g.Update(func(g *gocui.Gui) error {
+			if err = gui.endOperation(op, err); err != nil {
+				gui.rememberError("commands", err.Error())
+				return gui.showMessage(manageCommandsTitle, fmt.Sprintf("Could not read the arguments of %s: %v", command.Name, err))
+			}
+			if gui.commands.described == nil {
+				gui.commands.described = make(map[string]django.ManagementCommand)
+			}
+			gui.commands.described[command.Name] = described
+			return gui.openCommandForm(described)
+		})
+	}()
+	return nil
+}
+
+// openCommandForm opens a form with one field per argument. Ctrl+S runs the command.
+func (gui *Gui) openCommandForm(command django.ManagementCommand) error {
+	fields, values := commandFormFields(command)
+	gui.commands.form = command
+	gui.openFormModal("command", fields, values)
+	gui.modalTitle = fmt.Sprintf("manage.py %s (Ctrl+S runs)", command.Name)
+	return nil
+}
+
+// commandFormFields turns argparse arguments into form fields keyed by dest, prefilled with defaults
+func commandFormFields(command django.ManagementCommand) ([]map[string]interface{}, map[string]string) {
+	fields := make([]map[string]interface{}, 0, len(command.Arguments)+1)
+	values := make(map[string]string)
+	for _, arg := range command.Arguments {
+		kind := arg.Flag()
+		if arg.Positional() {
+			kind = "positional"
+		}
+		required := arg.Required || (arg.Positional() && arg.Nargs != "?" && arg.Nargs != "*")
+		field := map[string]interface{}{
+			"name":  arg.Dest,
+			"type":  kind,
+			"null":  !required,
+			"blank": !required,
+			"help":  strings.TrimSpace(arg.Help),
+		}
+		choices := arg.Choices
+		if arg.Boolean() {
+			choices = []string{"true", "false"}
+			values[arg.Dest] = "false"
+		} else {
+			values[arg.Dest] = arg.Default
+		}
+		if len(choices) > 0 {
+			options := make([]interface{}, 0, len(choices))
+			for _, choice := range choices {
+				options = append(options, map[string]interface{}{"value": choice, "label": choice})
+			}
+			field["choices"] = options
+		}
+		fields = append(fields, field)
+	}
+	fields = append(fields, map[string]interface{}{
+		"name": manageCommandsTaskField, "type": "task label", "null": true, "blank": true,
+		"help": "Also add the command to the project tasks under this label",
+	})
+	return fields, values
+}
+
+// submitCommandModal runs the composed command and saves it as a project task when labelled
+func (gui *Gui) submitCommandModal() error {
+	args, err := django.CommandArgs(gui.commands.form, gui.modalValues)
+	if err != nil {
+		gui.modalMessage = err.Error()
+		return nil
+	}
+	if label := strings.TrimSpace(gui.modalValues[manageCommandsTaskField]); label != "" {
+		if err := gui.addProjectTask(projectTaskEntry{
+			Label:   label,
+			Command: "python manage.py " + django.ShellCommandLine(args...),
+		}); err != nil {
+			gui.modalMessage = fmt.Sprintf("Saving the task failed: %v", err)
+			return nil
+		}
+	}
+	if err := gui.closeModal(); err != nil {
+		return err
+	}
+	return gui.runManageCommand(args[0], args...)
+}
+
+// formatManagementCommands renders the commands grouped by app. lineCommands maps each output line
+// to its command index.
+func formatManagementCommands(commands []django.ManagementCommand, filter string) (string, []int) {
+	var b strings.Builder
+	var lineCommands []int
+	fmt.Fprintf(&b, "%d management commands", len(commands))
+	if filter != "" {
+		fmt.Fprintf(&b, " | filter: %s", filter)
+	}
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Enter opens the argument form. F filters by command or app.")
+	lineCommands = append(lineCommands, -1, -1)
+
+	needle := strings.ToLower(filter)
+	app := ""
+	shown := 0
+	for i, command := range commands {
+		if needle != "" && !strings.Contains(strings.ToLower(command.Name), needle) && !strings.Contains(strings.ToLower(command.App), needle) {
+			continue
+		}
+		if shown == 0 || command.App != app {
+			app = command.App
+			fmt.Fprintf(&b, "\n[%s]\n", app)
+			lineCommands = append(lineCommands, -1, -1)
+		}
+		fmt.Fprintf(&b, "    %s\n", command.Name)
+		lineCommands = append(lineCommands, i)
+		shown++
+	}
+	if shown == 0 {
+		fmt.Fprintln(&b, "\nNo commands match the filter.")
+		lineCommands = append(lineCommands, -1, -1)
+	}
+	return b.String(), lineCommands
+}
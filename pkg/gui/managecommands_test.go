@@ -0,0 +1,61 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestFormatManagementCommandsGroupsByApp(t *testing.T) {
+	commands := []django.ManagementCommand{
+		{Name: "changepassword", App: "auth"},
+		{Name: "createsuperuser", App: "auth"},
+		{Name: "migrate", App: "django"},
+	}
+	text, lineCommands := formatManagementCommands(commands, "")
+	lines := strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+	if len(lines) != len(lineCommands) {
+		t.Fatalf("expected one mapping per line, got %d lines and %d mappings", len(lines), len(lineCommands))
+	}
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "migrate" && lineCommands[i] != 2 {
+			t.Fatalf("expected migrate line to map to command 2, got %d", lineCommands[i])
+		}
+		if line == "[auth]" && lineCommands[i] != -1 {
+			t.Fatalf("expected app header to map to no command")
+		}
+	}
+
+	text, lineCommands = formatManagementCommands(commands, "create")
+	if strings.Contains(text, "migrate") || !strings.Contains(text, "createsuperuser") || strings.Contains(text, "[django]") {
+		t.Fatalf("unexpected filtered output:\n%s", text)
+	}
+	if lineCommands[len(lineCommands)-1] != 1 {
+		t.Fatalf("expected last line to be createsuperuser, got %v", lineCommands)
+	}
+}
+
+func TestCommandFormFields(t *testing.T) {
+	command := django.ManagementCommand{
+		Name: "migrate",
+		Arguments: []django.CommandArgument{
+			{Dest: "app_label", Nargs: "?", Help: "App label of an application to synchronize the state."},
+			{Dest: "verbosity", Flags: []string{"-v", "--verbosity"}, Action: "store", Default: "1", Choices: []string{"0", "1", "2", "3"}},
+			{Dest: "plan", Flags: []string{"--plan"}, Action: "store_true"},
+		},
+	}
+	fields, values := commandFormFields(command)
+	if len(fields) != 4 || fields[3]["name"] != manageCommandsTaskField {
+		t.Fatalf("expected one field per argument plus the task label, got %v", fields)
+	}
+	if fields[0]["type"] != "positional" || fields[0]["null"] != true {
+		t.Fatalf("expected optional positional field, got %v", fields[0])
+	}
+	if fields[1]["type"] != "--verbosity" || values["verbosity"] != "1" {
+		t.Fatalf("expected verbosity flag with its default, got %v / %q", fields[1], values["verbosity"])
+	}
+	if choices, ok := fields[2]["choices"].([]interface{}); !ok || len(choices) != 2 || values["plan"] != "false" {
+		t.Fatalf("expected boolean flag to offer true/false, got %v", fields[2])
+	}
+}
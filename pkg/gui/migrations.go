@@ -0,0 +1,400 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/config"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+// migrationHealthBadges returns short status badges for detected migration issues.
+func migrationHealthBadges(health *django.MigrationHealth) []string {
+	if health == nil {
+		return nil
+	}
+
+	badges := make([]string, 0, 3)
+	if apps := health.ConflictingApps(); len(apps) > 0 {
+		badges = append(badges, fmt.Sprintf("[conflicting leaves: %s]", strings.Join(apps, ",")))
+	}
+	if health.MissingMigrations {
+		badges = append(badges, "[missing migrations]")
+	}
+	if health.Unapplied > 0 {
+		badges = append(badges, fmt.Sprintf("[unapplied: %d]", health.Unapplied))
+	}
+	return badges
+}
+
+func (gui *Gui) migrationHealthLine() string {
+	if gui.migrationChecking && gui.migrationHealth == nil {
+		return "migration-check: running..."
+	}
+	if gui.migrationHealth == nil {
+		return ""
+	}
+
+	badges := migrationHealthBadges(gui.migrationHealth)
+	if len(badges) == 0 {
+		if gui.migrationHealth.CheckError != "" {
+			return "migration-check: error"
+		}
+		return "migration-check: ok"
+	}
+	return "migration-check: " + strings.Join(badges, " ")
+}
+
+// startMigrationHealthCheck runs conflict and drift detection in the background.
+func (gui *Gui) startMigrationHealthCheck() {
+	if gui.project == nil || gui.g == nil {
+		return
+	}
+	if gui.migrationChecking {
+		gui.migrationCheckPending = true
+		return
+	}
+	gui.migrationChecking = true
+	gui.migrationCheckPending = false
+
+	projectCopy := cloneProjectForDiscovery(gui.project)
+	go func(checkProject *django.Project) {
+		health := checkProject.CheckMigrationHealth()
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.migrationChecking = false
+			gui.migrationHealth = &health
+			if gui.migrationCheckPending {
+				gui.startMigrationHealthCheck()
+			}
+			if menuView, err := g.View(MenuWindow); err == nil {
+				gui.renderProjectList(menuView)
+			}
+			return nil
+		})
+	}(projectCopy)
+}
+
+// mergeMigrations runs makemigrations --merge for conflicting leaf migrations.
+func (gui *Gui) mergeMigrations(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentModel != "" {
+		return nil
+	}
+	return gui.mergeConflictingMigrations()
+}
+
+// mergeConflictingMigrations merges leaf migrations once the health check has found a conflict
+func (gui *Gui) mergeConflictingMigrations() error {
+	if gui.migrationHealth == nil && !gui.migrationChecking {
+		gui.startMigrationHealthCheck()
+	}
+	if reason := mergeBlockedReason(gui.migrationHealth); reason != "" {
+		return gui.showMessage("Merge Migrations", reason)
+	}
+	return gui.runManageCommand("Merge migrations", "makemigrations", "--merge", "--noinput")
+}
+
+// mergeBlockedReason explains why there is nothing to merge, or returns "" when leaves conflict
+func mergeBlockedReason(health *django.MigrationHealth) string {
+	switch {
+	case health == nil:
+		return "Migration check is still running; try again when the Project panel shows its migration-check line."
+	case len(health.ConflictingApps()) == 0:
+		return "No migration conflicts."
+	}
+	return ""
+}
+
+// projectSquashAppActions lists apps with enough migrations to squash.
+func (gui *Gui) projectSquashAppActions() []projectAction {
+	counts := make(map[string]int)
+	apps := make([]string, 0)
+	for _, m := range gui.project.Migrations {
+		if counts[m.App] == 0 {
+			apps = append(apps, m.App)
+		}
+		counts[m.App]++
+	}
+	sort.Strings(apps)
+
+	actions := make([]projectAction, 0, len(apps))
+	for _, app := range apps {
+		if counts[app] < 2 {
+			continue
+		}
+		actions = append(actions, projectAction{
+			label:    fmt.Sprintf("%s (%d migrations)", app, counts[app]),
+			internal: "squashapp",
+			value:    app,
+		})
+	}
+	return actions
+}
+
+func (gui *Gui) projectSquashStartActions(app string) []projectAction {
+	gui.squashApp = app
+	gui.squashStart = ""
+	names := gui.project.AppMigrationNames(app)
+	if len(names) < 2 {
+		return nil
+	}
+
+	actions := []projectAction{{label: "From first migration", internal: "squashstart"}}
+	for _, name := range names[1 : len(names)-1] {
+		actions = append(actions, projectAction{label: name, internal: "squashstart", value: name})
+	}
+	return actions
+}
+
+func (gui *Gui) projectSquashEndActions(start string) []projectAction {
+	gui.squashStart = start
+	names := gui.project.AppMigrationNames(gui.squashApp)
+	first := 1
+	if start != "" {
+		first = slices.Index(names, start) + 1
+	}
+	if first <= 0 || first >= len(names) {
+		return nil
+	}
+
+	actions := make([]projectAction, 0, len(names)-first)
+	for i := len(names) - 1; i >= first; i-- {
+		actions = append(actions, projectAction{label: names[i], internal: "squashend", value: names[i]})
+	}
+	return actions
+}
+
+// previewSquashPlan shows what squashmigrations will replace and offers to generate the file.
+func (gui *Gui) previewSquashPlan(end string) error {
+	plan, err := gui.project.BuildSquashPlan(gui.squashApp, gui.squashStart, end)
+	if err != nil {
+		return gui.showMessage("Squash Migrations", err.Error())
+	}
+	gui.squashPlan = &plan
+
+	command := "python manage.py " + strings.Join(plan.Args(), " ")
+	tabID := gui.startCommandOutputTab("Squash Preview")
+	gui.appendOutput(tabID, fmt.Sprintf("App: %s\n", plan.App))
+	gui.appendOutput(tabID, fmt.Sprintf("New migration: %s.%s\n", plan.App, plan.SquashedName))
+	gui.appendOutput(tabID, fmt.Sprintf("Command: %s\n\n", command))
+	gui.appendOutput(tabID, fmt.Sprintf("Replaces %d migrations:\n", len(plan.Replaces)))
+	for _, name := range plan.Replaces {
+		gui.appendOutput(tabID, fmt.Sprintf("  - %s\n", name))
+	}
+	gui.appendOutput(tabID, "\nThe generated migration is shown for review before you keep it. The originals stay on\ndisk until the squashed migration is applied everywhere; use Migrations > Prune squashed\nmigrations afterwards.\n")
+	gui.refreshOutputView()
+
+	return gui.openProjectActionsModal("Confirm Squash", []projectAction{
+		{label: fmt.Sprintf("Generate %s and review it (%d migrations)", plan.SquashedName, len(plan.Replaces)), internal: "squashrun"},
+	})
+}
+
+// runSquashPlan runs squashmigrations, then shows the generated file and asks whether to keep it
+func (gui *Gui) runSquashPlan() error {
+	if gui.squashPlan == nil {
+		return nil
+	}
+	plan := *gui.squashPlan
+	gui.squashPlan = nil
+	path, err := gui.project.SquashedMigrationPath(plan)
+	if err != nil {
+		return gui.showMessage("Squash Migrations", err.Error())
+	}
+	if _, err := os.Stat(path); err == nil {
+		return gui.showMessage("Squash Migrations", fmt.Sprintf("%s already exists.", gui.relativeProjectPath(path)))
+	}
+
+	command := "python manage.py " + strings.Join(plan.Args(), " ")
+	tabID := gui.startCommandOutputTab("Squash migrations")
+	gui.appendOutput(tabID, fmt.Sprintf("$ %s\n\n", command))
+	_ = gui.switchPanel(MainWindow)
+
+	startedAt := time.Now()
+	ctx, op := gui.beginContextOperation(command, tabID)
+	go func() {
+		runCtx, cancel := gui.project.OperationContext(ctx, config.TimeoutCommand)
+		output, runErr := gui.project.RunCommandContext(runCtx, plan.Args()...)
+		cancel()
+		var squashed django.SquashedMigration
+		var readErr error
+		if runErr == nil {
+			squashed, readErr = django.ReadSquashedMigration(path)
+		}
+		gui.g.Update(func(g *gocui.Gui) error {
+			runErr = gui.endOperation(op, runErr)
+			gui.appendOutput(tabID, output)
+			gui.recordCommandExecution(command, tabID, startedAt, runErr)
+			switch {
+			case runErr != nil:
+				gui.appendOutput(tabID, fmt.Sprintf("\nError: %v\n", runErr))
+			case readErr != nil:
+				gui.appendOutput(tabID, fmt.Sprintf("\nCould not read the generated migration: %v\n", readErr))
+			default:
+				gui.appendOutput(tabID, "\n"+formatSquashedMigration(squashed, gui.relativeProjectPath(path)))
+				gui.squashGenerated = path
+			}
+			gui.refreshOutputView()
+			if gui.squashGenerated == "" {
+				return nil
+			}
+			return gui.openProjectActionsModal("Keep Squashed Migration?", []projectAction{
+				{label: "Keep " + filepath.Base(path), internal: "squashkeep"},
+				{label: "Discard (delete " + gui.relativeProjectPath(path) + ")", internal: "squashdiscard"},
+			})
+		})
+	}()
+	return nil
+}
+
+// formatSquashedMigration summarizes the generated migration's header and lists its source
+func formatSquashedMigration(squashed django.SquashedMigration, path string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Generated %s\n", path)
+	fmt.Fprintf(&b, "Replaces %d migrations, %d operations\n", len(squashed.Replaces), squashed.Operations)
+	fmt.Fprintln(&b, "Dependencies:")
+	if len(squashed.Dependencies) == 0 {
+		fmt.Fprintln(&b, "  (none)")
+	}
+	for _, dep := range squashed.Dependencies {
+		fmt.Fprintf(&b, "  - %s.%s\n", dep.App, dep.Name)
+	}
+	fmt.Fprintf(&b, "\n----- %s -----\n%s", filepath.Base(path), squashed.Source)
+	if !strings.HasSuffix(squashed.Source, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// finishSquash keeps or deletes the generated migration and refreshes migration state
+func (gui *Gui) finishSquash(keep bool) error {
+	path := gui.squashGenerated
+	gui.squashGenerated = ""
+	if path == "" {
+		return nil
+	}
+	message := fmt.Sprintf("Kept %s.", gui.relativeProjectPath(path))
+	if !keep {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return gui.showMessage("Squash Migrations", fmt.Sprintf("Failed to delete %s: %v", gui.relativeProjectPath(path), err))
+		}
+		message = fmt.Sprintf("Discarded %s.", gui.relativeProjectPath(path))
+	}
+	gui.project.Migrations = nil
+	gui.project.DiscoverMigrations()
+	gui.startMigrationHealthCheck()
+	return gui.showMessage("Squash Migrations", message)
+}
+
+// projectPruneActions lists applied squashed migrations whose originals can be removed.
+func (gui *Gui) projectPruneActions() []projectAction {
+	gui.pruneCandidates = gui.project.FindPrunableSquashes()
+	actions := make([]projectAction, 0, len(gui.pruneCandidates))
+	for i, squash := range gui.pruneCandidates {
+		actions = append(actions, projectAction{
+			label:    fmt.Sprintf("%s.%s (%d originals, %d references)", squash.App, squash.Name, len(squash.ReplacedPaths), len(squash.DependentPaths)),
+			internal: "prunesquash",
+			value:    strconv.Itoa(i),
+		})
+	}
+	return actions
+}
+
+func (gui *Gui) relativeProjectPath(path string) string {
+	if rel, err := filepath.Rel(gui.project.RootDir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+func (gui *Gui) previewPruneSquash(value string) error {
+	idx, err := strconv.Atoi(value)
+	if err != nil || idx < 0 || idx >= len(gui.pruneCandidates) {
+		return nil
+	}
+	squash := gui.pruneCandidates[idx]
+	gui.pruneSelected = &squash
+
+	tabID := gui.startCommandOutputTab("Prune Preview")
+	gui.appendOutput(tabID, fmt.Sprintf("Squashed migration: %s.%s\n\n", squash.App, squash.Name))
+	gui.appendOutput(tabID, "Delete:\n")
+	for _, path := range squash.ReplacedPaths {
+		gui.appendOutput(tabID, fmt.Sprintf("  - %s\n", gui.relativeProjectPath(path)))
+	}
+	gui.appendOutput(tabID, "\nRepoint dependencies to the squashed migration:\n")
+	if len(squash.DependentPaths) == 0 {
+		gui.appendOutput(tabID, "  (none)\n")
+	}
+	for _, path := range squash.DependentPaths {
+		gui.appendOutput(tabID, fmt.Sprintf("  - %s\n", gui.relativeProjectPath(path)))
+	}
+	gui.appendOutput(tabID, fmt.Sprintf("\nRemove `replaces` from %s\n", gui.relativeProjectPath(squash.Path)))
+	gui.refreshOutputView()
+
+	return gui.openProjectActionsModal("Confirm Prune", []projectAction{
+		{label: fmt.Sprintf("Delete %d originals and update %d references", len(squash.ReplacedPaths), len(squash.DependentPaths)), internal: "pruneconfirm"},
+	})
+}
+
+func (gui *Gui) runPruneSquash() error {
+	if gui.pruneSelected == nil {
+		return nil
+	}
+	squash := *gui.pruneSelected
+	gui.pruneSelected = nil
+
+	tabID := gui.startCommandOutputTab("Prune Squashed Migration")
+	err := gui.project.PruneSquashedMigration(squash)
+	status := "success"
+	if err != nil {
+		status = "error"
+		gui.rememberError("migrations", err.Error())
+		gui.appendOutput(tabID, fmt.Sprintf("Prune failed: %v\n", err))
+	} else {
+		gui.appendOutput(tabID, fmt.Sprintf("Pruned %d migrations replaced by %s.%s\n", len(squash.ReplacedPaths), squash.App, squash.Name))
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:   "migration",
+		Source: "project",
+		Status: status,
+		Action: "prune",
+		App:    squash.App,
+		Error:  safeErrorMessage(err),
+	})
+	gui.refreshOutputView()
+	_ = gui.switchPanel(MainWindow)
+
+	gui.project.Migrations = nil
+	gui.project.DiscoverMigrations()
+	gui.startMigrationHealthCheck()
+	return nil
+}
+
+// showMigrationSQLReport aggregates sqlmigrate output for unapplied migrations into one tab.
+func (gui *Gui) showMigrationSQLReport() error {
+	tabID := gui.startCommandOutputTab("Migration SQL")
+	gui.appendOutput(tabID, "Running sqlmigrate for unapplied migrations...\n")
+	gui.refreshOutputView()
+	_ = gui.switchPanel(MainWindow)
+
+	projectCopy := cloneProjectForDiscovery(gui.project)
+	go func(reportProject *django.Project) {
+		report := reportProject.BuildMigrationSQLReport()
+		gui.g.Update(func(g *gocui.Gui) error {
+			gui.resetOutput(tabID, "Migration SQL")
+			gui.appendOutput(tabID, report.String())
+			if _, _, failed := report.Counts(); failed > 0 {
+				gui.rememberError("migrations", fmt.Sprintf("sqlmigrate failed for %d migrations", failed))
+			}
+			gui.refreshOutputView()
+			return nil
+		})
+	}(projectCopy)
+
+	return nil
+}
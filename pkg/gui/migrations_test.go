@@ -0,0 +1,112 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestMigrationHealthBadges(t *testing.T) {
+	health := &django.MigrationHealth{
+		ConflictingLeaves: map[string][]string{
+			"shop": {"0004_a", "0004_b"},
+			"blog": {"0002_a", "0002_b"},
+		},
+		MissingMigrations: true,
+		Unapplied:         3,
+	}
+
+	got := strings.Join(migrationHealthBadges(health), " ")
+	want := "[conflicting leaves: blog,shop] [missing migrations] [unapplied: 3]"
+	if got != want {
+		t.Fatalf("unexpected badges:\n got: %q\nwant: %q", got, want)
+	}
+	if badges := migrationHealthBadges(&django.MigrationHealth{}); len(badges) != 0 {
+		t.Fatalf("expected no badges for healthy project, got %v", badges)
+	}
+}
+
+func TestMigrationHealthLine(t *testing.T) {
+	gui := &Gui{project: &django.Project{RootDir: t.TempDir()}}
+	if line := gui.migrationHealthLine(); line != "" {
+		t.Fatalf("expected empty line before first check, got %q", line)
+	}
+
+	gui.migrationChecking = true
+	if line := gui.migrationHealthLine(); line != "migration-check: running..." {
+		t.Fatalf("unexpected running line: %q", line)
+	}
+
+	gui.migrationChecking = false
+	gui.migrationHealth = &django.MigrationHealth{}
+	if line := gui.migrationHealthLine(); line != "migration-check: ok" {
+		t.Fatalf("unexpected healthy line: %q", line)
+	}
+
+	gui.migrationHealth = &django.MigrationHealth{MissingMigrations: true}
+	if line := gui.migrationHealthLine(); line != "migration-check: [missing migrations]" {
+		t.Fatalf("unexpected drift line: %q", line)
+	}
+}
+
+func TestProjectMigrationActionsIncludeMerge(t *testing.T) {
+	gui := &Gui{project: &django.Project{RootDir: t.TempDir()}, makeTargetsLoaded: true}
+	found := false
+	for _, action := range gui.projectMigrationActions() {
+		if action.internal == "mergemigrations" {
+			found = true
+		}
+		if strings.Contains(action.command, "--merge") {
+			t.Fatalf("expected merge to go through the conflict check, got command %q", action.command)
+		}
+	}
+	if !found {
+		t.Fatal("expected merge action in migration actions")
+	}
+}
+
+func TestProjectSquashRangeActions(t *testing.T) {
+	gui := &Gui{project: &django.Project{
+		RootDir: t.TempDir(),
+		Migrations: []django.Migration{
+			{App: "blog", Name: "0001_initial", Applied: true},
+			{App: "blog", Name: "0002_post", Applied: true},
+			{App: "blog", Name: "0003_tag", Applied: true},
+			{App: "shop", Name: "0001_initial", Applied: true},
+		},
+	}}
+
+	apps := gui.projectSquashAppActions()
+	if len(apps) != 1 || apps[0].value != "blog" {
+		t.Fatalf("expected only blog to be squashable, got %#v", apps)
+	}
+
+	starts := gui.projectSquashStartActions("blog")
+	if len(starts) != 2 || starts[0].value != "" || starts[1].value != "0002_post" {
+		t.Fatalf("unexpected start actions: %#v", starts)
+	}
+
+	ends := gui.projectSquashEndActions("0002_post")
+	if len(ends) != 1 || ends[0].value != "0003_tag" {
+		t.Fatalf("unexpected end actions: %#v", ends)
+	}
+
+	ends = gui.projectSquashEndActions("")
+	if len(ends) != 2 || ends[0].value != "0003_tag" || ends[1].value != "0002_post" {
+		t.Fatalf("unexpected end actions from first migration: %#v", ends)
+	}
+}
+
+func TestMergeBlockedReason(t *testing.T) {
+	if reason := mergeBlockedReason(&django.MigrationHealth{Unapplied: 2}); reason != "No migration conflicts." {
+		t.Fatalf("expected no-conflict message, got %q", reason)
+	}
+	if reason := mergeBlockedReason(nil); !strings.Contains(reason, "still running") {
+		t.Fatalf("expected running message before the first check, got %q", reason)
+	}
+	health := &django.MigrationHealth{ConflictingLeaves: map[string][]string{"blog": {"0003_a", "0003_b"}}}
+	if reason := mergeBlockedReason(health); reason != "" {
+		t.Fatalf("expected merge to run for conflicting leaves, got %q", reason)
+	}
+}
@@ -1,6 +1,7 @@
 package gui
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -165,6 +166,9 @@ func (gui *Gui) renderModal(v *gocui.View) {
 
 	if gui.modalType == "delete" {
 		fmt.Fprintln(v, gui.modalMessage)
+		if gui.modalBusy != "" {
+			fmt.Fprintf(v, "%s...\n", gui.modalBusy)
+		}
 		return
 	}
 	if gui.modalType == "restore" {
@@ -360,6 +364,10 @@ func (gui *Gui) renderModal(v *gocui.View) {
 
 	fmt.Fprintln(v, "━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 	fmt.Fprintln(v, "  * = required field")
+	if gui.modalBusy != "" {
+		fmt.Fprintln(v)
+		fmt.Fprintf(v, "%s...\n", gui.modalBusy)
+	}
 	if gui.modalMessage != "" {
 		fmt.Fprintln(v)
 		fmt.Fprintf(v, "Error: %s\n", gui.modalMessage)
@@ -721,7 +729,7 @@ func (gui *Gui) setModalKeybindings() {
 		return gui.submitModal()
 	})
 
-	if gui.modalType == "add" || gui.modalType == "edit" {
+	if gui.modalType == "add" || gui.modalType == "edit" || gui.modalType == "request" || gui.modalType == "command" {
 		gui.g.SetKeybinding(ModalWindow, 'j', gocui.ModNone, func(g *gocui.Gui, v *gocui.View) error {
 			gui.modalFieldIdx = (gui.modalFieldIdx + 1) % len(gui.modalFields)
 			return nil
@@ -890,7 +898,7 @@ func (gui *Gui) editModalField() error {
 	if os.Getenv("DEBUG") == "1" {
 		log.Printf("editModalField: modalFieldIdx=%d, len(fields)=%d", gui.modalFieldIdx, len(gui.modalFields))
 	}
-	if gui.modalFieldIdx >= len(gui.modalFields) {
+	if gui.modalFieldIdx >= len(gui.modalFields) || gui.modalBusy != "" {
 		return nil
 	}
 	gui.modalMessage = ""
@@ -970,7 +978,6 @@ func (gui *Gui) showForeignKeyPicker(field map[string]interface{}, fieldName, cu
 		return nil
 	}
 
-	viewer := django.NewDataViewer(gui.project)
 	pageSize := 100
 	page := 1
 	totalCount := 0
@@ -997,8 +1004,8 @@ func (gui *Gui) showForeignKeyPicker(field map[string]interface{}, fieldName, cu
 		return options
 	}
 
-	queryPage := func(pageNumber int) ([]pickerOption, error) {
-		result, err := viewer.QueryModel(relatedApp, relatedModel, nil, pageNumber, pageSize)
+	queryPage := func(ctx context.Context, pageNumber int) ([]pickerOption, error) {
+		result, err := django.NewDataViewer(gui.project).WithContext(ctx).QueryModel(relatedApp, relatedModel, nil, pageNumber, pageSize)
 		if err != nil {
 			return nil, err
 		}
@@ -1009,30 +1016,11 @@ func (gui *Gui) showForeignKeyPicker(field map[string]interface{}, fieldName, cu
 		return buildOptions(result.Records), nil
 	}
 
-	options, err := queryPage(page)
-	if err != nil {
-		gui.modalMessage = fmt.Sprintf("Failed to load related records for %s: %v", fieldName, err)
-		return nil
-	}
-	page++
-
-	if currentValue != "" && !pickerOptionsContainValue(options, currentValue) {
-		// Preserve/edit existing FK values even if they are not in the loaded page.
-		if currentRecord, err := viewer.GetRecord(relatedApp, relatedModel, currentValue); err == nil {
-			seenValues[currentValue] = struct{}{}
-			display := gui.getRecordDisplayString(*currentRecord)
-			options = append([]pickerOption{{
-				Value: currentValue,
-				Label: fmt.Sprintf("[%s] %s (current)", currentValue, display),
-			}}, options...)
-		}
-	}
-
-	loadMore := func() ([]pickerOption, bool, error) {
+	loadMore := func(ctx context.Context) ([]pickerOption, bool, error) {
 		if !hasMore {
 			return nil, true, nil
 		}
-		more, err := queryPage(page)
+		more, err := queryPage(ctx, page)
 		if err != nil {
 			return nil, !hasMore, err
 		}
@@ -1040,19 +1028,52 @@ func (gui *Gui) showForeignKeyPicker(field map[string]interface{}, fieldName, cu
 		return more, !hasMore, nil
 	}
 
-	if !hasMore {
-		loadMore = nil
-	}
+	modalType, fieldIdx := gui.modalType, gui.modalFieldIdx
+	gui.modalBusy = fmt.Sprintf("Loading %s.%s records", relatedApp, relatedModel)
+	ctx, op := gui.beginContextOperation(fmt.Sprintf("load %s.%s choices", relatedApp, relatedModel), "")
+	go func() {
+		options, err := queryPage(ctx, page)
+		if err == nil {
+			page++
+			if currentValue != "" && !pickerOptionsContainValue(options, currentValue) {
+				// Preserve/edit existing FK values even if they are not in the loaded page.
+				if currentRecord, getErr := django.NewDataViewer(gui.project).WithContext(ctx).GetRecord(relatedApp, relatedModel, currentValue); getErr == nil {
+					seenValues[currentValue] = struct{}{}
+					display := gui.getRecordDisplayString(*currentRecord)
+					options = append([]pickerOption{{
+						Value: currentValue,
+						Label: fmt.Sprintf("[%s] %s (current)", currentValue, display),
+					}}, options...)
+				}
+			}
+		}
 
-	return gui.showValuePicker(
-		fieldName,
-		fmt.Sprintf(" Select %s (FK: %s.%s) ", fieldName, relatedApp, relatedModel),
-		options,
-		currentValue,
-		gui.fieldAllowsEmpty(field),
-		totalCount,
-		loadMore,
-	)
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			gui.modalBusy = ""
+			if !gui.isModalOpen || gui.modalType != modalType || gui.modalFieldIdx != fieldIdx {
+				return nil
+			}
+			if err != nil {
+				gui.modalMessage = fmt.Sprintf("Failed to load related records for %s: %v", fieldName, err)
+				return nil
+			}
+			more := loadMore
+			if !hasMore {
+				more = nil
+			}
+			return gui.showValuePicker(
+				fieldName,
+				fmt.Sprintf(" Select %s (FK: %s.%s) ", fieldName, relatedApp, relatedModel),
+				options,
+				currentValue,
+				gui.fieldAllowsEmpty(field),
+				totalCount,
+				more,
+			)
+		})
+	}()
+	return nil
 }
 
 func (gui *Gui) showChoicePicker(field map[string]interface{}, fieldName, currentValue string, options []pickerOption) error {
@@ -1073,7 +1094,7 @@ func (gui *Gui) showValuePicker(
 	currentValue string,
 	allowEmpty bool,
 	totalCount int,
-	loadMore func() ([]pickerOption, bool, error),
+	loadMore func(ctx context.Context) ([]pickerOption, bool, error),
 ) error {
 	allOptions := make([]pickerOption, 0, len(options)+1)
 	if allowEmpty {
@@ -1099,9 +1120,12 @@ func (gui *Gui) showValuePicker(
 		return err
 	}
 
+	gui.pickerSeq++
+	seq := gui.pickerSeq
 	searchMode := false
 	searchQuery := ""
 	statusMessage := ""
+	loading := false
 	hasMore := loadMore != nil
 	filtered := make([]pickerOption, 0, len(allOptions))
 	selected := 0 // selected index inside filtered list
@@ -1307,26 +1331,44 @@ func (gui *Gui) showValuePicker(
 			render()
 			return
 		}
-		prev := selectedValue()
-		moreOptions, done, err := loadMore()
-		if err != nil {
-			statusMessage = fmt.Sprintf("Load failed: %v", err)
-			render()
+		if loading {
 			return
 		}
-		if done {
-			hasMore = false
-		}
-		if len(moreOptions) > 0 {
-			allOptions = appendPickerOptionsUnique(allOptions, moreOptions)
-			rebuildFiltered(prev)
-			statusMessage = fmt.Sprintf("Loaded %d more record(s).", len(moreOptions))
-		} else if hasMore {
-			statusMessage = "No additional records loaded."
-		} else {
-			statusMessage = "No more records."
-		}
+		loading = true
+		statusMessage = "Loading more records..."
 		render()
+
+		ctx, op := gui.beginContextOperation("load more "+fieldName+" choices", "")
+		go func() {
+			moreOptions, done, err := loadMore(ctx)
+			gui.g.Update(func(g *gocui.Gui) error {
+				err = gui.endOperation(op, err)
+				loading = false
+				// Drop results for a picker that was closed or replaced meanwhile.
+				if _, viewErr := g.View(ModalInputWindow); viewErr != nil || gui.pickerSeq != seq {
+					return nil
+				}
+				if err != nil {
+					statusMessage = fmt.Sprintf("Load failed: %v", err)
+					render()
+					return nil
+				}
+				if done {
+					hasMore = false
+				}
+				if len(moreOptions) > 0 {
+					allOptions = appendPickerOptionsUnique(allOptions, moreOptions)
+					rebuildFiltered(selectedValue())
+					statusMessage = fmt.Sprintf("Loaded %d more record(s).", len(moreOptions))
+				} else if hasMore {
+					statusMessage = "No additional records loaded."
+				} else {
+					statusMessage = "No more records."
+				}
+				render()
+				return nil
+			})
+		}()
 	}
 
 	bindSearchRune := func(char rune) {
@@ -1604,6 +1646,7 @@ func (gui *Gui) closeModal() error {
 	gui.modalFieldIdx = 0
 	gui.modalValues = nil
 	gui.modalMessage = ""
+	gui.modalBusy = ""
 	gui.modalTitle = ""
 	gui.restoreSnapshots = nil
 	gui.restoreIndex = 0
@@ -1637,7 +1680,10 @@ func (gui *Gui) submitModal() error {
 	if os.Getenv("DEBUG") == "1" {
 		log.Printf("submitModal: modalType=%s, values=%+v", gui.modalType, gui.modalValues)
 	}
-	viewer := django.NewDataViewer(gui.project)
+	if gui.modalBusy != "" {
+		return nil
+	}
+	app, model := gui.currentApp, gui.currentModel
 
 	switch gui.modalType {
 	case "add":
@@ -1647,16 +1693,17 @@ func (gui *Gui) submitModal() error {
 		}
 		gui.modalMessage = ""
 
-		fields := gui.convertModalFields()
-		pk, err := viewer.CreateRecord(gui.currentApp, gui.currentModel, fields)
-		if err != nil {
-			gui.modalMessage = fmt.Sprintf("Create failed: %v", err)
-			return nil
-		}
-
-		gui.showMessage("Success", fmt.Sprintf("Created %s.%s #%v successfully!", gui.currentApp, gui.currentModel, pk))
-		gui.closeModal()
-		return gui.loadAndDisplayRecords()
+		refs, fields := gui.foreignKeyReferences(), gui.convertModalFields()
+		return gui.submitRecordChange("Saving", "Create failed", func(viewer *django.DataViewer) (string, error) {
+			if err := checkForeignKeyReferences(viewer, refs); err != nil {
+				return "", err
+			}
+			pk, err := viewer.CreateRecord(app, model, fields)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Created %s.%s #%v successfully!", app, model, pk), nil
+		})
 
 	case "edit":
 		if len(gui.currentRecords) == 0 {
@@ -1670,17 +1717,16 @@ func (gui *Gui) submitModal() error {
 		gui.modalMessage = ""
 
 		selectedRecord := gui.currentRecords[gui.selectedRecordIdx]
-		fields := gui.convertModalFields()
-
-		err := viewer.UpdateRecord(gui.currentApp, gui.currentModel, selectedRecord.PK, fields)
-		if err != nil {
-			gui.modalMessage = fmt.Sprintf("Update failed: %v", err)
-			return nil
-		}
-
-		gui.showMessage("Success", fmt.Sprintf("Updated %s.%s #%v successfully!", gui.currentApp, gui.currentModel, selectedRecord.PK))
-		gui.closeModal()
-		return gui.loadAndDisplayRecords()
+		refs, fields := gui.foreignKeyReferences(), gui.convertModalFields()
+		return gui.submitRecordChange("Saving", "Update failed", func(viewer *django.DataViewer) (string, error) {
+			if err := checkForeignKeyReferences(viewer, refs); err != nil {
+				return "", err
+			}
+			if err := viewer.UpdateRecord(app, model, selectedRecord.PK, fields); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Updated %s.%s #%v successfully!", app, model, selectedRecord.PK), nil
+		})
 
 	case "delete":
 		if len(gui.currentRecords) == 0 {
@@ -1689,21 +1735,12 @@ func (gui *Gui) submitModal() error {
 		}
 
 		selectedRecord := gui.currentRecords[gui.selectedRecordIdx]
-		err := viewer.DeleteRecord(gui.currentApp, gui.currentModel, selectedRecord.PK)
-		if err != nil {
-			gui.showMessage("Error", fmt.Sprintf("Failed to delete record: %v", err))
-			gui.closeModal()
-			return nil
-		}
-
-		gui.showMessage("Success", fmt.Sprintf("Deleted %s.%s #%v successfully!", gui.currentApp, gui.currentModel, selectedRecord.PK))
-		gui.closeModal()
-
-		if gui.selectedRecordIdx >= len(gui.currentRecords)-1 && gui.selectedRecordIdx > 0 {
-			gui.selectedRecordIdx--
-		}
-
-		return gui.loadAndDisplayRecords()
+		return gui.submitRecordChange("Deleting", "Failed to delete record", func(viewer *django.DataViewer) (string, error) {
+			if err := viewer.DeleteRecord(app, model, selectedRecord.PK); err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("Deleted %s.%s #%v successfully!", app, model, selectedRecord.PK), nil
+		})
 
 	case "restore":
 		if len(gui.restoreSnapshots) == 0 || gui.restoreIndex < 0 || gui.restoreIndex >= len(gui.restoreSnapshots) {
@@ -1718,10 +1755,12 @@ func (gui *Gui) submitModal() error {
 		gui.refreshOutputView()
 		_ = gui.switchPanel(MainWindow)
 
+		ctx, op := gui.beginContextOperation("restore snapshot "+snapshot.Name, tabID)
 		go func() {
-			sm := django.NewSnapshotManager(gui.project)
+			sm := django.NewSnapshotManager(gui.project).WithContext(ctx)
 			err := sm.RestoreSnapshot(snapshot.ID)
 			gui.g.Update(func(g *gocui.Gui) error {
+				err = gui.endOperation(op, err)
 				gui.resetOutput(tabID, "Restore Snapshot")
 				if err != nil {
 					gui.appendOutput(tabID, fmt.Sprintf("Restore failed: %v\n", err))
@@ -1741,6 +1780,12 @@ func (gui *Gui) submitModal() error {
 
 		return nil
 
+	case "request":
+		return gui.submitRequestModal()
+
+	case "command":
+		return gui.submitCommandModal()
+
 	case "containers":
 		return gui.runContainerSelectionAction()
 
@@ -1774,6 +1819,45 @@ func (gui *Gui) submitModal() error {
 	return nil
 }
 
+// submitRecordChange runs a create, update, or delete off the UI goroutine. change returns the
+// success message. On failure add/edit forms stay open with the error; delete closes and reports it.
+func (gui *Gui) submitRecordChange(busy, failure string, change func(viewer *django.DataViewer) (string, error)) error {
+	modalType, app, model := gui.modalType, gui.currentApp, gui.currentModel
+	gui.modalBusy = busy
+	ctx, op := gui.beginContextOperation(fmt.Sprintf("%s %s.%s", strings.ToLower(busy), app, model), "")
+	go func() {
+		message, err := change(django.NewDataViewer(gui.project).WithContext(ctx))
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			gui.modalBusy = ""
+			modalOpen := gui.isModalOpen && gui.modalType == modalType
+			if err != nil {
+				if modalOpen && modalType != "delete" {
+					gui.modalMessage = fmt.Sprintf("%s: %v", failure, err)
+					return nil
+				}
+				if modalOpen {
+					gui.closeModal()
+				}
+				return gui.showMessage("Error", fmt.Sprintf("%s: %v", failure, err))
+			}
+
+			gui.showMessage("Success", message)
+			if modalOpen {
+				gui.closeModal()
+			}
+			if gui.currentApp != app || gui.currentModel != model {
+				return nil
+			}
+			if modalType == "delete" && gui.selectedRecordIdx >= len(gui.currentRecords)-1 && gui.selectedRecordIdx > 0 {
+				gui.selectedRecordIdx--
+			}
+			return gui.loadAndDisplayRecords()
+		})
+	}()
+	return nil
+}
+
 // validateRequiredFields validates that all required fields have values
 func (gui *Gui) validateRequiredFields() error {
 	for _, field := range gui.modalFields {
@@ -1796,9 +1880,9 @@ func (gui *Gui) validateModalFieldValues() error {
 	return gui.validateConstrainedFields()
 }
 
+// validateConstrainedFields checks choices locally; foreign keys are only checked for a project
+// here and verified against the database by checkForeignKeyReferences
 func (gui *Gui) validateConstrainedFields() error {
-	var viewer *django.DataViewer
-
 	for _, field := range gui.modalFields {
 		name, ok := field["name"].(string)
 		if !ok || name == "" {
@@ -1820,6 +1904,32 @@ func (gui *Gui) validateConstrainedFields() error {
 			continue
 		}
 
+		if relatedModel, _ := gui.getRelatedModelFromField(field, name); strings.TrimSpace(relatedModel) != "" && gui.project == nil {
+			return fmt.Errorf("cannot validate foreign key field '%s': project context missing", name)
+		}
+	}
+
+	return nil
+}
+
+// foreignKeyReference is a filled-in foreign key field that must name an existing record
+type foreignKeyReference struct {
+	field string
+	app   string
+	model string
+	value string
+}
+
+// foreignKeyReferences collects the form's foreign key values for checkForeignKeyReferences
+func (gui *Gui) foreignKeyReferences() []foreignKeyReference {
+	var refs []foreignKeyReference
+	for _, field := range gui.modalFields {
+		name, _ := field["name"].(string)
+		fieldType, _ := field["type"].(string)
+		value := strings.TrimSpace(gui.modalValues[name])
+		if name == "" || fieldType != "ForeignKey" || value == "" {
+			continue
+		}
 		relatedModel, relatedApp := gui.getRelatedModelFromField(field, name)
 		if strings.TrimSpace(relatedModel) == "" {
 			continue
@@ -1827,18 +1937,18 @@ func (gui *Gui) validateConstrainedFields() error {
 		if strings.TrimSpace(relatedApp) == "" {
 			relatedApp = gui.currentApp
 		}
-		if gui.project == nil {
-			return fmt.Errorf("cannot validate foreign key field '%s': project context missing", name)
-		}
+		refs = append(refs, foreignKeyReference{field: name, app: relatedApp, model: relatedModel, value: value})
+	}
+	return refs
+}
 
-		if viewer == nil {
-			viewer = django.NewDataViewer(gui.project)
-		}
-		if _, err := viewer.GetRecord(relatedApp, relatedModel, value); err != nil {
-			return fmt.Errorf("field '%s' must reference an existing %s.%s record", name, relatedApp, relatedModel)
+// checkForeignKeyReferences looks up each referenced record; it runs off the UI goroutine
+func checkForeignKeyReferences(viewer *django.DataViewer, refs []foreignKeyReference) error {
+	for _, ref := range refs {
+		if _, err := viewer.GetRecord(ref.app, ref.model, ref.value); err != nil {
+			return fmt.Errorf("field '%s' must reference an existing %s.%s record", ref.field, ref.app, ref.model)
 		}
 	}
-
 	return nil
 }
 
@@ -1888,6 +1998,10 @@ func (gui *Gui) getFieldConstraints(field map[string]interface{}) string {
 		constraints = append(constraints, "unique")
 	}
 
+	if help, ok := field["help"].(string); ok && help != "" {
+		constraints = append(constraints, help)
+	}
+
 	if len(constraints) > 0 {
 		return strings.Join(constraints, " | ")
 	}
@@ -0,0 +1,109 @@
+package gui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// runningOperation is background work that can be stopped with X.
+// Operations are only touched from the gocui goroutine.
+type runningOperation struct {
+	id        int
+	label     string
+	tabID     string
+	startedAt time.Time
+	cancel    func()
+	release   func() // frees context resources once the work has returned
+	cancelled bool
+}
+
+// beginOperation registers cancellable work. cancel must stop the work (and its process group);
+// the caller ends the operation from g.Update once the work has returned.
+func (gui *Gui) beginOperation(label, tabID string, cancel func()) *runningOperation {
+	gui.nextOperationID++
+	op := &runningOperation{
+		id:        gui.nextOperationID,
+		label:     label,
+		tabID:     tabID,
+		startedAt: time.Now(),
+		cancel:    cancel,
+	}
+	gui.operations = append(gui.operations, op)
+	return op
+}
+
+// beginContextOperation registers work driven by a context, returning the context to pass down
+func (gui *Gui) beginContextOperation(label, tabID string) (context.Context, *runningOperation) {
+	ctx, cancel := context.WithCancel(context.Background())
+	op := gui.beginOperation(label, tabID, cancel)
+	op.release = cancel
+	return ctx, op
+}
+
+// endOperation unregisters op and converts errors caused by a user cancel into context.Canceled
+func (gui *Gui) endOperation(op *runningOperation, err error) error {
+	if op == nil {
+		return err
+	}
+	for i, current := range gui.operations {
+		if current.id == op.id {
+			gui.operations = append(gui.operations[:i], gui.operations[i+1:]...)
+			break
+		}
+	}
+	if op.cancelled && err != nil && !errors.Is(err, context.Canceled) {
+		err = fmt.Errorf("%w: %w", context.Canceled, err)
+	}
+	if op.release != nil {
+		op.release()
+	}
+	return err
+}
+
+// cancelRunningOperation stops the most recently started operation
+func (gui *Gui) cancelRunningOperation(g *gocui.Gui, v *gocui.View) error {
+	var op *runningOperation
+	for i := len(gui.operations) - 1; i >= 0; i-- {
+		if !gui.operations[i].cancelled {
+			op = gui.operations[i]
+			break
+		}
+	}
+	if op == nil {
+		return gui.showMessage("Cancel", "No running operation to cancel.")
+	}
+
+	op.cancelled = true
+	op.cancel()
+	if op.tabID != "" {
+		gui.appendOutput(op.tabID, "\nCancelling...\n")
+		gui.refreshOutputView()
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:       "cancel",
+		Source:     "operation",
+		Status:     "requested",
+		Action:     op.label,
+		OutputTab:  op.tabID,
+		DurationMS: time.Since(op.startedAt).Milliseconds(),
+	})
+	return nil
+}
+
+// operationStatus maps an operation result to the history status
+func operationStatus(err error) string {
+	switch {
+	case err == nil:
+		return "success"
+	case errors.Is(err, context.Canceled):
+		return "cancelled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,66 @@
+package gui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestCancelRunningOperationRecordsOutcome(t *testing.T) {
+	root := t.TempDir()
+	gui := &Gui{
+		project:      &django.Project{RootDir: root},
+		historyStore: newProjectHistoryStore(root),
+		outputTabs:   make(map[string]*outputTabState),
+		outputOrder:  make([]string, 0),
+		outputRoutes: make(map[string]string),
+	}
+	tabID := gui.startCommandOutputTab("Command")
+	startedAt := time.Now()
+
+	ctx, op := gui.beginContextOperation("python manage.py migrate", tabID)
+	if err := gui.cancelRunningOperation(nil, nil); err != nil {
+		t.Fatalf("cancelRunningOperation failed: %v", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("expected the operation context to be cancelled")
+	}
+
+	runErr := gui.endOperation(op, fmt.Errorf("signal: killed"))
+	if !errors.Is(runErr, context.Canceled) {
+		t.Fatalf("expected a cancelled error, got %v", runErr)
+	}
+	if len(gui.operations) != 0 {
+		t.Fatalf("expected the operation to be removed, got %d", len(gui.operations))
+	}
+	gui.recordCommandExecution("python manage.py migrate", tabID, startedAt, runErr)
+
+	events, err := gui.historyStore.tail(2)
+	if err != nil {
+		t.Fatalf("tail failed: %v", err)
+	}
+	if len(events) != 2 || events[0].Type != "cancel" || events[1].Status != "cancelled" {
+		t.Fatalf("expected cancel request and cancelled command events, got %+v", events)
+	}
+	if len(gui.recentErrors) != 0 {
+		t.Fatalf("did not expect a cancelled command to be remembered as an error, got %+v", gui.recentErrors)
+	}
+}
+
+func TestOperationStatus(t *testing.T) {
+	cases := map[string]error{
+		"success":   nil,
+		"error":     fmt.Errorf("exit status 1"),
+		"cancelled": fmt.Errorf("%w: signal: killed", context.Canceled),
+		"timeout":   fmt.Errorf("%w: signal: killed", context.DeadlineExceeded),
+	}
+	for want, err := range cases {
+		if got := operationStatus(err); got != want {
+			t.Errorf("operationStatus(%v) = %q, want %q", err, got, want)
+		}
+	}
+}
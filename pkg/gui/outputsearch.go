@@ -0,0 +1,424 @@
+package gui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+)
+
+// Match highlights: every match in the visible window, and the current match
+const (
+	ansiSearchMatch   = "\x1b[30;43m"
+	ansiSearchCurrent = "\x1b[30;46m"
+)
+
+var searchFlagPattern = regexp.MustCompile(`^-[rcif]+$`)
+
+// outputSearch is the active search of an output tab. Matches are rows before the filter view,
+// so Logs filters and collapsed tracebacks apply first.
+type outputSearch struct {
+	query         string
+	regex         bool
+	caseSensitive bool
+	filter        bool // show only matching rows and their context
+	context       int  // rows around each match in the filter view
+	pattern       *regexp.Regexp
+	current       int // row of the current match, or -1
+
+	// matches of the last count, reused while the tab's rows do not change
+	cacheTextLen int
+	cacheRows    int
+	cacheMatches []int
+}
+
+// parseOutputSearch reads grep-style flags before the query: -r regex, -c case-sensitive,
+// -i case-insensitive, -f filter view, -C N context rows. `--` ends the flags.
+func parseOutputSearch(input string) (*outputSearch, error) {
+	search := &outputSearch{current: -1}
+	rest := strings.TrimSpace(input)
+	for rest != "" {
+		token, tail, _ := strings.Cut(rest, " ")
+		tail = strings.TrimLeft(tail, " ")
+		switch {
+		case token == "--":
+			rest = tail
+		case searchFlagPattern.MatchString(token):
+			for _, flag := range token[1:] {
+				switch flag {
+				case 'r':
+					search.regex = true
+				case 'c':
+					search.caseSensitive = true
+				case 'i':
+					search.caseSensitive = false
+				case 'f':
+					search.filter = true
+				}
+			}
+			rest = tail
+			continue
+		case token == "-C" || strings.HasPrefix(token, "-C") && isDigits(token[2:]):
+			value := token[2:]
+			if value == "" {
+				value, tail, _ = strings.Cut(tail, " ")
+				tail = strings.TrimLeft(tail, " ")
+			}
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("-C needs a number of context lines")
+			}
+			search.context = n
+			search.filter = true
+			rest = tail
+			continue
+		}
+		break
+	}
+	search.query = rest
+	if search.query == "" {
+		return nil, nil
+	}
+
+	expr := search.query
+	if !search.regex {
+		expr = regexp.QuoteMeta(expr)
+	}
+	if !search.caseSensitive {
+		expr = "(?i)" + expr
+	}
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	search.pattern = pattern
+	return search, nil
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *outputSearch) matchesRow(row outputRow) bool {
+	return s.pattern.MatchString(stripANSI(row.text))
+}
+
+// matchingRows returns the indexes of rows with a match
+func (s *outputSearch) matchingRows(rows []outputRow) []int {
+	var matches []int
+	for i, row := range rows {
+		if s.matchesRow(row) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// label summarizes the search for the tab title, with the current match counter
+func (s *outputSearch) label(matches []int) string {
+	var flags []string
+	if s.regex {
+		flags = append(flags, "regex")
+	}
+	if s.caseSensitive {
+		flags = append(flags, "case")
+	}
+	if s.filter {
+		flag := "filter"
+		if s.context > 0 {
+			flag += fmt.Sprintf(" C%d", s.context)
+		}
+		flags = append(flags, flag)
+	}
+	position := "-"
+	for i, row := range matches {
+		if row == s.current {
+			position = strconv.Itoa(i + 1)
+			break
+		}
+	}
+	label := fmt.Sprintf("/%s %s/%d", s.query, position, len(matches))
+	if len(flags) > 0 {
+		label += " " + strings.Join(flags, " ")
+	}
+	return label
+}
+
+// filterSearchRows keeps matching rows and context rows around them, with a "--" row between
+// groups like grep. Separator rows cover no raw lines.
+func filterSearchRows(rows []outputRow, search *outputSearch) []outputRow {
+	keep := make([]bool, len(rows))
+	for i, row := range rows {
+		if !search.matchesRow(row) {
+			continue
+		}
+		for j := max(i-search.context, 0); j <= min(i+search.context, len(rows)-1); j++ {
+			keep[j] = true
+		}
+	}
+	filtered := make([]outputRow, 0, len(rows))
+	last := -1
+	for i, row := range rows {
+		if !keep[i] {
+			continue
+		}
+		if last >= 0 && i > last+1 {
+			end := filtered[len(filtered)-1].end
+			filtered = append(filtered, outputRow{text: "--", color: ansiCyan, start: end + 1, end: end, traceback: -1})
+		}
+		filtered = append(filtered, row)
+		last = i
+	}
+	return filtered
+}
+
+// highlightMatches marks each match in a row; colored rows lose their own escapes on matching
+// lines and get color restored after each match
+func highlightMatches(text string, pattern *regexp.Regexp, highlight, color string) string {
+	plain := stripANSI(text)
+	locs := pattern.FindAllStringIndex(plain, -1)
+	if len(locs) == 0 {
+		return text
+	}
+	var b strings.Builder
+	last := 0
+	for _, loc := range locs {
+		if loc[0] == loc[1] {
+			continue
+		}
+		b.WriteString(plain[last:loc[0]])
+		b.WriteString(highlight + plain[loc[0]:loc[1]] + ansiReset + color)
+		last = loc[1]
+	}
+	b.WriteString(plain[last:])
+	return b.String()
+}
+
+// searchRows applies the tab's filter view to its displayed rows
+func (tab *outputTabState) searchRows(rows []outputRow) []outputRow {
+	if tab.search == nil || !tab.search.filter {
+		return rows
+	}
+	return filterSearchRows(rows, tab.search)
+}
+
+// searchMatches returns the displayed rows that match the tab's search
+func (gui *Gui) searchMatches(tabID string) []int {
+	tab, ok := gui.outputTabs[tabID]
+	if !ok || tab.search == nil {
+		return nil
+	}
+	rows := gui.baseOutputRows(tabID)
+	search := tab.search
+	if search.cacheTextLen != len(tab.text) || search.cacheRows != len(rows) || search.cacheMatches == nil {
+		search.cacheMatches = search.matchingRows(rows)
+		if search.cacheMatches == nil {
+			search.cacheMatches = []int{}
+		}
+		search.cacheTextLen = len(tab.text)
+		search.cacheRows = len(rows)
+	}
+	return search.cacheMatches
+}
+
+// outputTabHasItems reports tabs whose lines map to tests, files, routes, commands or events;
+// hiding lines would break that mapping, so they only get highlighting
+func (gui *Gui) outputTabHasItems(tabID string) bool {
+	switch tabID {
+	case gui.tests.resultsTabID, gui.tests.coverageTabID, gui.routes.tabID, gui.commands.tabID, gui.history.tabID:
+		return true
+	}
+	return false
+}
+
+// searchCurrentOutput starts a search in the current output tab and jumps to the first match at
+// or below the top line, or the last one above it when tailing; an empty query clears the search
+func (gui *Gui) searchCurrentOutput(query string) error {
+	tabID := gui.resolveOutputTabID("", false)
+	if tabID == "" {
+		return gui.showMessage("Search", "No output tab is currently available.")
+	}
+	tab, ok := gui.outputTabs[tabID]
+	if !ok {
+		return gui.showMessage("Search", "Current output tab is not available.")
+	}
+
+	search, err := parseOutputSearch(query)
+	if err != nil {
+		return gui.showMessage("Search", err.Error())
+	}
+	gui.clearOutputSelectionFor(tabID)
+	if search == nil {
+		tab.search = nil
+		gui.refreshOutputView()
+		return nil
+	}
+	if search.filter && gui.outputTabHasItems(tabID) {
+		search.filter = false
+	}
+	start := gui.topOutputRow(tabID)
+	tab.search = search
+	matches := gui.searchMatches(tabID)
+	if len(matches) == 0 {
+		gui.refreshOutputView()
+		return gui.showMessage("Search", "No match in current output tab.")
+	}
+	search.current = matches[len(matches)-1]
+	for _, row := range matches {
+		if row >= start {
+			search.current = row
+			break
+		}
+	}
+	return gui.showSearchMatch(tabID)
+}
+
+// jumpOutputMatch moves to the next (step 1) or previous (step -1) match from the top line,
+// wrapping around
+func (gui *Gui) jumpOutputMatch(step int) error {
+	tabID := gui.resolveOutputTabID("", false)
+	tab, ok := gui.outputTabs[tabID]
+	if !ok || tab.search == nil {
+		return nil
+	}
+	matches := gui.searchMatches(tabID)
+	if len(matches) == 0 {
+		return gui.showMessage("Search", "No match in current output tab.")
+	}
+	from := gui.topOutputRow(tabID)
+	next := matches[0]
+	if step < 0 {
+		next = matches[len(matches)-1]
+		for i := len(matches) - 1; i >= 0; i-- {
+			if matches[i] < from {
+				next = matches[i]
+				break
+			}
+		}
+	} else {
+		for _, row := range matches {
+			if row > from {
+				next = row
+				break
+			}
+		}
+	}
+	tab.search.current = next
+	return gui.showSearchMatch(tabID)
+}
+
+// showSearchMatch scrolls the current match to the top of the view
+func (gui *Gui) showSearchMatch(tabID string) error {
+	tab := gui.outputTabs[tabID]
+	line := tab.search.current
+	if tab.search.filter {
+		line = gui.filteredLineForRow(tabID, line)
+	}
+	tab.autoscroll = false
+	tab.originX = 0
+	tab.originY = max(line, 0)
+	gui.markStateDirty()
+	gui.refreshOutputView()
+	return nil
+}
+
+// topOutputRow returns the unfiltered row at the top of the view
+func (gui *Gui) topOutputRow(tabID string) int {
+	line := gui.currentOutputLineIndex(tabID)
+	if tab := gui.outputTabs[tabID]; tab != nil && tab.search != nil && tab.search.filter {
+		return gui.rawRowForFilteredLine(tabID, line)
+	}
+	return line
+}
+
+// filteredLineForRow finds where an unfiltered row appears in the filter view
+func (gui *Gui) filteredLineForRow(tabID string, row int) int {
+	tab := gui.outputTabs[tabID]
+	rows := gui.baseOutputRows(tabID)
+	if row < 0 || row >= len(rows) {
+		return 0
+	}
+	target := rows[row].start
+	for i, filtered := range tab.searchRows(rows) {
+		if filtered.start == target && filtered.end >= filtered.start {
+			return i
+		}
+	}
+	return 0
+}
+
+// rawRowForFilteredLine maps a line of the filter view back to its unfiltered row
+func (gui *Gui) rawRowForFilteredLine(tabID string, line int) int {
+	tab := gui.outputTabs[tabID]
+	rows := gui.baseOutputRows(tabID)
+	filtered := tab.searchRows(rows)
+	if line < 0 || line >= len(filtered) {
+		return -1
+	}
+	for i, row := range rows {
+		if row.start >= filtered[line].start {
+			return i
+		}
+	}
+	return -1
+}
+
+func (gui *Gui) prevOutputMatch(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentWindow != MainWindow || gui.currentModel != "" {
+		return nil
+	}
+	return gui.jumpOutputMatch(-1)
+}
+
+// toggleSearchFilter switches the current tab between all rows and only the matching ones
+func (gui *Gui) toggleSearchFilter(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentWindow != MainWindow || gui.currentModel != "" {
+		return nil
+	}
+	tabID := gui.resolveOutputTabID("", false)
+	tab, ok := gui.outputTabs[tabID]
+	if !ok {
+		return nil
+	}
+	if tab.search == nil {
+		return gui.showMessage("Search", "Search with / first; & then shows only the matching lines.")
+	}
+	if gui.outputTabHasItems(tabID) {
+		return gui.showMessage("Search", "This tab has its own filter; press F.")
+	}
+	gui.clearOutputSelectionFor(tabID)
+	tab.search.filter = !tab.search.filter
+	if tab.search.current >= 0 {
+		return gui.showSearchMatch(tabID)
+	}
+	tab.originY = 0
+	gui.refreshOutputView()
+	return nil
+}
+
+func (gui *Gui) clearOutputSelectionFor(tabID string) {
+	if gui.outputSelectMode && gui.outputSelectTabID == tabID {
+		gui.outputSelectMode = false
+		gui.outputSelectTabID = ""
+	}
+}
+
+// visibleOutputRows returns the range of rows shown in the view, for highlighting
+func visibleOutputRows(tab *outputTabState, height, rowCount int, following bool) (int, int) {
+	first := tab.originY
+	if following || first == outputOriginTail || first < 0 {
+		first = rowCount - height
+	}
+	first = max(first, 0)
+	return first, min(first+height, rowCount)
+}
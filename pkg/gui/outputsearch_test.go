@@ -0,0 +1,134 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseOutputSearch(t *testing.T) {
+	tests := []struct {
+		input   string
+		query   string
+		regex   bool
+		cased   bool
+		filter  bool
+		context int
+	}{
+		{input: "ERROR", query: "ERROR"},
+		{input: "-r -c ERR(OR)?", query: "ERR(OR)?", regex: true, cased: true},
+		{input: "-rf timeout 5s", query: "timeout 5s", regex: true, filter: true},
+		{input: "-C 2 Traceback", query: "Traceback", filter: true, context: 2},
+		{input: "-C3 -i GET /api", query: "GET /api", filter: true, context: 3},
+		{input: "-- -f flag", query: "-f flag"},
+	}
+	for _, tc := range tests {
+		search, err := parseOutputSearch(tc.input)
+		if err != nil || search == nil {
+			t.Fatalf("parseOutputSearch(%q) = %v, %v", tc.input, search, err)
+		}
+		if search.query != tc.query || search.regex != tc.regex || search.caseSensitive != tc.cased || search.filter != tc.filter || search.context != tc.context {
+			t.Fatalf("parseOutputSearch(%q) = %+v", tc.input, search)
+		}
+	}
+
+	if search, err := parseOutputSearch("  -r "); search != nil || err != nil {
+		t.Fatalf("expected flags without a query to clear the search, got %v %v", search, err)
+	}
+	if _, err := parseOutputSearch("-r ("); err == nil || !strings.Contains(err.Error(), "invalid pattern") {
+		t.Fatalf("expected invalid pattern error, got %v", err)
+	}
+	search, _ := parseOutputSearch("a.b")
+	if search.pattern.MatchString("axb") || !search.pattern.MatchString("A.B") {
+		t.Fatalf("expected a literal, case-insensitive pattern")
+	}
+}
+
+func TestFilterSearchRows(t *testing.T) {
+	lines := []string{"one", "ERROR a", "two", "three", "four", "error b", "five"}
+	search, _ := parseOutputSearch("-C 1 error")
+	rows := filterSearchRows(rawOutputRows(lines), search)
+	var got []string
+	for _, row := range rows {
+		got = append(got, row.text)
+	}
+	if strings.Join(got, "|") != "one|ERROR a|two|--|four|error b|five" {
+		t.Fatalf("unexpected filtered rows: %v", got)
+	}
+	separator := rows[3]
+	if separator.start != 3 || separator.end != 2 || len(lines[separator.start:separator.end+1]) != 0 {
+		t.Fatalf("expected separator to cover no raw lines, got %+v", separator)
+	}
+}
+
+func TestHighlightMatches(t *testing.T) {
+	search, _ := parseOutputSearch("-r o+")
+	got := highlightMatches(ansiRed+"foo boo"+ansiReset, search.pattern, ansiSearchMatch, ansiRed)
+	want := "f" + ansiSearchMatch + "oo" + ansiReset + ansiRed + " b" + ansiSearchMatch + "oo" + ansiReset + ansiRed
+	if got != want {
+		t.Fatalf("unexpected highlight: %q", got)
+	}
+	if got := highlightMatches("bar", search.pattern, ansiSearchMatch, ""); got != "bar" {
+		t.Fatalf("expected non-matching text unchanged, got %q", got)
+	}
+}
+
+func TestOutputSearchNavigationAndFilter(t *testing.T) {
+	gui := &Gui{
+		currentWindow: MainWindow,
+		outputTabs:    make(map[string]*outputTabState),
+		outputOrder:   make([]string, 0),
+		outputRoutes:  make(map[string]string),
+	}
+	tabID := gui.startCommandOutputTab("Logs")
+	gui.appendOutput(tabID, "start\nGET /a 200\nidle\nGET /b 500\nidle\nGET /c 200\n")
+	tab := gui.outputTabs[tabID]
+	tab.originY = 2
+
+	if err := gui.searchCurrentOutput("get"); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if tab.search.current != 3 || tab.originY != 3 || tab.search.label(gui.searchMatches(tabID)) != "/get 2/3" {
+		t.Fatalf("expected first match below the top line, got current=%d origin=%d", tab.search.current, tab.originY)
+	}
+	_ = gui.jumpOutputMatch(1)
+	_ = gui.jumpOutputMatch(1)
+	if tab.search.current != 1 {
+		t.Fatalf("expected n to wrap to the first match, got %d", tab.search.current)
+	}
+	_ = gui.jumpOutputMatch(-1)
+	if tab.search.current != 5 {
+		t.Fatalf("expected N to wrap to the last match, got %d", tab.search.current)
+	}
+
+	if err := gui.toggleSearchFilter(nil, nil); err != nil {
+		t.Fatalf("toggle failed: %v", err)
+	}
+	if lines := gui.outputViewLines(tabID); strings.Join(lines, "|") != "GET /a 200|--|GET /b 500|--|GET /c 200" {
+		t.Fatalf("unexpected filter view: %v", lines)
+	}
+	if tab.originY != 4 {
+		t.Fatalf("expected the current match to stay in view, got origin %d", tab.originY)
+	}
+	_ = gui.jumpOutputMatch(-1)
+	if tab.search.current != 3 || tab.originY != 2 {
+		t.Fatalf("expected N in the filter view to move to /b, got current=%d origin=%d", tab.search.current, tab.originY)
+	}
+
+	if err := gui.searchCurrentOutput(""); err != nil || tab.search != nil || len(gui.outputViewLines(tabID)) != 7 {
+		t.Fatalf("expected an empty search to clear the filter view")
+	}
+}
+
+func TestVisibleOutputRows(t *testing.T) {
+	tab := &outputTabState{originY: 5}
+	if first, last := visibleOutputRows(tab, 10, 100, false); first != 5 || last != 15 {
+		t.Fatalf("unexpected window: %d-%d", first, last)
+	}
+	if first, last := visibleOutputRows(tab, 10, 100, true); first != 90 || last != 100 {
+		t.Fatalf("expected the tail when following, got %d-%d", first, last)
+	}
+	tab.originY = outputOriginTail
+	if first, last := visibleOutputRows(tab, 10, 4, false); first != 0 || last != 4 {
+		t.Fatalf("unexpected window for short output: %d-%d", first, last)
+	}
+}
@@ -0,0 +1,354 @@
+package gui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/config"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+// Process states shown in the processes list
+const (
+	processStopped  = "stopped"
+	processRunning  = "running"
+	processStopping = "stopping"
+	processExited   = "exited"
+	processCrashed  = "crashed"
+)
+
+// managedProcess is an auxiliary process (worker, asset watcher) from the Procfile or config
+type managedProcess struct {
+	name      string
+	command   string
+	cmd       *exec.Cmd
+	done      chan struct{} // closed once the process has been waited on
+	tabID     string
+	status    string
+	startedAt time.Time
+	exitedAt  time.Time
+	exitCode  int
+	stopping  bool
+	restart   bool
+}
+
+func (p *managedProcess) running() bool {
+	return p.cmd != nil
+}
+
+// summary is the processes list row, e.g. "worker  running 3m12s pid:4242"
+func (p *managedProcess) summary(now time.Time) string {
+	status := p.status
+	if status == "" {
+		status = processStopped
+	}
+	switch status {
+	case processRunning, processStopping:
+		line := fmt.Sprintf("%s %s", status, formatUptime(now.Sub(p.startedAt)))
+		if p.cmd != nil && p.cmd.Process != nil {
+			line += fmt.Sprintf(" pid:%d", p.cmd.Process.Pid)
+		}
+		return line
+	case processExited, processCrashed:
+		return fmt.Sprintf("%s (exit %d) after %s", status, p.exitCode, formatUptime(p.exitedAt.Sub(p.startedAt)))
+	}
+	return status
+}
+
+// formatUptime renders a duration at second precision, e.g. 1h2m3s
+func formatUptime(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return d.Round(time.Second).String()
+}
+
+// processByName returns the tracked process, creating it on first use
+func (gui *Gui) processByName(name string) *managedProcess {
+	if gui.processes == nil {
+		gui.processes = make(map[string]*managedProcess)
+	}
+	process, ok := gui.processes[name]
+	if !ok {
+		process = &managedProcess{name: name, status: processStopped}
+		gui.processes[name] = process
+	}
+	return process
+}
+
+// processDefinitions loads the Procfile and configured processes, reporting Procfile errors
+func (gui *Gui) processDefinitions() []config.ProcessConfig {
+	processes, err := gui.project.Processes()
+	if err != nil {
+		gui.rememberError("processes", err.Error())
+	}
+	return processes
+}
+
+// processStatusLine summarizes auxiliary processes for the Project panel
+func (gui *Gui) processStatusLine() string {
+	running, crashed := 0, 0
+	for _, process := range gui.processes {
+		switch {
+		case process.running():
+			running++
+		case process.status == processCrashed:
+			crashed++
+		}
+	}
+	line := fmt.Sprintf("processes: %d running", running)
+	if crashed > 0 {
+		line += fmt.Sprintf(", %d crashed", crashed)
+	}
+	return line
+}
+
+func (gui *Gui) projectProcessActions() []projectAction {
+	definitions := gui.processDefinitions()
+	if len(definitions) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	width := 0
+	for _, definition := range definitions {
+		width = max(width, len(definition.Name))
+	}
+	actions := make([]projectAction, 0, len(definitions)+3)
+	for _, definition := range definitions {
+		process := gui.processByName(definition.Name)
+		actions = append(actions, projectAction{
+			label:    fmt.Sprintf("%-*s  %s", width, definition.Name, process.summary(now)),
+			internal: "openprocess",
+			value:    definition.Name,
+		})
+	}
+	return append(actions,
+		projectAction{label: "Start all", internal: "startallprocesses"},
+		projectAction{label: "Restart all", internal: "restartallprocesses"},
+		projectAction{label: "Stop all", internal: "stopallprocesses"},
+	)
+}
+
+func (gui *Gui) openProcesses() error {
+	actions := gui.projectProcessActions()
+	if len(actions) == 0 {
+		return gui.showMessage("Processes", fmt.Sprintf("No processes defined. Add a Procfile.dev or Procfile, or a \"processes\" list to %s.", config.ProjectConfigPath(gui.project.RootDir)))
+	}
+	return gui.openProjectActionsModal("Processes", actions)
+}
+
+func (gui *Gui) projectProcessDetailActions(name string) []projectAction {
+	process := gui.processByName(name)
+	actions := make([]projectAction, 0, 4)
+	if process.running() {
+		actions = append(actions,
+			projectAction{label: "Stop", internal: "stopprocess", value: name},
+			projectAction{label: "Restart", internal: "restartprocess", value: name},
+		)
+	} else {
+		actions = append(actions, projectAction{label: "Start", internal: "startprocess", value: name})
+	}
+	if process.tabID != "" {
+		actions = append(actions, projectAction{label: "Show logs", internal: "showprocesslogs", value: name})
+	}
+	return actions
+}
+
+// startProcess launches a process into its own Logs tab
+func (gui *Gui) startProcess(name string) error {
+	definitions := gui.processDefinitions()
+	index := -1
+	for i, definition := range definitions {
+		if definition.Name == name {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return gui.showMessage("Processes", fmt.Sprintf("Unknown process %q.", name))
+	}
+	process := gui.processByName(name)
+	if process.running() {
+		return gui.showProcessLogs(name)
+	}
+
+	definition := definitions[index]
+	dir := gui.project.RootDir
+	if definition.Dir != "" {
+		dir = filepath.Join(dir, definition.Dir)
+	}
+	cmd := newTTYCompatShellCommand(definition.Command, dir)
+	cmd.Env = append(os.Environ(), django.ProcessEnv(definition, index)...)
+
+	process.command = definition.Command
+	process.stopping = false
+	process.restart = false
+	supervisor := &streamSupervisor{
+		started: func(cmd *exec.Cmd, tabID string) chan struct{} {
+			return gui.processStarted(process, cmd, tabID)
+		},
+	}
+	err := gui.runStreamingCommandToRoute(OutputTabLogs, "Process: "+name, cmd, supervisor, definition.Command, func(g *gocui.Gui, waitErr error) {
+		gui.handleProcessExit(process, waitErr)
+	})
+	if !process.running() {
+		process.status = processCrashed
+		process.exitCode = -1
+		process.startedAt = time.Now()
+		process.exitedAt = process.startedAt
+		gui.renderServerStatus()
+	}
+	return err
+}
+
+func (gui *Gui) processStarted(process *managedProcess, cmd *exec.Cmd, tabID string) chan struct{} {
+	process.cmd = cmd
+	process.tabID = tabID
+	process.status = processRunning
+	process.startedAt = time.Now()
+	process.done = make(chan struct{})
+	gui.appendHistoryEvent(historyEvent{
+		Type:      "process",
+		Source:    "project",
+		Status:    "started",
+		Action:    "start " + process.name,
+		Command:   process.command,
+		OutputTab: tabID,
+	})
+	gui.renderServerStatus()
+	return process.done
+}
+
+// stopProcess sends SIGTERM to the process group and kills it if it lingers
+func (gui *Gui) stopProcess(name string) error {
+	process := gui.processByName(name)
+	if !process.running() {
+		return nil
+	}
+	cmd := process.cmd
+	process.stopping = true
+	process.status = processStopping
+	if err := django.TerminateProcessGroup(cmd); err != nil {
+		gui.appendOutput(process.tabID, fmt.Sprintf("\nFailed to stop %s: %v\n", name, err))
+	} else {
+		gui.appendOutput(process.tabID, fmt.Sprintf("\nStopping %s...\n", name))
+	}
+	gui.renderServerStatus()
+	gui.refreshOutputView()
+
+	time.AfterFunc(serverStopGrace, func() {
+		gui.g.Update(func(g *gocui.Gui) error {
+			if process.cmd != cmd {
+				return nil
+			}
+			_ = django.KillProcessGroup(cmd)
+			gui.appendOutput(process.tabID, fmt.Sprintf("%s did not exit within %s; killed its process group.\n", name, serverStopGrace))
+			gui.refreshOutputView()
+			return nil
+		})
+	})
+	return nil
+}
+
+func (gui *Gui) restartProcess(name string) error {
+	process := gui.processByName(name)
+	if !process.running() {
+		return gui.startProcess(name)
+	}
+	_ = gui.stopProcess(name)
+	process.restart = true
+	return nil
+}
+
+func (gui *Gui) showProcessLogs(name string) error {
+	process := gui.processByName(name)
+	if process.tabID == "" {
+		return nil
+	}
+	gui.switchOutputTab(process.tabID)
+	return gui.switchPanel(MainWindow)
+}
+
+// startAllProcesses starts every process that is not already running
+func (gui *Gui) startAllProcesses() error {
+	for _, definition := range gui.processDefinitions() {
+		if gui.processByName(definition.Name).running() {
+			continue
+		}
+		if err := gui.startProcess(definition.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (gui *Gui) stopAllProcesses() error {
+	for name := range gui.processes {
+		_ = gui.stopProcess(name)
+	}
+	return nil
+}
+
+// restartAllProcesses restarts running processes and starts the rest
+func (gui *Gui) restartAllProcesses() error {
+	for _, definition := range gui.processDefinitions() {
+		if err := gui.restartProcess(definition.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleProcessExit records the exit code and restarts the process when requested
+func (gui *Gui) handleProcessExit(process *managedProcess, waitErr error) {
+	process.cmd = nil
+	process.done = nil
+	process.exitedAt = time.Now()
+	process.exitCode = exitCodeFromError(waitErr)
+
+	status := processExited
+	switch {
+	case process.stopping:
+		status = processStopped
+	case waitErr != nil:
+		status = processCrashed
+		gui.rememberError("process", fmt.Sprintf("%s exited with code %d", process.name, process.exitCode))
+	}
+	process.status = status
+	gui.appendHistoryEvent(historyEvent{
+		Type:       "process",
+		Source:     "project",
+		Status:     status,
+		Action:     "exit " + process.name,
+		ExitCode:   process.exitCode,
+		OutputTab:  process.tabID,
+		DurationMS: process.exitedAt.Sub(process.startedAt).Milliseconds(),
+		Error:      safeErrorMessage(waitErr),
+	})
+
+	if process.restart {
+		_ = gui.startProcess(process.name)
+		return
+	}
+	gui.renderServerStatus()
+}
+
+// stopAllOnQuit terminates every server (with its reloader children) and auxiliary process
+// together, so they do not outlive the UI and quitting waits one grace period at most
+func (gui *Gui) stopAllOnQuit() {
+	var groups []quitGroup
+	for _, server := range gui.runningServers() {
+		groups = append(groups, quitGroup{cmd: server.cmd, executor: server.executor, done: server.done})
+	}
+	for _, process := range gui.processes {
+		if process.running() {
+			groups = append(groups, quitGroup{cmd: process.cmd, done: process.done})
+		}
+	}
+	terminateOnQuit(groups, serverStopGrace)
+}
@@ -0,0 +1,45 @@
+package gui
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestManagedProcessSummary(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		process managedProcess
+		want    string
+	}{
+		{managedProcess{}, "stopped"},
+		{managedProcess{status: processRunning, startedAt: now.Add(-192 * time.Second)}, "running 3m12s"},
+		{managedProcess{status: processCrashed, exitCode: 2, startedAt: now.Add(-5 * time.Second), exitedAt: now}, "crashed (exit 2) after 5s"},
+	}
+	for _, tc := range cases {
+		if got := tc.process.summary(now); got != tc.want {
+			t.Errorf("summary() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestHandleProcessExitRecordsStatus(t *testing.T) {
+	gui := &Gui{}
+	worker := gui.processByName("worker")
+	worker.status = processRunning
+	worker.startedAt = time.Now()
+	gui.handleProcessExit(worker, errors.New("exit status 1"))
+	if worker.status != processCrashed || worker.running() {
+		t.Fatalf("expected a crashed worker, got %+v", worker)
+	}
+
+	assets := gui.processByName("assets")
+	assets.stopping = true
+	gui.handleProcessExit(assets, errors.New("signal: terminated"))
+	if assets.status != processStopped {
+		t.Fatalf("expected a stopped process after a requested stop, got %q", assets.status)
+	}
+	if line := gui.processStatusLine(); line != "processes: 0 running, 1 crashed" {
+		t.Fatalf("unexpected status line %q", line)
+	}
+}
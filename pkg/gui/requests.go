@@ -0,0 +1,543 @@
+package gui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const (
+	projectRequestsFileName = "requests.json"
+	httpRequestTimeout      = 30 * time.Second
+	maxHTTPResponseBytes    = 256 * 1024
+)
+
+var httpMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"}
+
+// httpRequestSpec is a request composed in the request form. Saved requests keep everything but
+// the token, session and credential headers, so credentials never land in .lazy-django/.
+type httpRequestSpec struct {
+	Name    string            `json:"name"`
+	Method  string            `json:"method"`
+	Path    string            `json:"path"` // May contain <converter:name> or (?P<name>...) placeholders
+	Params  map[string]string `json:"params,omitempty"`
+	Query   string            `json:"query,omitempty"`
+	Headers string            `json:"headers,omitempty"` // "Name: value; Name: value"
+	Body    string            `json:"body,omitempty"`
+	Token   string            `json:"-"`
+	Session string            `json:"-"`
+}
+
+// httpResponseSummary is what the Output tab shows for a response
+type httpResponseSummary struct {
+	Status    string
+	Code      int
+	Duration  time.Duration
+	Headers   http.Header
+	Body      []byte
+	Truncated bool
+}
+
+var routePlaceholderPattern = regexp.MustCompile(`<(?:\w+:)?(\w+)>|\(\?P<(\w+)>[^)]*\)`)
+
+// routeParams lists the placeholders of a route path in order
+func routeParams(path string) []string {
+	var params []string
+	for _, m := range routePlaceholderPattern.FindAllStringSubmatch(path, -1) {
+		name := m[1]
+		if name == "" {
+			name = m[2]
+		}
+		params = append(params, name)
+	}
+	return params
+}
+
+// fillRoutePath drops regex anchors and substitutes placeholder values. Anchors are removed
+// from the route text only, so values keep any ^ or $ they contain.
+func fillRoutePath(path string, params map[string]string) (string, error) {
+	var missing []string
+	var filled strings.Builder
+	last := 0
+	for _, loc := range routePlaceholderPattern.FindAllStringSubmatchIndex(path, -1) {
+		filled.WriteString(stripRouteAnchors(path[last:loc[0]]))
+		var name string
+		if loc[2] >= 0 {
+			name = path[loc[2]:loc[3]]
+		} else {
+			name = path[loc[4]:loc[5]]
+		}
+		value := strings.TrimSpace(params[name])
+		if value == "" {
+			missing = append(missing, name)
+		}
+		filled.WriteString(url.PathEscape(value))
+		last = loc[1]
+	}
+	filled.WriteString(stripRouteAnchors(path[last:]))
+	if len(missing) > 0 {
+		return "", fmt.Errorf("fill in %s", strings.Join(missing, ", "))
+	}
+	result := filled.String()
+	if !strings.HasPrefix(result, "/") {
+		result = "/" + result
+	}
+	return result, nil
+}
+
+func stripRouteAnchors(text string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(text, "^", ""), "$", "")
+}
+
+// credentialHeaders are dropped from the header list before a request is saved
+var credentialHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"X-Api-Key":           true,
+	"X-Auth-Token":        true,
+	"X-Csrftoken":         true,
+}
+
+// withoutCredentialHeaders removes credentialHeaders from a "Name: value; Name: value" list
+func withoutCredentialHeaders(text string) string {
+	var kept []string
+	for _, item := range strings.Split(text, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, _, _ := strings.Cut(item, ":")
+		if credentialHeaders[http.CanonicalHeaderKey(strings.TrimSpace(name))] {
+			continue
+		}
+		kept = append(kept, item)
+	}
+	return strings.Join(kept, "; ")
+}
+
+// parseHeaderList reads "Name: value; Name: value"
+func parseHeaderList(text string) (http.Header, error) {
+	headers := http.Header{}
+	for _, item := range strings.Split(text, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(item, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("header %q must look like Name: value", item)
+		}
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// buildHTTPRequest turns a spec into a request against baseURL
+func buildHTTPRequest(ctx context.Context, baseURL string, spec httpRequestSpec) (*http.Request, error) {
+	path, err := fillRoutePath(spec.Path, spec.Params)
+	if err != nil {
+		return nil, err
+	}
+	target := strings.TrimSuffix(baseURL, "/") + path
+	if query := strings.TrimPrefix(strings.TrimSpace(spec.Query), "?"); query != "" {
+		target += "?" + query
+	}
+	headers, err := parseHeaderList(spec.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader
+	if strings.TrimSpace(spec.Body) != "" {
+		if headers.Get("Content-Type") == "" {
+			if !json.Valid([]byte(spec.Body)) {
+				return nil, fmt.Errorf("body is not valid JSON; set a Content-Type header to send other data")
+			}
+			headers.Set("Content-Type", "application/json")
+		}
+		body = strings.NewReader(spec.Body)
+	}
+
+	method := strings.ToUpper(strings.TrimSpace(spec.Method))
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header = headers
+	if token := strings.TrimSpace(spec.Token); token != "" {
+		if !strings.Contains(token, " ") {
+			token = "Bearer " + token
+		}
+		req.Header.Set("Authorization", token)
+	}
+	if session := strings.TrimSpace(spec.Session); session != "" {
+		req.AddCookie(&http.Cookie{Name: "sessionid", Value: session})
+	}
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json, text/html;q=0.9, */*;q=0.8")
+	}
+	return req, nil
+}
+
+// sendHTTPRequest performs req without following redirects, so a login redirect shows as a 302
+func sendHTTPRequest(req *http.Request) (*httpResponseSummary, error) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	startedAt := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPResponseBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	summary := &httpResponseSummary{
+		Status:   resp.Status,
+		Code:     resp.StatusCode,
+		Duration: time.Since(startedAt),
+		Headers:  resp.Header,
+		Body:     body,
+	}
+	if len(body) > maxHTTPResponseBytes {
+		summary.Body = body[:maxHTTPResponseBytes]
+		summary.Truncated = true
+	}
+	return summary, nil
+}
+
+// formatHTTPResponse renders the status line, headers and a pretty-printed body
+func formatHTTPResponse(resp *httpResponseSummary) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s%s%s  %s  %s\n\n", statusColor(resp.Code), resp.Status, ansiReset,
+		formatTestDuration(resp.Duration), formatByteSize(len(resp.Body)))
+
+	names := make([]string, 0, len(resp.Headers))
+	for name := range resp.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range resp.Headers[name] {
+			fmt.Fprintf(&b, "%s: %s\n", name, value)
+		}
+	}
+	b.WriteString("\n")
+
+	body := resp.Body
+	var pretty bytes.Buffer
+	if json.Valid(body) && json.Indent(&pretty, body, "", "  ") == nil {
+		body = pretty.Bytes()
+	}
+	b.Write(body)
+	if len(body) > 0 && body[len(body)-1] != '\n' {
+		b.WriteString("\n")
+	}
+	if resp.Truncated {
+		fmt.Fprintf(&b, "\n[body truncated at %s]\n", formatByteSize(maxHTTPResponseBytes))
+	}
+	return b.String()
+}
+
+func formatByteSize(n int) string {
+	switch {
+	case n >= 1024*1024:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1024*1024))
+	case n >= 1024:
+		return fmt.Sprintf("%.1f KB", float64(n)/1024)
+	default:
+		return fmt.Sprintf("%d B", n)
+	}
+}
+
+func requestsFilePath(projectRoot string) string {
+	return filepath.Join(projectRoot, projectStateDirName, projectRequestsFileName)
+}
+
+// loadSavedRequests reads the project's saved requests; a missing file means none
+func loadSavedRequests(projectRoot string) ([]httpRequestSpec, error) {
+	data, err := os.ReadFile(requestsFilePath(projectRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var requests []httpRequestSpec
+	if err := json.Unmarshal(data, &requests); err != nil {
+		return nil, fmt.Errorf("invalid saved requests: %w", err)
+	}
+	return requests, nil
+}
+
+// savedRequestExists reports whether a request named name is saved
+func savedRequestExists(projectRoot, name string) bool {
+	requests, _ := loadSavedRequests(projectRoot)
+	for _, request := range requests {
+		if request.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// saveRequest adds spec to the saved requests, replacing one with the same name
+func saveRequest(projectRoot string, spec httpRequestSpec) error {
+	requests, err := loadSavedRequests(projectRoot)
+	if err != nil {
+		return err
+	}
+	spec.Headers = withoutCredentialHeaders(spec.Headers)
+	replaced := false
+	for i := range requests {
+		if requests[i].Name == spec.Name {
+			requests[i] = spec
+			replaced = true
+		}
+	}
+	if !replaced {
+		requests = append(requests, spec)
+	}
+	path := requestsFilePath(projectRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(requests, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// requestBaseURL is the address of the first running server, or the configured runserver address
+func (gui *Gui) requestBaseURL() string {
+	for _, server := range gui.runningServers() {
+		if server.url != "" {
+			return server.url
+		}
+	}
+	host, port := gui.project.ServerAddress()
+	return django.ServerURL(host, port)
+}
+
+// openRequests composes a request for the route under the cursor in the URL Routes tab, or lists
+// saved requests elsewhere
+func (gui *Gui) openRequests(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.inputMode != "" || gui.currentModel != "" {
+		return nil
+	}
+	if gui.routes.tabID != "" && gui.resolveOutputTabID("", false) == gui.routes.tabID {
+		line := gui.outputCursorLine(gui.routes.tabID)
+		if line >= 0 && line < len(gui.routes.lineRoutes) && gui.routes.lineRoutes[line] >= 0 {
+			route := gui.routes.routes[gui.routes.lineRoutes[line]]
+			method := http.MethodGet
+			if len(route.Methods) > 0 {
+				method = route.Methods[0]
+			}
+			// Leave "save as" empty so sending does not save; the route name is only a suggestion.
+			return gui.openRequestComposer(httpRequestSpec{Method: method, Path: route.Path}, route.Name)
+		}
+	}
+	return gui.openProjectActionsModal("HTTP Requests", gui.requestActions())
+}
+
+func (gui *Gui) requestActions() []projectAction {
+	actions := []projectAction{{label: "New request...", internal: "newrequest"}}
+	requests, err := loadSavedRequests(gui.project.RootDir)
+	if err != nil {
+		gui.rememberError("requests", err.Error())
+	}
+	for _, request := range requests {
+		actions = append(actions, projectAction{
+			label:    fmt.Sprintf("%s %s (%s)", request.Method, request.Path, request.Name),
+			internal: "openrequest",
+			value:    request.Name,
+		})
+	}
+	return actions
+}
+
+// openSavedRequest opens the composer prefilled with a saved request
+func (gui *Gui) openSavedRequest(name string) error {
+	requests, err := loadSavedRequests(gui.project.RootDir)
+	if err != nil {
+		return gui.showMessage("HTTP Requests", err.Error())
+	}
+	for _, request := range requests {
+		if request.Name == name {
+			return gui.openRequestComposer(request, "")
+		}
+	}
+	return gui.showMessage("HTTP Requests", fmt.Sprintf("No saved request named %q.", name))
+}
+
+// openRequestComposer opens the request form. Ctrl+S sends the request. nameHint is shown as the
+// placeholder of an empty "save as" field.
+func (gui *Gui) openRequestComposer(spec httpRequestSpec, nameHint string) error {
+	methodChoices := make([]interface{}, 0, len(httpMethods))
+	for _, method := range httpMethods {
+		methodChoices = append(methodChoices, map[string]interface{}{"value": method, "label": method})
+	}
+	saveAs := "name"
+	if nameHint != "" {
+		saveAs = "name, e.g. " + nameHint
+	}
+	text := func(name, kind string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "type": kind, "null": true, "blank": true}
+	}
+
+	fields := []map[string]interface{}{
+		{"name": "method", "type": "method", "null": false, "blank": false, "choices": methodChoices},
+		{"name": "path", "type": "path", "null": false, "blank": false},
+	}
+	values := map[string]string{"method": spec.Method, "path": spec.Path}
+	if values["method"] == "" {
+		values["method"] = http.MethodGet
+	}
+	if values["path"] == "" {
+		values["path"] = "/"
+	}
+	for _, param := range routeParams(spec.Path) {
+		fields = append(fields, map[string]interface{}{"name": "<" + param + ">", "type": "path param", "null": false, "blank": false})
+		values["<"+param+">"] = spec.Params[param]
+	}
+	fields = append(fields,
+		text("query", "a=1&b=2"),
+		text("headers", "Name: value; ..."),
+		text("body", "JSON"),
+		text("token", "Authorization"),
+		text("session", "sessionid cookie"),
+		text("save as", saveAs),
+	)
+	values["query"] = spec.Query
+	values["headers"] = spec.Headers
+	values["body"] = spec.Body
+	values["token"] = spec.Token
+	values["session"] = spec.Session
+	values["save as"] = spec.Name
+
+	gui.requestSavedName = spec.Name
+	gui.requestReplaceName = ""
+	gui.openFormModal("request", fields, values)
+	gui.modalTitle = fmt.Sprintf("Request -> %s (Ctrl+S sends)", gui.requestBaseURL())
+	return nil
+}
+
+// requestSpecFromModal reads the composer form
+func (gui *Gui) requestSpecFromModal() httpRequestSpec {
+	spec := httpRequestSpec{
+		Name:    strings.TrimSpace(gui.modalValues["save as"]),
+		Method:  strings.ToUpper(strings.TrimSpace(gui.modalValues["method"])),
+		Path:    strings.TrimSpace(gui.modalValues["path"]),
+		Query:   gui.modalValues["query"],
+		Headers: gui.modalValues["headers"],
+		Body:    gui.modalValues["body"],
+		Token:   gui.modalValues["token"],
+		Session: gui.modalValues["session"],
+	}
+	for _, param := range routeParams(spec.Path) {
+		if spec.Params == nil {
+			spec.Params = make(map[string]string)
+		}
+		spec.Params[param] = gui.modalValues["<"+param+">"]
+	}
+	return spec
+}
+
+// submitRequestModal validates the composer, saves the request when named, and sends it
+func (gui *Gui) submitRequestModal() error {
+	spec := gui.requestSpecFromModal()
+	baseURL := gui.requestBaseURL()
+	if _, err := buildHTTPRequest(context.Background(), baseURL, spec); err != nil {
+		gui.modalMessage = err.Error()
+		return nil
+	}
+	if spec.Name != "" {
+		if spec.Name != gui.requestSavedName && spec.Name != gui.requestReplaceName && savedRequestExists(gui.project.RootDir, spec.Name) {
+			gui.requestReplaceName = spec.Name
+			gui.modalMessage = fmt.Sprintf("A request named %q is already saved. Press Ctrl+S again to replace it.", spec.Name)
+			return nil
+		}
+		if err := saveRequest(gui.project.RootDir, spec); err != nil {
+			gui.modalMessage = fmt.Sprintf("Save failed: %v", err)
+			return nil
+		}
+	}
+	if err := gui.closeModal(); err != nil {
+		return err
+	}
+	return gui.sendRequest(baseURL, spec)
+}
+
+// sendRequest runs the request in the background and shows the response in a Command tab
+func (gui *Gui) sendRequest(baseURL string, spec httpRequestSpec) error {
+	path, _ := fillRoutePath(spec.Path, spec.Params)
+	label := fmt.Sprintf("%s %s", spec.Method, path)
+	tabID := gui.startCommandOutputTab("HTTP: " + label)
+	gui.appendOutput(tabID, fmt.Sprintf("> %s %s\n\n", spec.Method, strings.TrimSuffix(baseURL, "/")+path))
+	_ = gui.switchPanel(MainWindow)
+	gui.refreshOutputView()
+
+	ctx, op := gui.beginContextOperation(label, tabID)
+	startedAt := time.Now()
+	go func() {
+		runCtx, cancel := context.WithTimeout(ctx, httpRequestTimeout)
+		defer cancel()
+		var resp *httpResponseSummary
+		req, err := buildHTTPRequest(runCtx, baseURL, spec)
+		if err == nil {
+			resp, err = sendHTTPRequest(req)
+		}
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			status := "ok"
+			if err != nil {
+				status = "error"
+				gui.appendOutput(tabID, fmt.Sprintf("Request failed: %v\n", err))
+				if !gui.serverRunning() {
+					gui.appendOutput(tabID, "Hint: start the dev server first (Project -> Server...).\n")
+				}
+			} else {
+				if resp.Code >= 500 {
+					status = "error"
+				}
+				gui.appendOutput(tabID, formatHTTPResponse(resp))
+			}
+			gui.appendHistoryEvent(historyEvent{
+				Type:       "request",
+				Source:     "http",
+				Status:     status,
+				Action:     label,
+				OutputTab:  tabID,
+				DurationMS: time.Since(startedAt).Milliseconds(),
+				Error:      safeErrorMessage(err),
+			})
+			gui.refreshOutputView()
+			return nil
+		})
+	}()
+	return nil
+}
+
+func (gui *Gui) serverRunning() bool {
+	return len(gui.runningServers()) > 0
+}
@@ -0,0 +1,228 @@
+package gui
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestFillRoutePath(t *testing.T) {
+	path, err := fillRoutePath("/blog/<int:year>/<slug>/", map[string]string{"year": "2024", "slug": "hello world"})
+	if err != nil {
+		t.Fatalf("fillRoutePath returned error: %v", err)
+	}
+	if path != "/blog/2024/hello%20world/" {
+		t.Fatalf("unexpected path: %q", path)
+	}
+
+	path, err = fillRoutePath("/^articles/(?P<pk>[0-9]+)/$", map[string]string{"pk": "7"})
+	if err != nil {
+		t.Fatalf("fillRoutePath returned error: %v", err)
+	}
+	if path != "/articles/7/" {
+		t.Fatalf("unexpected regex path: %q", path)
+	}
+
+	path, err = fillRoutePath("^tags/(?P<tag>[^/]+)/$", map[string]string{"tag": "$ale"})
+	if err != nil {
+		t.Fatalf("fillRoutePath returned error: %v", err)
+	}
+	if path != "/tags/$ale/" {
+		t.Fatalf("expected anchors stripped from the route only, got %q", path)
+	}
+
+	if _, err := fillRoutePath("/blog/<int:year>/<slug>/", map[string]string{"year": "2024"}); err == nil || !strings.Contains(err.Error(), "slug") {
+		t.Fatalf("expected missing slug error, got %v", err)
+	}
+}
+
+func TestRouteParams(t *testing.T) {
+	params := routeParams("/users/<uuid:id>/posts/(?P<post>[^/]+)/")
+	if len(params) != 2 || params[0] != "id" || params[1] != "post" {
+		t.Fatalf("unexpected params: %v", params)
+	}
+}
+
+func TestParseHeaderList(t *testing.T) {
+	headers, err := parseHeaderList("X-Tenant: acme; Accept: text/plain;")
+	if err != nil {
+		t.Fatalf("parseHeaderList returned error: %v", err)
+	}
+	if headers.Get("X-Tenant") != "acme" || headers.Get("Accept") != "text/plain" {
+		t.Fatalf("unexpected headers: %v", headers)
+	}
+	if _, err := parseHeaderList("broken"); err == nil {
+		t.Fatalf("expected error for header without a colon")
+	}
+}
+
+func TestBuildHTTPRequestAuthAndBody(t *testing.T) {
+	req, err := buildHTTPRequest(context.Background(), "http://127.0.0.1:8000/", httpRequestSpec{
+		Method:  "post",
+		Path:    "/api/items/<int:pk>/",
+		Params:  map[string]string{"pk": "3"},
+		Query:   "?expand=1",
+		Body:    `{"name": "x"}`,
+		Token:   "abc123",
+		Session: "s3ss",
+	})
+	if err != nil {
+		t.Fatalf("buildHTTPRequest returned error: %v", err)
+	}
+	if req.Method != http.MethodPost || req.URL.String() != "http://127.0.0.1:8000/api/items/3/?expand=1" {
+		t.Fatalf("unexpected request: %s %s", req.Method, req.URL)
+	}
+	if req.Header.Get("Authorization") != "Bearer abc123" {
+		t.Fatalf("unexpected authorization header: %q", req.Header.Get("Authorization"))
+	}
+	if req.Header.Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected content type: %q", req.Header.Get("Content-Type"))
+	}
+	if cookie, err := req.Cookie("sessionid"); err != nil || cookie.Value != "s3ss" {
+		t.Fatalf("expected sessionid cookie, got %v (%v)", cookie, err)
+	}
+
+	req, err = buildHTTPRequest(context.Background(), "http://127.0.0.1:8000", httpRequestSpec{Path: "/", Token: "Token abc"})
+	if err != nil {
+		t.Fatalf("buildHTTPRequest returned error: %v", err)
+	}
+	if req.Header.Get("Authorization") != "Token abc" {
+		t.Fatalf("expected explicit scheme to be kept, got %q", req.Header.Get("Authorization"))
+	}
+
+	if _, err := buildHTTPRequest(context.Background(), "http://127.0.0.1:8000", httpRequestSpec{Path: "/", Body: "{nope"}); err == nil {
+		t.Fatalf("expected invalid JSON body error")
+	}
+}
+
+func TestSendHTTPRequestFormatsResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"method":"` + r.Method + `","received":` + string(body) + `}`))
+	}))
+	defer server.Close()
+
+	req, err := buildHTTPRequest(context.Background(), server.URL, httpRequestSpec{Method: "PUT", Path: "/", Body: `{"a":1}`})
+	if err != nil {
+		t.Fatalf("buildHTTPRequest returned error: %v", err)
+	}
+	resp, err := sendHTTPRequest(req)
+	if err != nil {
+		t.Fatalf("sendHTTPRequest returned error: %v", err)
+	}
+	if resp.Code != http.StatusCreated {
+		t.Fatalf("unexpected status: %d", resp.Code)
+	}
+	text := formatHTTPResponse(resp)
+	for _, want := range []string{"201 Created", "Content-Type: application/json", "\"method\": \"PUT\"", "\"a\": 1"} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected %q in formatted response:\n%s", want, text)
+		}
+	}
+}
+
+func TestSendHTTPRequestDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/accounts/login/", http.StatusFound)
+	}))
+	defer server.Close()
+
+	req, err := buildHTTPRequest(context.Background(), server.URL, httpRequestSpec{Path: "/private/"})
+	if err != nil {
+		t.Fatalf("buildHTTPRequest returned error: %v", err)
+	}
+	resp, err := sendHTTPRequest(req)
+	if err != nil {
+		t.Fatalf("sendHTTPRequest returned error: %v", err)
+	}
+	if resp.Code != http.StatusFound || resp.Headers.Get("Location") != "/accounts/login/" {
+		t.Fatalf("expected unfollowed redirect, got %d %v", resp.Code, resp.Headers)
+	}
+}
+
+func TestFormatHTTPResponseTruncated(t *testing.T) {
+	text := formatHTTPResponse(&httpResponseSummary{Status: "200 OK", Code: 200, Duration: 12 * time.Millisecond, Body: []byte("plain"), Truncated: true})
+	if !strings.Contains(text, "plain\n") || !strings.Contains(text, "[body truncated at 256.0 KB]") {
+		t.Fatalf("unexpected formatted response:\n%s", text)
+	}
+}
+
+func TestSaveRequestKeepsSecretsOutOfDisk(t *testing.T) {
+	root := t.TempDir()
+	spec := httpRequestSpec{
+		Name:    "create item",
+		Method:  "POST",
+		Path:    "/api/items/",
+		Headers: "X-Tenant: acme; authorization: Basic c2VjcmV0; Cookie: csrftoken=cookie; X-API-Key: secret",
+		Body:    `{}`,
+		Token:   "secret",
+		Session: "cookie",
+	}
+	if err := saveRequest(root, spec); err != nil {
+		t.Fatalf("saveRequest returned error: %v", err)
+	}
+	spec.Path = "/api/v2/items/"
+	if err := saveRequest(root, spec); err != nil {
+		t.Fatalf("saveRequest returned error: %v", err)
+	}
+
+	data, err := os.ReadFile(requestsFilePath(root))
+	if err != nil {
+		t.Fatalf("failed to read saved requests: %v", err)
+	}
+	if strings.Contains(string(data), "secret") || strings.Contains(string(data), "cookie") {
+		t.Fatalf("credentials were written to disk:\n%s", data)
+	}
+
+	requests, err := loadSavedRequests(root)
+	if err != nil {
+		t.Fatalf("loadSavedRequests returned error: %v", err)
+	}
+	if len(requests) != 1 || requests[0].Path != "/api/v2/items/" {
+		t.Fatalf("expected the request to be replaced by name, got %+v", requests)
+	}
+	if requests[0].Headers != "X-Tenant: acme" {
+		t.Fatalf("expected only non-credential headers to be saved, got %q", requests[0].Headers)
+	}
+}
+
+func TestRouteRequestIsNotSavedUnlessNamed(t *testing.T) {
+	root := t.TempDir()
+	if err := saveRequest(root, httpRequestSpec{Name: "blog-detail", Method: "GET", Path: "/blog/1/"}); err != nil {
+		t.Fatalf("saveRequest returned error: %v", err)
+	}
+	gui := &Gui{project: &django.Project{RootDir: root}}
+	if err := gui.openRequestComposer(httpRequestSpec{Method: "GET", Path: "/blog/<int:pk>/"}, "blog-detail"); err != nil {
+		t.Fatalf("openRequestComposer returned error: %v", err)
+	}
+	if gui.modalValues["save as"] != "" {
+		t.Fatalf("expected an empty save-as field, got %q", gui.modalValues["save as"])
+	}
+	for _, field := range gui.modalFields {
+		if field["name"] == "save as" && field["type"] != "name, e.g. blog-detail" {
+			t.Fatalf("expected the route name as placeholder, got %v", field["type"])
+		}
+	}
+
+	gui.modalValues["<pk>"] = "2"
+	gui.modalValues["save as"] = "blog-detail"
+	if err := gui.submitRequestModal(); err != nil {
+		t.Fatalf("submitRequestModal returned error: %v", err)
+	}
+	if !strings.Contains(gui.modalMessage, "Ctrl+S again to replace") {
+		t.Fatalf("expected a confirmation before replacing, got %q", gui.modalMessage)
+	}
+	requests, err := loadSavedRequests(root)
+	if err != nil || len(requests) != 1 || requests[0].Path != "/blog/1/" {
+		t.Fatalf("expected the saved request to be untouched, got %+v (%v)", requests, err)
+	}
+}
@@ -0,0 +1,190 @@
+package gui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const routesTitle = "URL Routes"
+
+// routesView is the URL Routes tab: the loaded routes, the active filter and which route each line shows
+type routesView struct {
+	tabID      string
+	routes     []django.URLRoute
+	filter     string
+	lineRoutes []int // Index into routes for each line, -1 for other lines
+}
+
+// showURLRoutes loads the URLconf in the background into the URL Routes tab
+func (gui *Gui) showURLRoutes() error {
+	tabID := gui.routes.tabID
+	if _, ok := gui.outputTabs[tabID]; ok {
+		gui.resetOutput(tabID, routesTitle)
+		gui.switchOutputTab(tabID)
+	} else {
+		tabID = gui.startCommandOutputTab(routesTitle)
+	}
+	gui.routes.tabID = tabID
+	gui.routes.lineRoutes = nil
+	gui.appendOutput(tabID, "Loading URL routes...\n")
+	_ = gui.switchPanel(MainWindow)
+	gui.refreshOutputView()
+
+	project := gui.project
+	go func() {
+		routes, err := project.GetURLRoutes()
+		gui.g.Update(func(g *gocui.Gui) error {
+			if err != nil {
+				gui.resetOutput(tabID, routesTitle)
+				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n", err))
+				gui.rememberError("urls", err.Error())
+				gui.refreshOutputView()
+				return nil
+			}
+			gui.routes.routes = routes
+			gui.renderRoutes()
+			return nil
+		})
+	}()
+	return nil
+}
+
+// renderRoutes redraws the URL Routes tab with the current filter
+func (gui *Gui) renderRoutes() {
+	tabID := gui.routes.tabID
+	if _, ok := gui.outputTabs[tabID]; !ok {
+		return
+	}
+	text, lineRoutes := formatRoutes(gui.routes.routes, gui.routes.filter)
+	gui.resetOutput(tabID, routesTitle)
+	gui.outputTabs[tabID].originY = 0
+	gui.routes.lineRoutes = lineRoutes
+	gui.appendOutput(tabID, text)
+	gui.refreshOutputView()
+}
+
+// openRouteFilterInput asks for a routes filter
+func (gui *Gui) openRouteFilterInput() error {
+	gui.inputMode = "route-filter"
+	gui.inputReturnWindow = MainWindow
+	maxX, maxY := gui.g.Size()
+	return gui.layoutInputPrompt(gui.g, maxX, maxY)
+}
+
+func (gui *Gui) setRouteFilter(filter string) error {
+	gui.routes.filter = strings.TrimSpace(filter)
+	if gui.outputSelectMode && gui.outputSelectTabID == gui.routes.tabID {
+		gui.outputSelectMode = false
+		gui.outputSelectTabID = ""
+	}
+	gui.renderRoutes()
+	return nil
+}
+
+// openRouteView opens the view of the route under the cursor in $VISUAL/$EDITOR
+func (gui *Gui) openRouteView() error {
+	line := gui.outputCursorLine(gui.routes.tabID)
+	if line < 0 || line >= len(gui.routes.lineRoutes) || gui.routes.lineRoutes[line] < 0 {
+		return nil
+	}
+	route := gui.routes.routes[gui.routes.lineRoutes[line]]
+	if route.File == "" {
+		return gui.showMessage("URL Routes", fmt.Sprintf("No source file for %s.", route.View))
+	}
+	path, ok := gui.project.HostPath(route.File)
+	if !ok {
+		return gui.showMessage("URL Routes", fmt.Sprintf("%s is not available on this machine.", route.File))
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:   "editor",
+		Source: "urls",
+		Status: "ok",
+		Action: fmt.Sprintf("%s:%d", path, route.Line),
+	})
+	return gui.openFileInEditor("URL Routes", path, route.Line)
+}
+
+// routeMatches reports whether a route passes the filter. "!" keeps routes with conflicts.
+func routeMatches(route django.URLRoute, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	if filter == "!" {
+		return len(route.Issues) > 0
+	}
+	filter = strings.ToLower(filter)
+	for _, field := range append([]string{route.Path, route.Name, route.View}, route.Decorators...) {
+		if strings.Contains(strings.ToLower(field), filter) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatRoutes renders routes as a table with conflicts under the affected route. lineRoutes maps
+// each output line to its route index.
+func formatRoutes(routes []django.URLRoute, filter string) (string, []int) {
+	conflicts := 0
+	for _, route := range routes {
+		if len(route.Issues) > 0 {
+			conflicts++
+		}
+	}
+
+	var b strings.Builder
+	var lineRoutes []int
+	fmt.Fprintf(&b, "%d routes, %d with conflicts", len(routes), conflicts)
+	if filter != "" {
+		fmt.Fprintf(&b, " | filter: %s", filter)
+	}
+	fmt.Fprintln(&b)
+	fmt.Fprintln(&b, "Enter opens the view source. F filters by path, name or view (! for conflicts).")
+	fmt.Fprintln(&b)
+	lineRoutes = append(lineRoutes, -1, -1, -1)
+
+	var shown []int
+	for i, route := range routes {
+		if routeMatches(route, filter) {
+			shown = append(shown, i)
+		}
+	}
+	// Columns are padded by hand so that conflict lines under a route do not split the table the
+	// way they would with tabwriter.
+	header := []string{"PATH", "NAME", "VIEW", "METHODS", "DECORATORS"}
+	rows := [][]string{header}
+	for _, i := range shown {
+		route := routes[i]
+		rows = append(rows, []string{route.Path, dashIfEmpty(route.Name), route.View,
+			dashIfEmpty(strings.Join(route.Methods, ",")), dashIfEmpty(strings.Join(route.Decorators, ", "))})
+	}
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for c, cell := range row {
+			widths[c] = max(widths[c], len(cell))
+		}
+	}
+	writeRow := func(row []string) {
+		for c, cell := range row[:len(row)-1] {
+			fmt.Fprintf(&b, "%-*s  ", widths[c], cell)
+		}
+		fmt.Fprintln(&b, row[len(row)-1])
+	}
+	writeRow(header)
+	lineRoutes = append(lineRoutes, -1)
+	for n, i := range shown {
+		writeRow(rows[n+1])
+		lineRoutes = append(lineRoutes, i)
+		for _, issue := range routes[i].Issues {
+			fmt.Fprintf(&b, "  ! %s\n", issue)
+			lineRoutes = append(lineRoutes, i)
+		}
+	}
+	if len(shown) == 0 {
+		fmt.Fprintln(&b, "No routes match the filter.")
+		lineRoutes = append(lineRoutes, -1)
+	}
+	return b.String(), lineRoutes
+}
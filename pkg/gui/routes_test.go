@@ -0,0 +1,38 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestFormatRoutes(t *testing.T) {
+	routes := []django.URLRoute{
+		{Path: "/posts/<slug:slug>/", Name: "blog:detail", View: "blog.views.PostDetail", Methods: []string{"GET"}},
+		{Path: "/posts/new/", Name: "blog:create", View: "blog.views.create", Decorators: []string{"login_required"}, Issues: []string{"shadowed by /posts/<slug:slug>/"}},
+	}
+	text, lineRoutes := formatRoutes(routes, "")
+	lines := outputLines(strings.TrimSuffix(text, "\n"))
+	if len(lines) != len(lineRoutes) {
+		t.Fatalf("expected a route per line, got %d lines and %d routes:\n%s", len(lines), len(lineRoutes), text)
+	}
+	if !strings.HasPrefix(lines[0], "2 routes, 1 with conflicts") {
+		t.Fatalf("unexpected summary %q", lines[0])
+	}
+	header := strings.Index(lines[3], "NAME")
+	if strings.Index(lines[4], "blog:detail") != header || strings.Index(lines[5], "blog:create") != header {
+		t.Fatalf("expected aligned columns:\n%s", text)
+	}
+	if lines[6] != "  ! shadowed by /posts/<slug:slug>/" || lineRoutes[6] != 1 {
+		t.Fatalf("expected the conflict under its route, got %q -> %d", lines[6], lineRoutes[6])
+	}
+
+	text, lineRoutes = formatRoutes(routes, "login")
+	if strings.Contains(text, "blog:detail") || lineRoutes[len(lineRoutes)-1] != 1 {
+		t.Fatalf("expected the decorator filter to keep only the create route:\n%s", text)
+	}
+	if text, _ = formatRoutes(routes, "!"); strings.Contains(text, "blog:detail") {
+		t.Fatalf("expected ! to keep only conflicting routes:\n%s", text)
+	}
+}
@@ -0,0 +1,87 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+// schemaTarget returns the model whose schema should be inspected.
+func (gui *Gui) schemaTarget() (string, string, bool) {
+	if gui.currentWindow == MainWindow && gui.currentModel != "" {
+		return gui.currentApp, gui.currentModel, true
+	}
+	if gui.currentWindow != ListWindow {
+		return "", "", false
+	}
+	models := gui.sortedModels()
+	if len(models) == 0 {
+		return "", "", false
+	}
+	model := models[clampSelection(gui.listSelection, len(models))]
+	return model.App, model.Name, true
+}
+
+func (gui *Gui) showModelSchema(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen {
+		return nil
+	}
+	app, model, ok := gui.schemaTarget()
+	if !ok {
+		return nil
+	}
+	gui.schemaApp = app
+	gui.schemaModel = model
+	return gui.loadModelSchema()
+}
+
+// toggleSchemaDDL switches the schema view between _meta only and _meta plus introspected DDL.
+func (gui *Gui) toggleSchemaDDL(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.schemaModel == "" {
+		return nil
+	}
+	gui.schemaShowDDL = !gui.schemaShowDDL
+	return gui.loadModelSchema()
+}
+
+func (gui *Gui) loadModelSchema() error {
+	app, model, includeDDL := gui.schemaApp, gui.schemaModel, gui.schemaShowDDL
+	title := fmt.Sprintf("Schema %s.%s", app, model)
+	if gui.currentModel != "" {
+		_ = gui.clearModelView()
+	}
+	tabID := gui.schemaTabID
+	if _, ok := gui.outputTabs[tabID]; ok {
+		gui.resetOutput(tabID, title)
+		gui.switchOutputTab(tabID)
+	} else {
+		tabID = gui.startCommandOutputTab(title)
+	}
+	gui.schemaTabID = tabID
+	gui.appendOutput(tabID, "Loading schema...\n")
+	gui.refreshOutputView()
+	_ = gui.switchPanel(MainWindow)
+
+	ctx, op := gui.beginContextOperation(title, tabID)
+	go func() {
+		schema, err := django.NewDataViewer(gui.project).WithContext(ctx).GetModelSchema(app, model, includeDDL)
+		gui.g.Update(func(g *gocui.Gui) error {
+			err = gui.endOperation(op, err)
+			gui.resetOutput(tabID, title)
+			if err != nil {
+				gui.appendOutput(tabID, fmt.Sprintf("Error: %v\n", err))
+				gui.rememberError("schema", err.Error())
+				gui.refreshOutputView()
+				return nil
+			}
+			gui.appendOutput(tabID, schema.String())
+			if !includeDDL {
+				gui.appendOutput(tabID, "\nPress S to include the database DDL.\n")
+			}
+			gui.refreshOutputView()
+			return nil
+		})
+	}()
+	return nil
+}
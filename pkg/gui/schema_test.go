@@ -0,0 +1,69 @@
+package gui
+
+import (
+	"io"
+	"testing"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestSchemaTargetFollowsFocusedPanel(t *testing.T) {
+	gui := &Gui{project: &django.Project{
+		RootDir: t.TempDir(),
+		Models: []django.Model{
+			{App: "blog", Name: "Post"},
+			{App: "blog", Name: "Comment"},
+		},
+	}}
+
+	gui.currentWindow = ListWindow
+	gui.listSelection = 0
+	app, model, ok := gui.schemaTarget()
+	expected := gui.sortedModels()[0]
+	if !ok || app != expected.App || model != expected.Name {
+		t.Fatalf("expected selected list model, got %s.%s ok=%v", app, model, ok)
+	}
+
+	gui.currentWindow = MainWindow
+	gui.currentApp = "shop"
+	gui.currentModel = "Order"
+	if app, model, ok := gui.schemaTarget(); !ok || app != "shop" || model != "Order" {
+		t.Fatalf("expected open model, got %s.%s ok=%v", app, model, ok)
+	}
+
+	gui.currentWindow = MenuWindow
+	if _, _, ok := gui.schemaTarget(); ok {
+		t.Fatal("expected no schema target from project panel")
+	}
+}
+
+func TestSchemaReloadReusesItsTab(t *testing.T) {
+	g, err := gocui.NewGui(gocui.OutputSimulator, true)
+	if err != nil {
+		t.Fatalf("failed to create simulated gui: %v", err)
+	}
+	screen := g.GetTestingScreen()
+	stop := screen.StartGui()
+	defer stop()
+
+	gui := &Gui{
+		g:                  g,
+		project:            &django.Project{RootDir: t.TempDir()},
+		outputTabs:         make(map[string]*outputTabState),
+		outputInputWriters: make(map[string]io.WriteCloser),
+		schemaApp:          "blog",
+		schemaModel:        "Post",
+	}
+	tabs := make(chan []string, 1)
+	g.Update(func(g *gocui.Gui) error {
+		_ = gui.loadModelSchema()
+		gui.schemaShowDDL = true
+		_ = gui.loadModelSchema()
+		tabs <- append([]string(nil), gui.outputOrder...)
+		return nil
+	})
+	if got := <-tabs; len(got) != 1 || got[0] != gui.schemaTabID {
+		t.Fatalf("expected a single schema tab, got %v", got)
+	}
+}
@@ -0,0 +1,625 @@
+package gui
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/config"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+// Dev server states shown in the Project panel
+const (
+	serverStopped   = "stopped"
+	serverStarting  = "starting"
+	serverRunning   = "running"
+	serverReloading = "reloading"
+	serverError     = "error" // autoreloader is alive but the app failed to load
+	serverStopping  = "stopping"
+	serverCrashed   = "crashed"
+)
+
+// serverStopGrace is how long runserver gets to exit after SIGTERM before its group is killed
+const serverStopGrace = 5 * time.Second
+
+// serverLogTail is how many recent log lines are kept for crash reports
+const serverLogTail = 20
+
+// Log lines that mark a server as ready, reloading, or failing, across runserver,
+// runserver_plus (werkzeug), gunicorn, uvicorn and daphne.
+var (
+	serverReadyMarkers = []string{
+		"Starting development server at ",
+		" * Running on http",
+		"Listening at: http",
+		"Uvicorn running on ",
+		"Listening on TCP address ",
+	}
+	serverReloadMarkers = []string{
+		" changed, reloading.",
+		" * Detected change in ",
+		"Worker reloading: ",
+		"detected changes in ",
+	}
+	serverFailureMarkers = []string{
+		"Error: That port is already in use",
+		"Address already in use",
+		"Worker failed to boot",
+	}
+)
+
+func containsAny(line string, markers []string) bool {
+	for _, marker := range markers {
+		if strings.Contains(line, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// devServer is one supervised server process and what its log has told us
+type devServer struct {
+	name      string // server profile name
+	cmd       *exec.Cmd
+	executor  django.Executor // built cmd; nil for make targets, which stop as a local process group
+	done      chan struct{}   // closed once cmd has been waited on
+	tabID     string
+	port      int
+	url       string
+	status    string
+	stopping  bool // exit was requested, so it is not a crash
+	restart   bool // start again once the current process exits
+	reloads   int
+	exitErr   error
+	partial   string // incomplete trailing log line
+	lastLines []string
+}
+
+func (s *devServer) running() bool {
+	return s.cmd != nil && s.cmd.Process != nil
+}
+
+// stopStartedCommand signals cmd through the executor that built it, so a server running in a
+// container stops there too and not just its docker client
+func stopStartedCommand(executor django.Executor, cmd *exec.Cmd, force bool) error {
+	if executor != nil {
+		return executor.Stop(cmd, force)
+	}
+	if force {
+		return django.KillProcessGroup(cmd)
+	}
+	return django.TerminateProcessGroup(cmd)
+}
+
+// statusLine summarizes the server for the Project panel
+func (s *devServer) statusLine() string {
+	prefix := "server"
+	if s.name != "" && s.name != django.RunnerRunserver {
+		prefix += " " + s.name
+	}
+	status := s.status
+	if status == "" {
+		status = serverStopped
+	}
+	switch status {
+	case serverStopped:
+		return prefix + ": stopped"
+	case serverCrashed:
+		if code := exitCodeFromError(s.exitErr); code > 0 {
+			return fmt.Sprintf("%s: crashed (exit %d)", prefix, code)
+		}
+		return prefix + ": crashed"
+	case serverError:
+		return fmt.Sprintf("%s: error on :%d (waiting for file changes)", prefix, s.port)
+	}
+	line := fmt.Sprintf("%s: %s :%d", prefix, status, s.port)
+	if s.cmd != nil && s.cmd.Process != nil {
+		line += fmt.Sprintf(" pid:%d", s.cmd.Process.Pid)
+	}
+	if s.reloads > 0 {
+		line += fmt.Sprintf(" reloads:%d", s.reloads)
+	}
+	return line
+}
+
+// observe updates the server state from runserver output and reports whether it changed
+func (s *devServer) observe(chunk string) bool {
+	text := s.partial + chunk
+	lines := strings.Split(text, "\n")
+	s.partial = lines[len(lines)-1]
+	before := s.status
+
+	for _, line := range lines[:len(lines)-1] {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		s.lastLines = append(s.lastLines, line)
+		if len(s.lastLines) > serverLogTail {
+			s.lastLines = s.lastLines[len(s.lastLines)-serverLogTail:]
+		}
+
+		switch {
+		case containsAny(line, serverReadyMarkers):
+			s.status = serverRunning
+			if idx := strings.Index(line, "http://"); idx >= 0 {
+				s.url = strings.Fields(line[idx:])[0]
+			}
+		case containsAny(line, serverReloadMarkers):
+			s.status = serverReloading
+			s.reloads++
+		case strings.HasPrefix(line, "Traceback (most recent call last)"),
+			containsAny(line, serverFailureMarkers):
+			if s.status != serverStopping {
+				s.status = serverError
+			}
+		}
+	}
+	return s.status != before
+}
+
+// serverTitle is the Logs tab title for a profile
+func serverTitle(name string) string {
+	if name == "" || name == django.RunnerRunserver {
+		return "Dev Server"
+	}
+	return "Server: " + name
+}
+
+// serverByName returns the tracked server for a profile, or nil
+func (gui *Gui) serverByName(name string) *devServer {
+	return gui.servers[name]
+}
+
+// runningServers lists running servers in start order
+func (gui *Gui) runningServers() []*devServer {
+	servers := make([]*devServer, 0, len(gui.servers))
+	for _, profile := range gui.serverOrder {
+		if server := gui.servers[profile]; server != nil && server.running() {
+			servers = append(servers, server)
+		}
+	}
+	return servers
+}
+
+// serverStatusLines summarizes every tracked server for the Project panel
+func (gui *Gui) serverStatusLines() []string {
+	var lines []string
+	for _, name := range gui.serverOrder {
+		server := gui.servers[name]
+		if server == nil || (server.status == serverStopped && len(gui.serverOrder) > 1) {
+			continue
+		}
+		lines = append(lines, server.statusLine())
+	}
+	if len(lines) == 0 {
+		lines = append(lines, (&devServer{}).statusLine())
+	}
+	return lines
+}
+
+func (gui *Gui) projectServerActions() []projectAction {
+	actions := []projectAction{
+		{label: "Start dev server", internal: "runserver"},
+		{label: "Restart dev server", internal: "restartserver"},
+		{label: "Stop dev server", internal: "stopserver"},
+	}
+	if gui.project == nil {
+		return actions
+	}
+
+	defaultProfile, _ := gui.project.ServerProfile("")
+	for _, profile := range gui.project.ServerProfiles() {
+		label := fmt.Sprintf("%s: %s", profile.Name, gui.project.ServerProfileLabel(profile))
+		if server := gui.serverByName(profile.Name); server != nil && server.running() {
+			actions = append(actions,
+				projectAction{label: fmt.Sprintf("Stop %s (:%d)", profile.Name, server.port), internal: "stopprofile", value: profile.Name},
+				projectAction{label: "Restart " + profile.Name, internal: "restartprofile", value: profile.Name},
+			)
+			continue
+		}
+		if profile.Name == defaultProfile.Name {
+			label += " (default)"
+		}
+		actions = append(actions, projectAction{label: "Start " + label, internal: "startprofile", value: profile.Name})
+	}
+	actions = append(actions, projectAction{label: "Default server profile...", internal: "opendefaultserver"})
+	return actions
+}
+
+func (gui *Gui) projectDefaultServerActions() []projectAction {
+	current, _ := gui.project.ServerProfile("")
+	profiles := gui.project.ServerProfiles()
+	actions := make([]projectAction, 0, len(profiles))
+	for _, profile := range profiles {
+		label := fmt.Sprintf("%s: %s", profile.Name, gui.project.ServerProfileLabel(profile))
+		if profile.Name == current.Name {
+			label += " (current)"
+		}
+		actions = append(actions, projectAction{label: label, internal: "setdefaultserver", value: profile.Name})
+	}
+	return actions
+}
+
+// setDefaultServerProfile persists the profile used by "Start dev server"
+func (gui *Gui) setDefaultServerProfile(name string) error {
+	cfg, err := config.LoadProjectConfig(gui.project.RootDir)
+	if err != nil {
+		return gui.showMessage("Dev Server", fmt.Sprintf("Failed to read %s: %v", config.ProjectConfigPath(gui.project.RootDir), err))
+	}
+	cfg.Server.Default = name
+	if err := config.SaveProjectConfig(gui.project.RootDir, cfg); err != nil {
+		gui.rememberError("server", err.Error())
+		return gui.showMessage("Dev Server", fmt.Sprintf("Failed to save default profile: %v", err))
+	}
+	gui.project.Server.Default = name
+	gui.appendHistoryEvent(historyEvent{
+		Type:   "server",
+		Source: "project",
+		Status: "ok",
+		Action: "default " + name,
+	})
+	return gui.showMessage("Dev Server", fmt.Sprintf("Start dev server now runs the %s profile.", name))
+}
+
+func (gui *Gui) renderServerStatus() {
+	if gui.g == nil {
+		return
+	}
+	if menuView, err := gui.g.View(MenuWindow); err == nil {
+		gui.renderProjectList(menuView)
+	}
+}
+
+func (gui *Gui) startServer(g *gocui.Gui, v *gocui.View) error {
+	return gui.startServerProfile("", 0)
+}
+
+// startServerProfile launches a server profile, on port when non-zero. A port already
+// taken by another process is reported instead, with options to free it or move.
+func (gui *Gui) startServerProfile(name string, port int) error {
+	profile, ok := gui.project.ServerProfile(name)
+	if !ok {
+		return gui.showMessage("Dev Server", fmt.Sprintf("Unknown server profile %q.", name))
+	}
+	if server := gui.serverByName(profile.Name); server != nil && server.running() {
+		gui.appendOutput(server.tabID, "Server is already running.\n")
+		gui.outputTab = server.tabID
+		gui.refreshOutputView()
+		_ = gui.switchPanel(MainWindow)
+		return nil
+	}
+
+	host, profilePort := gui.project.ServerProfileAddress(profile)
+	useMake := gui.serverUsesMakeTarget(profile, port)
+	if port <= 0 {
+		port = profilePort
+	}
+	// Containers and remote hosts publish their own ports, so only local servers can collide here.
+	if !gui.project.RunsRemotely() {
+		if owner := django.FindPortOwner(host, port); owner != nil {
+			return gui.showPortConflict(profile.Name, host, port, owner)
+		}
+	}
+
+	var cmd *exec.Cmd
+	var executor django.Executor
+	if useMake {
+		cmd = exec.Command("make", "runserver")
+	} else {
+		var err error
+		if cmd, err = gui.project.ServerCommand(profile, host, port); err != nil {
+			return gui.showMessage("Dev Server", fmt.Sprintf("Cannot start %s: %v", profile.Name, err))
+		}
+		executor = gui.project.ActiveExecutor()
+	}
+	cmd.Dir = gui.project.RootDir
+
+	server := &devServer{name: profile.Name, executor: executor, port: port, url: django.ServerURL(host, port), status: serverStarting}
+	gui.trackServer(server)
+	err := gui.runStreamingCommandToRoute(OutputTabLogs, serverTitle(profile.Name), cmd, gui.serverSupervisor(server), strings.Join(cmd.Args, " "), func(g *gocui.Gui, waitErr error) {
+		gui.handleServerExit(server, waitErr)
+	})
+	if !server.running() {
+		// The process never started; the output tab has the reason.
+		server.status = serverCrashed
+		gui.renderServerStatus()
+	}
+	return err
+}
+
+// serverUsesMakeTarget reports whether `make runserver` stands in for profile. The target binds
+// wherever its recipe says, so it is only used for the default profile while no address is
+// configured or requested.
+func (gui *Gui) serverUsesMakeTarget(profile config.ServerProfile, port int) bool {
+	server := gui.project.Server
+	return profile.Name == django.RunnerRunserver && len(server.Profiles) == 0 && port <= 0 &&
+		strings.TrimSpace(server.Host) == "" && server.Port <= 0 && gui.makeTargetExists("runserver")
+}
+
+func (gui *Gui) trackServer(server *devServer) {
+	if gui.servers == nil {
+		gui.servers = make(map[string]*devServer)
+	}
+	if _, ok := gui.servers[server.name]; !ok {
+		gui.serverOrder = append(gui.serverOrder, server.name)
+	}
+	gui.servers[server.name] = server
+}
+
+func (gui *Gui) showPortConflict(profile, host string, port int, owner *django.PortOwner) error {
+	gui.rememberError("server", fmt.Sprintf("port %d is in use by %s", port, owner))
+	actions := make([]projectAction, 0, 2)
+	if owner.PID > 0 {
+		actions = append(actions, projectAction{
+			label:    fmt.Sprintf("Stop PID %d and start %s on :%d", owner.PID, profile, port),
+			internal: "killportowner",
+			value:    fmt.Sprintf("%d:%d:%s", owner.PID, port, profile),
+		})
+	}
+	if free := django.FindFreePort(host, port); free > 0 {
+		actions = append(actions, projectAction{
+			label:    fmt.Sprintf("Start %s on :%d instead", profile, free),
+			internal: "runserverport",
+			value:    fmt.Sprintf("%d:%s", free, profile),
+		})
+	}
+	if len(actions) == 0 {
+		return gui.showMessage("Dev Server", fmt.Sprintf("Port %d is already in use by %s.", port, owner))
+	}
+	return gui.openProjectActionsModal(fmt.Sprintf("Port %d in use by %s", port, owner), actions)
+}
+
+// startServerOnPort handles the "start on another port" choice, encoded as port:profile
+func (gui *Gui) startServerOnPort(value string) error {
+	portText, profile, _ := strings.Cut(value, ":")
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return nil
+	}
+	return gui.startServerProfile(profile, port)
+}
+
+// stopPortOwnerAndStart terminates the process holding a port, waits for the port, then starts
+// the profile. value is pid:port:profile.
+func (gui *Gui) stopPortOwnerAndStart(value string) error {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 {
+		return nil
+	}
+	pid, pidErr := strconv.Atoi(parts[0])
+	port, portErr := strconv.Atoi(parts[1])
+	if pidErr != nil || portErr != nil {
+		return nil
+	}
+	profile := parts[2]
+	if err := django.TerminateProcess(pid); err != nil {
+		return gui.showMessage("Dev Server", fmt.Sprintf("Failed to stop PID %d: %v", pid, err))
+	}
+	host, _ := gui.project.ServerAddress()
+	if spec, ok := gui.project.ServerProfile(profile); ok {
+		host, _ = gui.project.ServerProfileAddress(spec)
+	}
+	go func() {
+		deadline := time.Now().Add(serverStopGrace)
+		for time.Now().Before(deadline) && !django.PortAvailable(host, port) {
+			time.Sleep(200 * time.Millisecond)
+		}
+		gui.g.Update(func(g *gocui.Gui) error {
+			return gui.startServerProfile(profile, port)
+		})
+	}()
+	return nil
+}
+
+// stopServer stops every running server
+func (gui *Gui) stopServer(g *gocui.Gui, v *gocui.View) error {
+	servers := gui.runningServers()
+	if len(servers) == 0 {
+		tabID := gui.startLogsOutputTab("Dev Server")
+		gui.appendOutput(tabID, "\nNo running server process.\n")
+		gui.refreshOutputView()
+		_ = gui.switchPanel(MainWindow)
+		return nil
+	}
+	for _, server := range servers {
+		gui.stopDevServer(server)
+	}
+	return nil
+}
+
+// restartServer restarts every running server, or starts the default profile when none is running
+func (gui *Gui) restartServer(g *gocui.Gui, v *gocui.View) error {
+	servers := gui.runningServers()
+	if len(servers) == 0 {
+		return gui.startServer(g, v)
+	}
+	for _, server := range servers {
+		gui.stopDevServer(server)
+		server.restart = true
+	}
+	return nil
+}
+
+func (gui *Gui) stopServerProfile(name string) error {
+	if server := gui.serverByName(name); server != nil && server.running() {
+		gui.stopDevServer(server)
+	}
+	return nil
+}
+
+func (gui *Gui) restartServerProfile(name string) error {
+	server := gui.serverByName(name)
+	if server == nil || !server.running() {
+		return gui.startServerProfile(name, 0)
+	}
+	gui.stopDevServer(server)
+	server.restart = true
+	return nil
+}
+
+// stopDevServer asks the server to exit and kills it if it lingers. Signals go through the
+// executor in the background, since reaching a container takes a docker call.
+func (gui *Gui) stopDevServer(server *devServer) {
+	cmd, executor := server.cmd, server.executor
+	server.stopping = true
+	server.status = serverStopping
+	gui.appendOutput(server.tabID, "\nStopping server...\n")
+	gui.renderServerStatus()
+	gui.refreshOutputView()
+
+	go func() {
+		if err := stopStartedCommand(executor, cmd, false); err != nil {
+			gui.g.Update(func(g *gocui.Gui) error {
+				gui.appendOutput(server.tabID, fmt.Sprintf("Failed to stop server: %v\n", err))
+				gui.refreshOutputView()
+				return nil
+			})
+		}
+	}()
+
+	// Escalate if the server or its autoreloader child ignores SIGTERM.
+	time.AfterFunc(serverStopGrace, func() {
+		gui.g.Update(func(g *gocui.Gui) error {
+			if server.cmd != cmd {
+				return nil
+			}
+			gui.appendOutput(server.tabID, fmt.Sprintf("Server did not exit within %s; killing it.\n", serverStopGrace))
+			gui.refreshOutputView()
+			go func() { _ = stopStartedCommand(executor, cmd, true) }()
+			return nil
+		})
+	})
+}
+
+// serverStarted is called by the streaming runner once the server process is running
+func (gui *Gui) serverStarted(server *devServer, cmd *exec.Cmd, tabID string) chan struct{} {
+	server.cmd = cmd
+	server.tabID = tabID
+	server.done = make(chan struct{})
+	gui.appendHistoryEvent(historyEvent{
+		Type:      "server",
+		Source:    "project",
+		Status:    "started",
+		Action:    "start " + server.name,
+		Command:   strings.Join(cmd.Args, " "),
+		OutputTab: tabID,
+	})
+	gui.renderServerStatus()
+	return server.done
+}
+
+func (gui *Gui) serverSupervisor(server *devServer) *streamSupervisor {
+	return &streamSupervisor{
+		started: func(cmd *exec.Cmd, tabID string) chan struct{} {
+			return gui.serverStarted(server, cmd, tabID)
+		},
+		output: func(chunk string) {
+			gui.observeServerOutput(server, chunk)
+		},
+	}
+}
+
+// observeServerOutput feeds server output into its supervisor
+func (gui *Gui) observeServerOutput(server *devServer, chunk string) {
+	if server.observe(chunk) {
+		gui.renderServerStatus()
+	}
+}
+
+// handleServerExit records how a server ended and restarts it when requested
+func (gui *Gui) handleServerExit(server *devServer, waitErr error) {
+	server.cmd = nil
+	server.done = nil
+	server.exitErr = waitErr
+
+	status := "stopped"
+	if server.stopping || errors.Is(waitErr, context.Canceled) {
+		server.status = serverStopped
+	} else {
+		status = "crashed"
+		server.status = serverCrashed
+		gui.appendOutput(server.tabID, fmt.Sprintf("\n%s exited unexpectedly.", serverTitle(server.name)))
+		if len(server.lastLines) > 0 {
+			gui.appendOutput(server.tabID, " Last output:\n")
+			for _, line := range server.lastLines {
+				gui.appendOutput(server.tabID, "  "+line+"\n")
+			}
+		} else {
+			gui.appendOutput(server.tabID, "\n")
+		}
+		message := fmt.Sprintf("%s exited unexpectedly", server.name)
+		if waitErr != nil {
+			message += ": " + waitErr.Error()
+		}
+		gui.rememberError("server", message)
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:      "server",
+		Source:    "project",
+		Status:    status,
+		Action:    "exit " + server.name,
+		ExitCode:  exitCodeFromError(waitErr),
+		OutputTab: server.tabID,
+		Error:     safeErrorMessage(waitErr),
+	})
+
+	if server.restart {
+		_ = gui.startServerProfile(server.name, server.port)
+		return
+	}
+	gui.renderServerStatus()
+}
+
+// quitGroup is a started process group to stop on quit; done is closed once it has been waited
+// on. executor, when set, is the one that built cmd.
+type quitGroup struct {
+	cmd      *exec.Cmd
+	executor django.Executor
+	done     <-chan struct{}
+}
+
+// terminateOnQuit asks every group to exit, waits for them against one shared deadline, and
+// kills the groups still running once it has passed, so quitting takes about grace at most
+func terminateOnQuit(groups []quitGroup, grace time.Duration) {
+	deadline := time.Now().Add(grace)
+	signalQuitGroups(groups, false)
+	var lingering []quitGroup
+	for _, group := range groups {
+		if remaining := time.Until(deadline); remaining > 0 {
+			timer := time.NewTimer(remaining)
+			select {
+			case <-group.done:
+				timer.Stop()
+				continue
+			case <-timer.C:
+			}
+		}
+		select {
+		case <-group.done:
+		default:
+			lingering = append(lingering, group)
+		}
+	}
+	signalQuitGroups(lingering, true)
+}
+
+// signalQuitGroups signals every group at once, since container backends need a docker call each
+func signalQuitGroups(groups []quitGroup, force bool) {
+	var wg sync.WaitGroup
+	for _, group := range groups {
+		wg.Add(1)
+		go func(group quitGroup) {
+			defer wg.Done()
+			_ = stopStartedCommand(group.executor, group.cmd, force)
+		}(group)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,126 @@
+package gui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/config"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestDevServerObserveTracksReloadsAndErrors(t *testing.T) {
+	server := devServer{port: 8000, status: serverStarting}
+
+	if !server.observe("Watching for file changes with StatReloader\nStarting development server at http://127.0.0.1:8000/\nQuit the server with CONTROL-C.\n") {
+		t.Fatal("expected the startup banner to change the status")
+	}
+	if server.status != serverRunning || server.url != "http://127.0.0.1:8000/" {
+		t.Fatalf("expected running at the banner URL, got %+v", server)
+	}
+
+	// Lines split across chunks are joined before matching.
+	server.observe("/app/blog/views.py changed, rel")
+	if server.status != serverRunning {
+		t.Fatalf("did not expect a partial line to change the status, got %q", server.status)
+	}
+	server.observe("oading.\nTraceback (most recent call last):\n")
+	if server.reloads != 1 || server.status != serverError {
+		t.Fatalf("expected one reload followed by an error, got %+v", server)
+	}
+	if !strings.Contains(server.statusLine(), "waiting for file changes") {
+		t.Fatalf("unexpected status line %q", server.statusLine())
+	}
+
+	for i := 0; i < serverLogTail+5; i++ {
+		server.observe(fmt.Sprintf("line %d\n", i))
+	}
+	if len(server.lastLines) != serverLogTail || server.lastLines[len(server.lastLines)-1] != fmt.Sprintf("line %d", serverLogTail+4) {
+		t.Fatalf("expected the last %d lines to be kept, got %v", serverLogTail, server.lastLines)
+	}
+}
+
+func TestDevServerStatusLine(t *testing.T) {
+	cases := []struct {
+		server devServer
+		want   string
+	}{
+		{devServer{}, "server: stopped"},
+		{devServer{status: serverCrashed, exitErr: fmt.Errorf("boom")}, "server: crashed"},
+		{devServer{status: serverReloading, port: 8001, reloads: 3}, "server: reloading :8001 reloads:3"},
+	}
+	for _, tc := range cases {
+		if got := tc.server.statusLine(); got != tc.want {
+			t.Errorf("statusLine() = %q, want %q", got, tc.want)
+		}
+	}
+}
+
+func TestDevServerObserveRecognizesOtherRunners(t *testing.T) {
+	banners := map[string]string{
+		"uvicorn":        "INFO:     Uvicorn running on http://127.0.0.1:8001 (Press CTRL+C to quit)\n",
+		"gunicorn":       "[INFO] Listening at: http://127.0.0.1:8002 (1234)\n",
+		"runserver_plus": " * Running on http://127.0.0.1:8003\n",
+	}
+	for name, banner := range banners {
+		server := devServer{name: name, status: serverStarting}
+		server.observe(banner)
+		if server.status != serverRunning || !strings.HasPrefix(server.url, "http://127.0.0.1:800") {
+			t.Fatalf("%s: expected running with banner URL, got %+v", name, server)
+		}
+		if !strings.HasPrefix(server.statusLine(), "server "+name+": running") {
+			t.Fatalf("%s: unexpected status line %q", name, server.statusLine())
+		}
+	}
+}
+
+func TestTerminateOnQuitSharesOneDeadline(t *testing.T) {
+	exited := make(chan struct{})
+	close(exited)
+	// Neither hung group ever closes done; the old per-iteration wait blocked forever on the second.
+	groups := []quitGroup{
+		{cmd: &exec.Cmd{}, done: make(chan struct{})},
+		{cmd: &exec.Cmd{}, done: exited},
+		{cmd: &exec.Cmd{}, done: make(chan struct{})},
+	}
+
+	finished := make(chan time.Duration, 1)
+	started := time.Now()
+	go func() {
+		terminateOnQuit(groups, 200*time.Millisecond)
+		finished <- time.Since(started)
+	}()
+	select {
+	case elapsed := <-finished:
+		if elapsed >= 400*time.Millisecond {
+			t.Fatalf("expected one shared grace period, took %v", elapsed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("terminateOnQuit did not return")
+	}
+}
+
+func TestServerUsesMakeTargetOnlyForTheDefaultAddress(t *testing.T) {
+	gui := &Gui{
+		project:           &django.Project{},
+		makeTargetsLoaded: true,
+		makeTargets:       []makeTarget{{name: "runserver"}},
+	}
+	runserver := config.ServerProfile{Name: django.RunnerRunserver}
+	if !gui.serverUsesMakeTarget(runserver, 0) {
+		t.Fatal("expected make runserver for the default profile and address")
+	}
+	if gui.serverUsesMakeTarget(runserver, 8001) {
+		t.Fatal("did not expect make runserver when another port is requested")
+	}
+	gui.project.Server.Port = 9000
+	if gui.serverUsesMakeTarget(runserver, 0) {
+		t.Fatal("did not expect make runserver when a port is configured")
+	}
+	gui.project.Server = config.ServerConfig{Host: "0.0.0.0"}
+	if gui.serverUsesMakeTarget(runserver, 0) {
+		t.Fatal("did not expect make runserver when a host is configured")
+	}
+}
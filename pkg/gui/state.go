@@ -485,9 +485,8 @@ func (gui *Gui) recordCommandExecution(command, tabID string, startedAt time.Tim
 	if tab, ok := gui.outputTabs[tabID]; ok {
 		route = tab.route
 	}
-	status := "success"
-	if runErr != nil {
-		status = "error"
+	status := operationStatus(runErr)
+	if runErr != nil && status != "cancelled" {
 		gui.rememberError("command", fmt.Sprintf("%s: %v", command, runErr))
 	}
 
@@ -532,9 +531,8 @@ func (gui *Gui) recordContainerAction(action string, services []string, startedA
 }
 
 func (gui *Gui) recordSnapshotActivity(action, snapshotID, snapshotName string, runErr error) {
-	status := "success"
-	if runErr != nil {
-		status = "error"
+	status := operationStatus(runErr)
+	if runErr != nil && status != "cancelled" {
 		gui.rememberError("snapshot", runErr.Error())
 	}
 	gui.appendHistoryEvent(historyEvent{
@@ -684,6 +682,7 @@ func (gui *Gui) restoreOutputTabsFromState(savedTabs []persistedOutputTab, activ
 			autoscroll: saved.Autoscroll,
 			originX:    saved.OriginX,
 			originY:    saved.OriginY,
+			created:    time.Now(),
 		}
 		if tab.autoscroll {
 			tab.originX = 0
@@ -59,6 +59,52 @@ func resolveEditorCommand() string {
 	return "vi"
 }
 
+// editorGotoCommand builds an editor invocation that opens path at line
+func editorGotoCommand(editor, path string, line int) string {
+	base := strings.ToLower(filepath.Base(strings.Fields(editor)[0]))
+	switch base {
+	case "code", "code-insiders", "codium", "cursor":
+		return fmt.Sprintf("%s -g %q", editor, fmt.Sprintf("%s:%d", path, line))
+	case "subl", "zed", "hx", "helix":
+		return fmt.Sprintf("%s %q", editor, fmt.Sprintf("%s:%d", path, line))
+	case "idea", "pycharm", "charm":
+		return fmt.Sprintf("%s --line %d %q", editor, line, path)
+	default:
+		// vi, vim, nvim, nano, emacs, kak, micro and most others take +line.
+		return fmt.Sprintf("%s +%d %q", editor, line, path)
+	}
+}
+
+// openFileInEditor runs terminal editors in this terminal and GUI editors in the background.
+// Failures are reported in a message titled after the caller.
+func (gui *Gui) openFileInEditor(title, path string, line int) error {
+	editor := resolveEditorCommand()
+	command := editorGotoCommand(editor, path, line)
+
+	if isTerminalEditorCommand(editor) {
+		err := runCommandInCurrentTerminal(command, gui.project.RootDir)
+		if err == nil {
+			return nil
+		}
+		if runtime.GOOS != "darwin" {
+			return gui.showMessage(title, fmt.Sprintf("Failed to open editor in current terminal: %v", err))
+		}
+		terminalCommand := fmt.Sprintf("cd %q && %s", gui.project.RootDir, command)
+		if launchErr := launchEditorInPreferredTerminal(terminalCommand); launchErr != nil {
+			return gui.showMessage(title, fmt.Sprintf("Failed to launch terminal editor: %v\nOpen manually: %s:%d", launchErr, path, line))
+		}
+		return nil
+	}
+
+	cmd := exec.Command("sh", "-lc", command)
+	cmd.Dir = gui.project.RootDir
+	if err := cmd.Start(); err != nil {
+		return gui.showMessage(title, fmt.Sprintf("Failed to launch editor: %v\nOpen manually: %s:%d", err, path, line))
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
 func runAppleScript(script string) error {
 	cmd := exec.Command("osascript", "-e", script)
 	output, err := cmd.CombinedOutput()
@@ -125,6 +171,11 @@ func launchEditorInPreferredTerminal(command string) error {
 func runCommandInCurrentTerminal(command string, dir string) error {
 	cmd := exec.Command("sh", "-lc", command)
 	cmd.Dir = dir
+	return runInCurrentTerminal(cmd)
+}
+
+// runInCurrentTerminal suspends the UI and hands the terminal to cmd until it exits
+func runInCurrentTerminal(cmd *exec.Cmd) error {
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -367,3 +418,22 @@ func (gui *Gui) editProjectTasksFile() error {
 	msg := fmt.Sprintf("Opened in editor: %s\nRun 'r' to reload tasks after edits.", path)
 	return gui.showMessage("Project Tasks", msg)
 }
+
+// addProjectTask appends a task to the project tasks file and refreshes the Project panel
+func (gui *Gui) addProjectTask(task projectTaskEntry) error {
+	tasks, err := gui.loadProjectTasks(false)
+	if err != nil {
+		return err
+	}
+	tasks = append(append([]projectTaskEntry(nil), tasks...), task)
+	if err := gui.writeProjectTasks(tasks); err != nil {
+		return err
+	}
+	if _, err := gui.loadProjectTasks(true); err != nil {
+		return err
+	}
+	if menuView, err := gui.g.View(MenuWindow); err == nil {
+		gui.renderProjectList(menuView)
+	}
+	return nil
+}
@@ -94,3 +94,17 @@ func TestLoadProjectTasksUsesExistingFile(t *testing.T) {
 		t.Fatalf("expected custom command to be preserved, got %q", tasks[0].Command)
 	}
 }
+
+func TestEditorGotoCommand(t *testing.T) {
+	cases := map[string]string{
+		"nvim":        `nvim +42 "/app/views.py"`,
+		"code --wait": `code --wait -g "/app/views.py:42"`,
+		"hx":          `hx "/app/views.py:42"`,
+		"pycharm":     `pycharm --line 42 "/app/views.py"`,
+	}
+	for editor, want := range cases {
+		if got := editorGotoCommand(editor, "/app/views.py", 42); got != want {
+			t.Errorf("editorGotoCommand(%q) = %q, want %q", editor, got, want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package gui
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+const testResultsTitle = "Test Results"
+
+// testRun tracks the test run in flight and the results view built from the last one
+type testRun struct {
+	tabID     string
+	startedAt time.Time
+	covered   bool // The run in flight is wrapped in `coverage run`
+	report    *django.TestReport
+
+	resultsTabID string
+	lineTests    []string // Test id for each line of the results tab, "" for headings
+
+	coverage      *django.CoverageReport
+	coverageSort  string
+	coverageTabID string
+	coverageLines []string // File path for each line of the coverage tab, "" for other lines
+}
+
+func (gui *Gui) projectTestActions() []projectAction {
+	runner := "manage.py test"
+	if gui.project.TestRunner() == django.TestRunnerPytest {
+		runner = "pytest"
+	}
+	actions := []projectAction{
+		{label: "Run all tests (" + runner + ")", internal: "runtests"},
+		{label: "Run all tests with coverage", internal: "runtestscoverage"},
+		{label: "Run tests matching keyword...", internal: "runtestskeyword"},
+	}
+	if gui.tests.report != nil {
+		if failed := gui.tests.report.FailedIDs(); len(failed) > 0 {
+			actions = append(actions, projectAction{label: fmt.Sprintf("Rerun failed tests (%d)", len(failed)), internal: "rerunfailedtests"})
+		}
+		actions = append(actions, projectAction{label: "Show last results", internal: "showtestresults"})
+	}
+	if gui.tests.coverage != nil {
+		actions = append(actions,
+			projectAction{label: "Show coverage", internal: "showcoverage"},
+			projectAction{label: "Toggle coverage order (worst first / by path)", internal: "coveragesort"},
+		)
+	}
+	if id := gui.testUnderCursor(); id != "" {
+		actions = append(actions, projectAction{label: "Run " + id, internal: "runtest", value: id})
+	}
+	return actions
+}
+
+// runTests streams a test run into a Command tab and parses the results when it exits
+func (gui *Gui) runTests(opts django.TestRunOptions) error {
+	if gui.tests.tabID != "" {
+		return gui.showMessage("Tests", "A test run is already in progress. Press X to cancel it.")
+	}
+	cmd := gui.project.TestCommand(context.Background(), opts)
+	display := strings.Join(cmd.Args, " ")
+	supervisor := &streamSupervisor{
+		started: func(cmd *exec.Cmd, tabID string) chan struct{} {
+			gui.tests.tabID = tabID
+			gui.tests.startedAt = time.Now()
+			gui.tests.covered = opts.Coverage
+			return nil
+		},
+	}
+	return gui.runStreamingCommandToRoute(OutputTabCommand, "Tests", cmd, supervisor, display, func(g *gocui.Gui, waitErr error) {
+		gui.handleTestRunExit(waitErr)
+	})
+}
+
+// rerunFailedTests runs the failed set of the last run
+func (gui *Gui) rerunFailedTests() error {
+	if gui.tests.report == nil {
+		return gui.showMessage("Tests", "Run the tests first.")
+	}
+	failed := gui.tests.report.FailedIDs()
+	if len(failed) == 0 {
+		return gui.showMessage("Tests", "No failed tests in the last run.")
+	}
+	if gui.project.TestRunner() == django.TestRunnerPytest {
+		return gui.runTests(django.TestRunOptions{Failed: true})
+	}
+	return gui.runTests(django.TestRunOptions{Labels: failed})
+}
+
+// openTestKeywordInput asks for a -k expression
+func (gui *Gui) openTestKeywordInput() error {
+	gui.inputMode = "test-keyword"
+	gui.inputReturnWindow = MainWindow
+	maxX, maxY := gui.g.Size()
+	return gui.layoutInputPrompt(gui.g, maxX, maxY)
+}
+
+func (gui *Gui) runTestsMatching(keyword string) error {
+	keyword = strings.TrimSpace(keyword)
+	if keyword == "" {
+		return nil
+	}
+	return gui.runTests(django.TestRunOptions{Keyword: keyword})
+}
+
+func (gui *Gui) handleTestRunExit(waitErr error) {
+	tabID := gui.tests.tabID
+	gui.tests.tabID = ""
+	if tabID == "" {
+		return
+	}
+	report := gui.project.ReadTestReport(stripANSI(gui.outputTextForTab(tabID)), gui.tests.startedAt)
+	if gui.tests.covered {
+		gui.exportCoverage()
+	}
+	if len(report.Results) == 0 {
+		if waitErr != nil {
+			gui.rememberError("tests", "test run failed before reporting results")
+		}
+		return
+	}
+	gui.tests.report = report
+	if failed := report.FailedIDs(); len(failed) > 0 {
+		gui.rememberError("tests", fmt.Sprintf("%d failed: %s", len(failed), failed[0]))
+	}
+	_ = gui.showTestResults()
+}
+
+// showTestResults renders the last report as a tree in the Test Results tab
+func (gui *Gui) showTestResults() error {
+	if gui.tests.report == nil {
+		return gui.showMessage("Tests", "No test results yet.")
+	}
+	text, lineTests := formatTestReport(gui.tests.report)
+	tabID := gui.tests.resultsTabID
+	if _, ok := gui.outputTabs[tabID]; ok {
+		gui.resetOutput(tabID, testResultsTitle)
+		gui.switchOutputTab(tabID)
+	} else {
+		tabID = gui.startCommandOutputTab(testResultsTitle)
+	}
+	gui.tests.resultsTabID = tabID
+	gui.tests.lineTests = lineTests
+	gui.outputTabs[tabID].originY = 0
+	gui.appendOutput(tabID, text)
+	_ = gui.switchPanel(MainWindow)
+	gui.refreshOutputView()
+	return nil
+}
+
+// testUnderCursor returns the test on the selected (or top visible) line of the Test Results tab
+func (gui *Gui) testUnderCursor() string {
+	tabID := gui.tests.resultsTabID
+	if tabID == "" || gui.resolveOutputTabID("", false) != tabID {
+		return ""
+	}
+	line := gui.outputCursorLine(tabID)
+	if line < 0 || line >= len(gui.tests.lineTests) {
+		return ""
+	}
+	return gui.tests.lineTests[line]
+}
+
+// runTestUnderCursor reruns the test under the cursor in the Test Results tab
+func (gui *Gui) runTestUnderCursor() error {
+	id := gui.testUnderCursor()
+	if id == "" {
+		return nil
+	}
+	return gui.runTests(django.TestRunOptions{Labels: []string{id}})
+}
+
+var testStatusMarks = map[string]string{
+	django.TestPassed:  "✓",
+	django.TestFailed:  "✗",
+	django.TestError:   "E",
+	django.TestSkipped: "-",
+}
+
+// formatTestReport renders results grouped by module and class, followed by failure details.
+// lineTests maps each output line to the test it belongs to.
+func formatTestReport(report *django.TestReport) (string, []string) {
+	results := append([]django.TestResult(nil), report.Results...)
+	django.SortTestResults(results)
+
+	var lines, lineTests []string
+	add := func(id, line string) {
+		lines = append(lines, line)
+		lineTests = append(lineTests, id)
+	}
+
+	counts := report.Counts()
+	var total time.Duration
+	for _, result := range results {
+		total += result.Duration
+	}
+	summary := fmt.Sprintf("%s: %d passed, %d failed, %d errors, %d skipped",
+		report.Runner, counts[django.TestPassed], counts[django.TestFailed], counts[django.TestError], counts[django.TestSkipped])
+	if total > 0 {
+		summary += fmt.Sprintf(" in %s", formatTestDuration(total))
+	}
+	add("", summary)
+	add("", "Enter on a test reruns it.")
+
+	module, class := "", ""
+	for i, result := range results {
+		if i == 0 || result.Module != module {
+			module, class = result.Module, ""
+			add("", "")
+			add("", result.Module)
+		}
+		indent := "  "
+		if result.Class != "" {
+			if result.Class != class {
+				class = result.Class
+				add("", "  "+class)
+			}
+			indent = "    "
+		}
+		line := fmt.Sprintf("%s%s %s", indent, testStatusMarks[result.Status], result.Name)
+		if result.Duration > 0 {
+			line += "  " + formatTestDuration(result.Duration)
+		}
+		add(result.ID, line)
+		if result.Message != "" && result.Status != django.TestPassed {
+			add(result.ID, indent+"    "+firstLine(result.Message))
+		}
+	}
+
+	header := false
+	for _, result := range results {
+		if result.Details == "" || (result.Status != django.TestFailed && result.Status != django.TestError) {
+			continue
+		}
+		if !header {
+			add("", "")
+			add("", "Failures")
+			header = true
+		}
+		add("", "")
+		add(result.ID, fmt.Sprintf("==== %s (%s) ====", result.ID, result.Status))
+		for _, detail := range strings.Split(result.Details, "\n") {
+			add(result.ID, detail)
+		}
+	}
+	return strings.Join(lines, "\n") + "\n", lineTests
+}
+
+func formatTestDuration(d time.Duration) string {
+	if d < time.Second {
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	return line
+}
@@ -0,0 +1,43 @@
+package gui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+func TestFormatTestReport(t *testing.T) {
+	report := &django.TestReport{Runner: django.TestRunnerPytest, Results: []django.TestResult{
+		{ID: "tests/test_api.py::TestDetail::test_get", Module: "tests/test_api.py", Class: "TestDetail", Name: "test_get", Status: django.TestFailed, Duration: 20 * time.Millisecond, Message: "assert 404 == 200", Details: "tests/test_api.py:9: AssertionError"},
+		{ID: "tests/test_api.py::test_list", Module: "tests/test_api.py", Name: "test_list", Status: django.TestPassed, Duration: 1500 * time.Millisecond},
+	}}
+	text, lineTests := formatTestReport(report)
+	lines := outputLines(strings.TrimSuffix(text, "\n"))
+	if len(lines) != len(lineTests) {
+		t.Fatalf("expected a test id per line, got %d lines and %d ids", len(lines), len(lineTests))
+	}
+	if !strings.HasPrefix(lines[0], "pytest: 1 passed, 1 failed, 0 errors, 0 skipped in 1.52s") {
+		t.Fatalf("unexpected summary %q", lines[0])
+	}
+
+	find := func(text string) int {
+		for i, line := range lines {
+			if strings.Contains(line, text) {
+				return i
+			}
+		}
+		t.Fatalf("%q not in report:\n%s", text, strings.Join(lines, "\n"))
+		return -1
+	}
+	if i := find("✗ test_get  20ms"); lineTests[i] != "tests/test_api.py::TestDetail::test_get" || !strings.HasPrefix(lines[i], "    ") {
+		t.Fatalf("expected the failed test nested under its class, got %q -> %q", lines[i], lineTests[i])
+	}
+	if i := find("TestDetail"); lineTests[i] != "" {
+		t.Fatalf("expected class headings to have no test, got %q", lineTests[i])
+	}
+	if i := find("tests/test_api.py:9: AssertionError"); lineTests[i] != "tests/test_api.py::TestDetail::test_get" {
+		t.Fatalf("expected failure details to map to their test, got %q", lineTests[i])
+	}
+}
@@ -0,0 +1,139 @@
+package gui
+
+import (
+	"fmt"
+
+	"github.com/awesome-gocui/gocui"
+	"github.com/williamblackie/lazydjango/pkg/django"
+)
+
+// tracebackFrames returns the frames printed in an output tab
+func (gui *Gui) tracebackFrames(tabID string) []django.TracebackFrame {
+	return django.ParseTracebackFrames(outputLines(stripANSI(gui.outputTextForTab(tabID))))
+}
+
+// nextTracebackFrame selects the next frame up the stack, starting from the innermost frame of
+// the last traceback and wrapping around. Project frames are preferred over library frames.
+func (gui *Gui) nextTracebackFrame(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentModel != "" {
+		return nil
+	}
+	tabID := gui.resolveOutputTabID("", false)
+	if tabID == "" {
+		return nil
+	}
+	frames := gui.tracebackFrames(tabID)
+	if len(frames) == 0 {
+		return gui.showMessage("Traceback", "No traceback frames in this output tab.")
+	}
+
+	project := make([]int, 0, len(frames))
+	for i, frame := range frames {
+		if !django.IsLibraryPath(frame.Path) {
+			project = append(project, i)
+		}
+	}
+	candidates := project
+	if len(candidates) == 0 {
+		candidates = make([]int, len(frames))
+		for i := range frames {
+			candidates[i] = i
+		}
+	}
+
+	next := candidates[len(candidates)-1]
+	if gui.frameTabID == tabID {
+		for i := len(candidates) - 1; i >= 0; i-- {
+			if candidates[i] < gui.frameIndex {
+				next = candidates[i]
+				break
+			}
+		}
+	}
+	gui.frameTabID = tabID
+	gui.frameIndex = next
+	_ = gui.switchPanel(MainWindow)
+	gui.showTracebackFrame(tabID, frames[next])
+	return nil
+}
+
+// showTracebackFrame scrolls to a frame and highlights it, expanding a folded Logs traceback
+func (gui *Gui) showTracebackFrame(tabID string, frame django.TracebackFrame) {
+	tab, ok := gui.outputTabs[tabID]
+	if !ok {
+		return
+	}
+	findRow := func() (int, outputRow) {
+		for i, r := range gui.outputRows(tabID) {
+			if frame.Index >= r.start && frame.Index <= r.end {
+				return i, r
+			}
+		}
+		return -1, outputRow{}
+	}
+	row, r := findRow()
+	if row >= 0 && r.traceback >= 0 && r.start != r.end {
+		tab.logState().expanded[r.traceback] = true
+		row, _ = findRow()
+	}
+	if row < 0 {
+		// Filtered out of the current log view.
+		return
+	}
+
+	gui.outputSelectMode = true
+	gui.outputSelectTabID = tabID
+	gui.outputSelectAnchor = row
+	gui.outputSelectCursor = row
+	gui.ensureOutputSelectionVisible(tabID)
+	gui.refreshOutputView()
+}
+
+// selectedTracebackFrame is the frame under the selection cursor, or the last frame visited
+func (gui *Gui) selectedTracebackFrame(tabID string) (django.TracebackFrame, bool) {
+	frames := gui.tracebackFrames(tabID)
+	if len(frames) == 0 {
+		return django.TracebackFrame{}, false
+	}
+	if gui.outputSelectMode && gui.outputSelectTabID == tabID {
+		rows := gui.outputRows(tabID)
+		if len(rows) > 0 {
+			row := rows[clampSelection(gui.outputSelectCursor, len(rows))]
+			for _, frame := range frames {
+				if frame.Index >= row.start && frame.Index <= row.end {
+					return frame, true
+				}
+			}
+		}
+	}
+	if gui.frameTabID == tabID && gui.frameIndex < len(frames) {
+		return frames[gui.frameIndex], true
+	}
+	return django.TracebackFrame{}, false
+}
+
+// openTracebackFrame opens the selected frame's file at its line in $VISUAL/$EDITOR
+func (gui *Gui) openTracebackFrame(g *gocui.Gui, v *gocui.View) error {
+	if gui.isModalOpen || gui.currentModel != "" {
+		return nil
+	}
+	tabID := gui.resolveOutputTabID("", false)
+	if tabID == "" {
+		return nil
+	}
+	frame, ok := gui.selectedTracebackFrame(tabID)
+	if !ok {
+		return gui.showMessage("Traceback", "Select a frame first: press T to cycle through traceback frames.")
+	}
+	path, ok := gui.project.HostPath(frame.Path)
+	if !ok {
+		return gui.showMessage("Traceback", fmt.Sprintf("%s is not available on this machine.\nCheck the volume mapping for the %q service.", frame.Path, gui.project.DockerService))
+	}
+	gui.appendHistoryEvent(historyEvent{
+		Type:   "editor",
+		Source: "output",
+		Status: "ok",
+		Action: fmt.Sprintf("%s:%d", path, frame.Line),
+	})
+	return gui.openFileInEditor("Traceback", path, frame.Line)
+}